@@ -2,7 +2,10 @@ package app
 
 import (
 	"fmt"
+	"log"
 	"math"
+	"strings"
+	"time"
 
 	kallax "gopkg.in/src-d/go-kallax.v1"
 )
@@ -14,21 +17,70 @@ func CreateUser(helper HTTPHelper, handler UserHandler) {
 	}
 
 	saveUser := func(v interface{}) (interface{}, error) {
-		return handler.SaveUser(v.(User)), nil
+		user := v.(User)
+		user, err := handler.SaveUser(user, UserRegisteredEvent{UserID: user.ID, Login: user.Login, At: time.Now()})
+		if err != nil {
+			return nil, err
+		}
+
+		return NewUserView(user), nil
 	}
 
 	helper.Process(&UserCreationData{}, createUser, saveUser)
 }
 
+//ClaimUser registers the anonymous user of the session and rotates the session
+//to a registered one.
+func ClaimUser(helper HTTPHelper, userHandler UserHandler, sessionHandler SessionHandler) {
+	checkAnonymous := func(v interface{}) (interface{}, error) {
+		if helper.IsRegisteredUser() {
+			return nil, ErrConflict("User already registered.")
+		}
+
+		return v, nil
+	}
+
+	claimUser := func(v interface{}) (interface{}, error) {
+		data := v.(*UserCreationData)
+		registered := UserRegisteredEvent{UserID: helper.LoggedUserID(), Login: data.Login, At: time.Now()}
+		return userHandler.ClaimAnonUser(helper.LoggedUserID(), data, registered)
+	}
+
+	rotateSession := func(v interface{}) (interface{}, error) {
+		view, err := createSessionView(sessionHandler, v.(*User))
+		if err != nil {
+			return nil, err
+		}
+
+		ID, err := helper.GetRequestSessionID()
+		if err != nil {
+			return nil, err
+		}
+
+		oldSessionID, err := kallax.NewULIDFromText(ID)
+		if err != nil {
+			return nil, ErrValidation(err.Error())
+		}
+
+		if err := sessionHandler.DeleteSession(oldSessionID); err != nil {
+			return nil, err
+		}
+
+		return view, nil
+	}
+
+	ExecuteSessioned(helper, &UserCreationData{}, checkAnonymous, claimUser, rotateSession)
+}
+
 //Visit ...
 func Visit(helper HTTPHelper, userHandler UserHandler, sessionHandler SessionHandler) {
 	createAnonUser := func(v interface{}) (interface{}, error) {
-		return userHandler.CreateAnonUser(), nil
+		return userHandler.CreateAnonUser()
 	}
 
 	createSession := func(v interface{}) (interface{}, error) {
 		user := v.(User)
-		return sessionHandler.CreateSession(user.ID, user.IsRegistered()), nil
+		return createSessionView(sessionHandler, &user)
 	}
 
 	helper.Process(nil, createAnonUser, createSession)
@@ -42,25 +94,71 @@ func Login(helper HTTPHelper, userHandler UserHandler, sessionHandler SessionHan
 	}
 
 	createSession := func(v interface{}) (interface{}, error) {
-		user := v.(*User)
-		return sessionHandler.CreateSession(user.ID, user.IsRegistered()), nil
+		return createSessionView(sessionHandler, v.(*User))
 	}
 
 	helper.Process(&LoginData{}, findUser, createSession)
 }
 
+func createSessionView(sessionHandler SessionHandler, user *User) (interface{}, error) {
+	session, err := sessionHandler.CreateSession(user.ID, user.IsRegistered())
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSessionView(session), nil
+}
+
+//Logout ...
+func Logout(helper HTTPHelper, sessionHandler SessionHandler) {
+	deleteSession := func(v interface{}) (interface{}, error) {
+		ID, err := helper.GetRequestSessionID()
+		if err != nil {
+			return nil, err
+		}
+
+		sessionID, err := kallax.NewULIDFromText(ID)
+		if err != nil {
+			return nil, ErrValidation(err.Error())
+		}
+
+		return struct{}{}, sessionHandler.DeleteSession(sessionID)
+	}
+
+	ExecuteSessioned(helper, nil, deleteSession)
+}
+
 //StartCreatePoll ...
 func StartCreatePoll(helper HTTPHelper, pollHandler PollHandler) {
+	validate := func(v interface{}) (interface{}, error) {
+		return v, ValidateCreatePollData(v.(*CreatePollData))
+	}
+
 	createPoll := func(v interface{}) (interface{}, error) {
 		data := v.(*CreatePollData)
-		return pollHandler.SavePoll(Poll{
-			ID:      kallax.NewULID(),
-			Name:    data.Name,
-			Options: make([]*PollOption, 0),
-			Owner:   helper.LoggedUserID(),
-		}), nil
+		poll := Poll{
+			ID:           kallax.NewULID(),
+			Name:         data.Name,
+			Options:      make([]*PollOption, 0),
+			Owner:        helper.LoggedUserID(),
+			Status:       PollDraft,
+			OpensAt:      data.OpensAt,
+			ClosesAt:     data.ClosesAt,
+			MinChoices:   data.MinChoices,
+			MaxChoices:   data.MaxChoices,
+			VotingMethod: data.VotingMethod,
+			MaxScore:     data.MaxScore,
+		}
+
+		poll, err := pollHandler.SavePoll(poll,
+			PollCreatedEvent{PollID: poll.ID, Owner: poll.Owner, Name: poll.Name, At: time.Now()})
+		if err != nil {
+			return nil, err
+		}
+
+		return NewPollView(&poll, helper.LoggedUserID()), nil
 	}
-	ExecuteAuthenticated(helper, &CreatePollData{}, createPoll)
+	ExecuteAuthenticated(helper, &CreatePollData{}, validate, createPoll)
 }
 
 //ChangePollDataPack ...
@@ -76,7 +174,14 @@ func AddOption(helper HTTPHelper, pollHandler PollHandler, pollOptionHandler Pol
 		pack := v.(*ChangePollDataPack)
 
 		pollOption := createPollOptionFrom(pack.PollTarget, pack.Data.(*AddOptionData))
-		pollOptionHandler.SavePollOption(*pollOption)
+		pollOption.Position = len(pack.PollTarget.Options)
+		savedOption, err := pollOptionHandler.SavePollOption(*pollOption,
+			OptionAddedEvent{PollID: pack.PollID, OptionID: pollOption.ID, Content: pollOption.Content, At: time.Now()})
+		if err != nil {
+			return nil, err
+		}
+
+		pack.PollTarget.Options = append(pack.PollTarget.Options, &savedOption)
 
 		return pack.PollTarget, nil
 	}
@@ -101,10 +206,22 @@ func RemoveOption(helper HTTPHelper, pollHandler PollHandler, pollOptionHandler
 		id, err := kallax.NewULIDFromText(data.Value)
 
 		if err != nil {
+			return nil, ErrValidation(err.Error())
+		}
+
+		if !hasOption(pack.PollTarget.Options, id) {
+			return nil, ErrNotFound("The poll has no such option.")
+		}
+
+		removed := OptionRemovedEvent{PollID: pack.PollID, OptionID: id, At: time.Now()}
+		if err := pollOptionHandler.DeletePollOption(pack.PollID, id, removed); err != nil {
 			return nil, err
 		}
 
-		pollOptionHandler.DeletePollOption(id)
+		pack.PollTarget.Options = withoutOption(pack.PollTarget.Options, id)
+		if err := renumberOptions(pack.PollTarget.Options, pollOptionHandler); err != nil {
+			return nil, err
+		}
 
 		return pack.PollTarget, nil
 	}
@@ -112,25 +229,366 @@ func RemoveOption(helper HTTPHelper, pollHandler PollHandler, pollOptionHandler
 	changePollOrCry(helper, &RemoveOptionData{}, pollHandler, pollOptionHandler, effectiveChange)
 }
 
-//Publish ...
-func Publish(helper HTTPHelper, pollHandler PollHandler, pollOptionHandler PollOptionHandler) {
+//ReorderOptions ...
+func ReorderOptions(helper HTTPHelper, pollHandler PollHandler, pollOptionHandler PollOptionHandler) {
 	effectiveChange := func(v interface{}) (interface{}, error) {
 		pack := v.(*ChangePollDataPack)
+		data := pack.Data.(*ReorderOptionsData)
 
-		pack.PollTarget.Published = true
+		ordered, err := orderOptions(pack.PollTarget.Options, data.Order)
+		if err != nil {
+			return nil, err
+		}
+
+		pack.PollTarget.Options = ordered
+		if err := renumberOptions(pack.PollTarget.Options, pollOptionHandler); err != nil {
+			return nil, err
+		}
 
 		return pack.PollTarget, nil
 	}
 
-	changePollOrCry(helper, new(interface{}), pollHandler, pollOptionHandler, effectiveChange)
+	changePollOrCry(helper, &ReorderOptionsData{}, pollHandler, pollOptionHandler, effectiveChange)
+}
+
+func orderOptions(options []*PollOption, order []string) ([]*PollOption, error) {
+	if len(order) != len(options) {
+		return nil, ErrValidation("Order must list every option of the poll once.")
+	}
+
+	byID := make(map[string]*PollOption)
+	for _, option := range options {
+		byID[option.ID.String()] = option
+	}
+
+	ordered := make([]*PollOption, 0, len(options))
+	for _, id := range order {
+		option, found := byID[id]
+		if !found {
+			return nil, ErrValidation("Order must list every option of the poll once.")
+		}
+
+		delete(byID, id)
+		ordered = append(ordered, option)
+	}
+
+	return ordered, nil
+}
+
+//renumberOptions keeps positions dense, following the slice order.
+func renumberOptions(options []*PollOption, pollOptionHandler PollOptionHandler) error {
+	for i, option := range options {
+		if option.Position == i {
+			continue
+		}
+
+		option.Position = i
+		if _, err := pollOptionHandler.SavePollOption(*option); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func hasOption(options []*PollOption, id kallax.ULID) bool {
+	for _, option := range options {
+		if option.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func withoutOption(options []*PollOption, id kallax.ULID) []*PollOption {
+	remaining := make([]*PollOption, 0, len(options))
+	for _, option := range options {
+		if option.ID != id {
+			remaining = append(remaining, option)
+		}
+	}
+
+	return remaining
+}
+
+//Publish opens the draft for votes, or schedules it when its opening time is still to come.
+//Scheduled polls are told published by the schedule, when they open.
+func Publish(helper HTTPHelper, pollHandler PollHandler, pollOptionHandler PollOptionHandler) {
+	published := func(poll *Poll) []Event {
+		if poll.Status == PollScheduled {
+			return []Event{PollScheduledEvent{PollID: poll.ID, OpensAt: *poll.OpensAt, At: time.Now()}}
+		}
+
+		return []Event{PollPublishedEvent{PollID: poll.ID, Status: poll.Status, At: time.Now()}}
+	}
+
+	transitPollOrCry(helper, pollHandler, func(poll *Poll) string {
+		return poll.PublishStatus(time.Now())
+	}, published)
+}
+
+//ClosePoll stops accepting votes.
+func ClosePoll(helper HTTPHelper, pollHandler PollHandler) {
+	closed := func(poll *Poll) []Event {
+		return []Event{PollClosedEvent{PollID: poll.ID, At: time.Now()}}
+	}
+
+	transitPollOrCry(helper, pollHandler, toStatus(PollClosed), closed)
+}
+
+//ReopenPoll accepts votes again on a closed poll, until the closesAt given, if any.
+//Drafts and scheduled polls open through Publish.
+func ReopenPoll(helper HTTPHelper, pollHandler PollHandler) {
+	checkReopen := func(v interface{}) (interface{}, error) {
+		pack := v.(*ChangePollDataPack)
+		data := pack.Data.(*ReopenPollData)
+
+		if err := pack.PollTarget.CheckReopen(data.ClosesAt, time.Now()); err != nil {
+			return nil, err
+		}
+
+		return pack, nil
+	}
+
+	reopen := func(v interface{}) (interface{}, error) {
+		pack := v.(*ChangePollDataPack)
+		data := pack.Data.(*ReopenPollData)
+
+		if err := pack.PollTarget.TransitionTo(PollOpen); err != nil {
+			return nil, err
+		}
+
+		if data.ClosesAt != nil {
+			pack.PollTarget.ClosesAt = data.ClosesAt
+		}
+
+		return pack.PollTarget, nil
+	}
+
+	processPollChange(helper, &ReopenPollData{}, pollHandler, checkReopen, reopen, nil)
+}
+
+//ArchivePoll ...
+func ArchivePoll(helper HTTPHelper, pollHandler PollHandler) {
+	transitPollOrCry(helper, pollHandler, toStatus(PollArchived), nil)
+}
+
+func toStatus(status string) func(*Poll) string {
+	return func(*Poll) string {
+		return status
+	}
+}
+
+func transitPollOrCry(helper HTTPHelper, pollHandler PollHandler, target func(*Poll) string,
+	eventsOf func(*Poll) []Event) {
+	checkTransition := func(v interface{}) (interface{}, error) {
+		pack := v.(*ChangePollDataPack)
+
+		if err := pack.PollTarget.CheckTransition(target(pack.PollTarget)); err != nil {
+			return nil, err
+		}
+
+		return pack, nil
+	}
+
+	transit := func(v interface{}) (interface{}, error) {
+		pack := v.(*ChangePollDataPack)
+
+		if err := pack.PollTarget.TransitionTo(target(pack.PollTarget)); err != nil {
+			return nil, err
+		}
+
+		return pack.PollTarget, nil
+	}
+
+	processPollChange(helper, new(interface{}), pollHandler, checkTransition, transit, eventsOf)
+}
+
+//CreateWebhook registers a webhook on a poll of the logged user. The secret the payloads
+//are signed with is only shown in this response.
+func CreateWebhook(helper HTTPHelper, pollHandler PollHandler, webhookHandler WebhookHandler) {
+	validate := func(v interface{}) (interface{}, error) {
+		return v, ValidateCreateWebhookData(v.(*CreateWebhookData))
+	}
+
+	getPoll := func(v interface{}) (interface{}, error) {
+		ID, err := kallax.NewULIDFromText(helper.GetVar("id"))
+		if err != nil {
+			return nil, ErrValidation(err.Error())
+		}
+
+		poll, err := pollHandler.FindPollByID(ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if poll.Owner != helper.LoggedUserID() {
+			return nil, ErrForbidden("Can't add a webhook to a poll from other user.")
+		}
+
+		return &ChangePollDataPack{PollID: ID, PollTarget: poll, Data: v}, nil
+	}
+
+	saveWebhook := func(v interface{}) (interface{}, error) {
+		pack := v.(*ChangePollDataPack)
+		data := pack.Data.(*CreateWebhookData)
+
+		secret := data.Secret
+		if secret == "" {
+			generated, err := NewWebhookSecret()
+			if err != nil {
+				return nil, err
+			}
+			secret = generated
+		}
+
+		webhook, err := webhookHandler.SaveWebhook(PollWebhook{
+			ID:            kallax.NewULID(),
+			PollID:        pack.PollID,
+			URL:           data.URL,
+			Secret:        secret,
+			OnPublish:     hasTrigger(data.Events, TriggerPublished),
+			OnClose:       hasTrigger(data.Events, TriggerClosed),
+			VoteThreshold: data.Votes,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return NewWebhookView(&webhook), nil
+	}
+
+	ExecuteAuthenticated(helper, &CreateWebhookData{}, validate, getPoll, saveWebhook)
+}
+
+func hasTrigger(triggers []string, trigger string) bool {
+	for _, t := range triggers {
+		if t == trigger {
+			return true
+		}
+	}
+
+	return false
+}
+
+//Clock tells the current time. The scheduler takes one so tests can control time.
+type Clock func() time.Time
+
+//RunPollSchedule opens the scheduled polls whose opening time has come and closes
+//the open polls whose closing time has passed. It returns how many polls changed.
+func RunPollSchedule(pollHandler PollHandler, now time.Time) (int, error) {
+	changed := 0
+
+	toOpen, err := pollHandler.FindPollsToOpen(now)
+	if err != nil {
+		return changed, err
+	}
+
+	for _, poll := range toOpen {
+		if err := schedulePollTransition(pollHandler, poll, PollOpen,
+			PollPublishedEvent{PollID: poll.ID, Status: PollOpen, At: now}); err != nil {
+			return changed, err
+		}
+		changed++
+	}
+
+	toClose, err := pollHandler.FindPollsToClose(now)
+	if err != nil {
+		return changed, err
+	}
+
+	for _, poll := range toClose {
+		if err := schedulePollTransition(pollHandler, poll, PollClosed,
+			PollClosedEvent{PollID: poll.ID, At: now}); err != nil {
+			return changed, err
+		}
+		changed++
+	}
+
+	return changed, nil
+}
+
+func schedulePollTransition(pollHandler PollHandler, poll *Poll, status string, events ...Event) error {
+	if err := poll.TransitionTo(status); err != nil {
+		return err
+	}
+
+	_, err := pollHandler.SavePoll(*poll, events...)
+	return err
+}
+
+//SchedulePolls runs the poll schedule every interval until done is closed.
+func SchedulePolls(pollHandler PollHandler, clock Clock, interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			changed, err := RunPollSchedule(pollHandler, clock())
+			if err != nil {
+				log.Println("Unable to run the poll schedule", err)
+				continue
+			}
+
+			if changed > 0 {
+				log.Println("Polls opened or closed by schedule:", changed)
+			}
+		}
+	}
+}
+
+//RebuildTallies rebuilds the tallies of the given polls from their votes, or of every poll
+//when none is given. It stops at the first failure and tells how many were rebuilt.
+func RebuildTallies(pollHandler PollHandler, pollVoteHandler PollVoteHandler, pollIDs []kallax.ULID) (int, error) {
+	if len(pollIDs) == 0 {
+		polls, err := pollHandler.FindPolls(NewPollQuery())
+		if err != nil {
+			return 0, err
+		}
+
+		for _, poll := range polls {
+			pollIDs = append(pollIDs, poll.ID)
+		}
+	}
+
+	rebuilt := 0
+	for _, pollID := range pollIDs {
+		if err := pollVoteHandler.RebuildTally(pollID); err != nil {
+			return rebuilt, err
+		}
+		rebuilt++
+	}
+
+	return rebuilt, nil
 }
 
 func changePollOrCry(helper HTTPHelper, data interface{}, pollHandler PollHandler,
 	pollOptionHandler PollOptionHandler, effectiveChange ProcessingBlock) {
+	checkEditable := func(v interface{}) (interface{}, error) {
+		pack := v.(*ChangePollDataPack)
+
+		if !pack.PollTarget.IsEditable() {
+			return nil, ErrNotChangePoll("Can't change a poll that is not a draft.")
+		}
+
+		return pack, nil
+	}
+
+	processPollChange(helper, data, pollHandler, checkEditable, effectiveChange, nil)
+}
+
+//processPollChange runs the change on the poll of the request and saves it. eventsOf, when
+//given, tells the events to record with the changed poll.
+func processPollChange(helper HTTPHelper, data interface{}, pollHandler PollHandler,
+	checkState ProcessingBlock, effectiveChange ProcessingBlock, eventsOf func(*Poll) []Event) {
 	getPollID := func(v interface{}) (interface{}, error) {
 		ID, err := kallax.NewULIDFromText(helper.GetVar("id"))
 		if err != nil {
-			return nil, ErrNotChangePoll(err.Error())
+			return nil, ErrValidation(err.Error())
 		}
 
 		return &ChangePollDataPack{
@@ -153,21 +611,11 @@ func changePollOrCry(helper HTTPHelper, data interface{}, pollHandler PollHandle
 		return pack, nil
 	}
 
-	checkPublished := func(v interface{}) (interface{}, error) {
-		pack := v.(*ChangePollDataPack)
-
-		if pack.PollTarget.Published {
-			return nil, ErrNotChangePoll("Can't change a published poll.")
-		}
-
-		return pack, nil
-	}
-
 	checkOwner := func(v interface{}) (interface{}, error) {
 		pack := v.(*ChangePollDataPack)
 
 		if pack.PollTarget.Owner != helper.LoggedUserID() {
-			return nil, ErrNotChangePoll("Can't change a poll from other user.")
+			return nil, ErrForbidden("Can't change a poll from other user.")
 		}
 
 		return pack, nil
@@ -176,29 +624,139 @@ func changePollOrCry(helper HTTPHelper, data interface{}, pollHandler PollHandle
 	savePoll := func(v interface{}) (interface{}, error) {
 		poll := v.(*Poll)
 
-		pollHandler.SavePoll(*poll)
+		var events []Event
+		if eventsOf != nil {
+			events = eventsOf(poll)
+		}
 
-		return poll, nil
+		if _, err := pollHandler.SavePoll(*poll, events...); err != nil {
+			return nil, err
+		}
+
+		return NewPollView(poll, helper.LoggedUserID()), nil
 	}
 
 	ExecuteAuthenticated(helper, data,
-		getPollID, getPoll, checkPublished, checkOwner, effectiveChange, savePoll)
+		getPollID, getPoll, checkState, checkOwner, effectiveChange, savePoll)
 }
 
-//CreateVoteDataPack ...
+//CreateVoteDataPack carries the vote through the pipelines that create, change or retract it.
 type CreateVoteDataPack struct {
 	PollID      kallax.ULID
 	Data        *PollVoteData
+	Poll        *Poll
+	Choices     []*PollOption
 	VoteCreated *PollVote
 }
 
-//CreateVote ...
-func CreateVote(helper HTTPHelper, pollOptionHandler PollOptionHandler, pollVoteHandler PollVoteHandler) {
-	makeCreateVoteDataPack := func(v interface{}) (interface{}, error) {
+//CreateVote registers the vote of the user and publishes the new tally of the poll.
+func CreateVote(helper HTTPHelper, pollHandler PollHandler, pollOptionHandler PollOptionHandler,
+	pollVoteHandler PollVoteHandler, publisher TallyPublisher) {
+	validateVoted := func(v interface{}) (interface{}, error) {
+		pack := v.(*CreateVoteDataPack)
+
+		voted, errVoted := pollVoteHandler.PollAlreadyVotedByUser(pack.PollID, helper.LoggedUserID())
+
+		if errVoted != nil {
+			return nil, errVoted
+		}
+
+		if voted {
+			return nil, errAlreadyVoted
+		}
+
+		return v, nil
+	}
+
+	createVote := func(v interface{}) (interface{}, error) {
+		pack := v.(*CreateVoteDataPack)
+
+		pack.VoteCreated = &PollVote{
+			ID:           kallax.NewULID(),
+			PollID:       pack.PollID,
+			UserID:       helper.LoggedUserID(),
+			ChosenOption: chosenContents(pack.Choices),
+		}
+
+		cast := VoteCastEvent{
+			PollID:    pack.PollID,
+			VoteID:    pack.VoteCreated.ID,
+			UserID:    pack.VoteCreated.UserID,
+			OptionIDs: optionIDsOf(pack.Choices),
+			At:        time.Now(),
+		}
+
+		if _, err := pollVoteHandler.SaveVote(*(pack.VoteCreated), selectionsOf(pack), cast); err != nil {
+			return nil, err
+		}
+
+		return pack, nil
+	}
+
+	ExecuteSessioned(helper, &PollVoteData{}, makeCreateVoteDataPack(helper), checkPollOpen(pollHandler),
+		resolveChoices(pollOptionHandler), validateVoted, createVote,
+		mountVoteResult(pollOptionHandler, pollVoteHandler, publisher))
+}
+
+//ChangeVote moves the vote of the user to another option while the poll is open.
+func ChangeVote(helper HTTPHelper, pollHandler PollHandler, pollOptionHandler PollOptionHandler,
+	pollVoteHandler PollVoteHandler, publisher TallyPublisher) {
+	changeVote := func(v interface{}) (interface{}, error) {
+		pack := v.(*CreateVoteDataPack)
+
+		changed := VoteChangedEvent{
+			PollID:    pack.PollID,
+			UserID:    helper.LoggedUserID(),
+			OptionIDs: optionIDsOf(pack.Choices),
+			At:        time.Now(),
+		}
+
+		vote, err := pollVoteHandler.ChangeVote(pack.PollID, helper.LoggedUserID(),
+			chosenContents(pack.Choices), selectionsOf(pack), changed)
+		if err != nil {
+			return nil, err
+		}
+
+		pack.VoteCreated = &vote
+		return pack, nil
+	}
+
+	ExecuteSessioned(helper, &PollVoteData{}, makeCreateVoteDataPack(helper), checkPollOpen(pollHandler),
+		resolveChoices(pollOptionHandler), changeVote,
+		mountVoteResult(pollOptionHandler, pollVoteHandler, publisher))
+}
+
+//RetractVote removes the vote of the user while the poll is open.
+func RetractVote(helper HTTPHelper, pollHandler PollHandler, pollOptionHandler PollOptionHandler,
+	pollVoteHandler PollVoteHandler, publisher TallyPublisher) {
+	retractVote := func(v interface{}) (interface{}, error) {
+		pack := v.(*CreateVoteDataPack)
+
+		retracted := VoteRetractedEvent{
+			PollID: pack.PollID,
+			UserID: helper.LoggedUserID(),
+			At:     time.Now(),
+		}
+
+		vote, err := pollVoteHandler.RetractVote(pack.PollID, helper.LoggedUserID(), retracted)
+		if err != nil {
+			return nil, err
+		}
+
+		pack.VoteCreated = &vote
+		return pack, nil
+	}
+
+	ExecuteSessioned(helper, &PollVoteData{}, makeCreateVoteDataPack(helper), checkPollOpen(pollHandler),
+		retractVote, mountVoteResult(pollOptionHandler, pollVoteHandler, publisher))
+}
+
+func makeCreateVoteDataPack(helper HTTPHelper) ProcessingBlock {
+	return func(v interface{}) (interface{}, error) {
 		IDValue := helper.GetVar("id")
 		pollID, err := kallax.NewULIDFromText(IDValue)
 		if err != nil {
-			return nil, err
+			return nil, ErrValidation(err.Error())
 		}
 
 		return &CreateVoteDataPack{
@@ -206,115 +764,443 @@ func CreateVote(helper HTTPHelper, pollOptionHandler PollOptionHandler, pollVote
 			Data:   v.(*PollVoteData),
 		}, nil
 	}
+}
 
-	validateOption := func(v interface{}) (interface{}, error) {
+func checkPollOpen(pollHandler PollHandler) ProcessingBlock {
+	return func(v interface{}) (interface{}, error) {
 		pack := v.(*CreateVoteDataPack)
-		exists, err := pollOptionHandler.ExistsOption(pack.PollID, pack.Data.Value)
 
+		poll, err := pollHandler.FindPollByID(pack.PollID)
 		if err != nil {
 			return nil, err
 		}
 
-		if !exists {
-			err := fmt.Errorf("There is no option %s for vote on this poll", pack.Data.Value)
-			return nil, err
+		if !poll.AcceptsVotesAt(time.Now()) {
+			return nil, ErrPollNotOpen("Poll is not open for votes.")
 		}
 
-		return v, nil
+		pack.Poll = poll
+		return pack, nil
 	}
+}
 
-	validateVoted := func(v interface{}) (interface{}, error) {
+//resolveChoices finds the chosen options and checks their number against the poll settings.
+func resolveChoices(pollOptionHandler PollOptionHandler) ProcessingBlock {
+	return func(v interface{}) (interface{}, error) {
 		pack := v.(*CreateVoteDataPack)
 
-		voted, errVoted := pollVoteHandler.PollAlreadyVotedByUser(pack.PollID, helper.LoggedUserID())
+		options, err := pollOptionHandler.FindPollOptions(pack.PollID)
+		if err != nil {
+			return nil, err
+		}
 
-		if errVoted != nil {
-			return nil, errVoted
+		choose := chooseOptions
+		if pack.Poll.IsScored() {
+			choose = chooseScoredOptions(pack.Poll.ScoreScale())
 		}
 
-		if voted {
-			err := fmt.Errorf("You already voted in this poll")
+		choices, err := choose(options, pack.Data)
+		if err != nil {
 			return nil, err
 		}
 
-		return v, nil
+		min, max := pack.Poll.BallotRange(len(options))
+		if len(choices) < min || len(choices) > max {
+			if min == max {
+				return nil, ErrValidation(fmt.Sprintf("Choose %d option(s) on this poll", min))
+			}
+			return nil, ErrValidation(fmt.Sprintf("Choose from %d to %d options on this poll", min, max))
+		}
+
+		pack.Choices = choices
+		return pack, nil
 	}
+}
 
-	createVote := func(v interface{}) (interface{}, error) {
-		pack := v.(*CreateVoteDataPack)
+func chooseOptions(options []*PollOption, data *PollVoteData) ([]*PollOption, error) {
+	IDs := data.Options
+	if len(IDs) == 0 && data.Option != "" {
+		IDs = []string{data.Option}
+	}
 
-		pack.VoteCreated = &PollVote{
-			ID:           kallax.NewULID(),
-			PollID:       pack.PollID,
-			UserID:       helper.LoggedUserID(),
-			ChosenOption: pack.Data.Value,
+	if len(IDs) == 0 {
+		return chooseOptionByContent(options, data.Value)
+	}
+
+	byID := make(map[string]*PollOption)
+	for _, opt := range options {
+		byID[opt.ID.String()] = opt
+	}
+
+	chosen := make(map[string]bool)
+	choices := make([]*PollOption, 0, len(IDs))
+	for _, ID := range IDs {
+		opt, exists := byID[ID]
+		if !exists {
+			return nil, ErrValidation(fmt.Sprintf("There is no option %s for vote on this poll", ID))
 		}
 
-		pollVoteHandler.SaveVote(*(pack.VoteCreated))
+		if chosen[ID] {
+			return nil, ErrValidation(fmt.Sprintf("Option %s was chosen more than once", ID))
+		}
 
-		return pack, nil
+		chosen[ID] = true
+		choices = append(choices, opt)
+	}
+
+	return choices, nil
+}
+
+//chooseOptionByContent serves clients that still send the content of the option. Options
+//sharing the content can't be told apart, so the voter has to choose them by ID.
+func chooseOptionByContent(options []*PollOption, content string) ([]*PollOption, error) {
+	choices := make([]*PollOption, 0, 1)
+	for _, opt := range options {
+		if opt.Content == content {
+			choices = append(choices, opt)
+		}
+	}
+
+	if len(choices) == 0 {
+		return nil, ErrValidation(fmt.Sprintf("There is no option %s for vote on this poll", content))
+	}
+
+	if len(choices) > 1 {
+		return nil, ErrValidation(fmt.Sprintf("There are %d options %s on this poll, choose one by its ID", len(choices), content))
+	}
+
+	return choices, nil
+}
+
+//chooseScoredOptions takes the scored options, in the order of the poll, checking every score against the scale.
+func chooseScoredOptions(scale int) func([]*PollOption, *PollVoteData) ([]*PollOption, error) {
+	return func(options []*PollOption, data *PollVoteData) ([]*PollOption, error) {
+		known := make(map[string]bool)
+		for _, opt := range options {
+			known[opt.ID.String()] = true
+		}
+
+		for ID, score := range data.Scores {
+			if !known[ID] {
+				return nil, ErrValidation(fmt.Sprintf("There is no option %s for vote on this poll", ID))
+			}
+
+			if score < 0 || score > scale {
+				return nil, ErrValidation(fmt.Sprintf("Score of option %s must be from 0 to %d", ID, scale))
+			}
+		}
+
+		choices := make([]*PollOption, 0, len(data.Scores))
+		for _, opt := range options {
+			if _, scored := data.Scores[opt.ID.String()]; scored {
+				choices = append(choices, opt)
+			}
+		}
+
+		return choices, nil
+	}
+}
+
+func optionIDsOf(choices []*PollOption) []kallax.ULID {
+	IDs := make([]kallax.ULID, 0, len(choices))
+	for _, choice := range choices {
+		IDs = append(IDs, choice.ID)
 	}
 
-	mountResult := func(v interface{}) (interface{}, error) {
+	return IDs
+}
+
+func chosenContents(choices []*PollOption) string {
+	return strings.Join(contentsOf(choices), ", ")
+}
+
+func selectionsOf(pack *CreateVoteDataPack) []PollVoteSelection {
+	selections := make([]PollVoteSelection, 0, len(pack.Choices))
+	for _, opt := range pack.Choices {
+		selection := PollVoteSelection{OptionID: opt.ID}
+		if pack.Poll.IsScored() {
+			selection.Score = pack.Data.Scores[opt.ID.String()]
+		}
+
+		selections = append(selections, selection)
+	}
+
+	return selections
+}
+
+//mountVoteResult counts the votes again, publishes the tally to the subscribers of the
+//poll and answers it to the voter. The version is taken before counting, so a tally
+//counted earlier never replaces this one.
+func mountVoteResult(pollOptionHandler PollOptionHandler, pollVoteHandler PollVoteHandler,
+	publisher TallyPublisher) ProcessingBlock {
+	return func(v interface{}) (interface{}, error) {
 		pack := v.(*CreateVoteDataPack)
 
+		version := publisher.NextVersion()
+		counted, err := TallierFor(pack.Poll).Tally(pack.Poll, pollOptionHandler, pollVoteHandler)
+		if err != nil {
+			return nil, err
+		}
+
+		tally := SelectionTallyOf(counted)
 		result := PollVoteResult{
-			VoteID:       pack.VoteCreated.ID.String(),
-			VoteCounting: CountVotes(pack.PollID, pollOptionHandler, pollVoteHandler),
+			VoteID:        pack.VoteCreated.ID.String(),
+			VoteCounting:  NewCountingView(tally.SelectionShares(), tally.TotalSelections()),
+			VoterCounting: NewCountingView(tally.VoterShares(), tally.Voters),
+			Tally:         counted,
 		}
 
-		return result, nil
+		view := NewVoteResultView(result)
+		publisher.Publish(pack.PollID, version, view.Tally)
+
+		return view, nil
 	}
+}
+
+//GetPoll ...
+func GetPoll(helper HTTPHelper, pollHandler PollHandler) {
+	findPoll := func(v interface{}) (interface{}, error) {
+		poll, err := pollHandler.FindPollByID(v.(kallax.ULID))
+		if err != nil {
+			return nil, err
+		}
 
-	ExecuteSessioned(helper, &PollVoteData{}, makeCreateVoteDataPack, validateOption, validateVoted, createVote,
-		mountResult)
+		return NewPollView(poll, helper.LoggedUserID()), nil
+	}
+
+	ExecuteSessioned(helper, nil, getPollIDFromRequest(helper), findPoll)
 }
 
-//CountVotes ...
-func CountVotes(pollID kallax.ULID, pollOptionHandler PollOptionHandler, pollVoteHandler PollVoteHandler) map[string]float64 {
-	options, err := pollOptionHandler.FindPollOptions(pollID)
+//GetPolls ...
+func GetPolls(helper HTTPHelper, pollHandler PollHandler) {
+	findPolls := func(v interface{}) (interface{}, error) {
+		query := NewPollQuery().
+			Order(kallax.Asc(Schema.Poll.CreatedAt))
+
+		polls, err := pollHandler.FindPolls(query)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewPollViews(polls, helper.LoggedUserID()), nil
+	}
+
+	ExecuteSessioned(helper, nil, findPolls)
+}
+
+//GetPollsMine ...
+func GetPollsMine(helper HTTPHelper, pollHandler PollHandler) {
+	findPolls := func(v interface{}) (interface{}, error) {
+		polls, err := pollHandler.FindPollsByOwner(helper.LoggedUserID())
+		if err != nil {
+			return nil, err
+		}
+
+		return NewPollViews(polls, helper.LoggedUserID()), nil
+	}
+
+	ExecuteSessioned(helper, nil, findPolls)
+}
+
+//CountingPollVotes counts the votes with the Tallier of the voting method of the poll.
+func CountingPollVotes(helper HTTPHelper, pollHandler PollHandler, pollOptionHandler PollOptionHandler,
+	pollVoteHandler PollVoteHandler) {
+	countVotes := func(v interface{}) (interface{}, error) {
+		return currentTallyView(v.(kallax.ULID), pollHandler, pollOptionHandler, pollVoteHandler)
+	}
+
+	ExecuteSessioned(helper, nil, getPollIDFromRequest(helper), countVotes)
+}
 
+func currentTallyView(pollID kallax.ULID, pollHandler PollHandler, pollOptionHandler PollOptionHandler,
+	pollVoteHandler PollVoteHandler) (interface{}, error) {
+	poll, err := pollHandler.FindPollByID(pollID)
 	if err != nil {
-		return map[string]float64{
-			err.Error(): -1.0,
+		return nil, err
+	}
+
+	result, err := TallierFor(poll).Tally(poll, pollOptionHandler, pollVoteHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTallyView(result), nil
+}
+
+//CountingPollVoters ...
+func CountingPollVoters(helper HTTPHelper, pollOptionHandler PollOptionHandler, pollVoteHandler PollVoteHandler) {
+	countVoters := func(v interface{}) (interface{}, error) {
+		return CountVoters(v.(kallax.ULID), pollOptionHandler, pollVoteHandler)
+	}
+
+	ExecuteSessioned(helper, nil, getPollIDFromRequest(helper), countVoters)
+}
+
+func getPollIDFromRequest(helper HTTPHelper) ProcessingBlock {
+	return func(v interface{}) (interface{}, error) {
+		ID, err := kallax.NewULIDFromText(helper.GetVar("id"))
+		if err != nil {
+			return nil, ErrValidation(err.Error())
 		}
+
+		return ID, nil
 	}
+}
 
-	count := make(map[string]int64)
+//VoteTally holds how many times each option was selected and how many users voted.
+type VoteTally struct {
+	Options    []*PollOption
+	Selections map[kallax.ULID]int64
+	Voters     int64
+}
 
-	for _, opt := range options {
-		count[opt.Content] = pollVoteHandler.VotesFor(pollID, opt.Content)
+//TallyVotes counts the selections of every option of the poll with a single query.
+func TallyVotes(pollID kallax.ULID, pollOptionHandler PollOptionHandler,
+	pollVoteHandler PollVoteHandler) (VoteTally, error) {
+	options, err := pollOptionHandler.FindPollOptions(pollID)
+	if err != nil {
+		return VoteTally{}, err
 	}
 
-	total := int64(0)
+	selections, err := pollVoteHandler.TallyByPoll(pollID)
+	if err != nil {
+		return VoteTally{}, err
+	}
 
-	for _, votes := range count {
-		total += votes
+	voters, err := pollVoteHandler.VotersOf(pollID)
+	if err != nil {
+		return VoteTally{}, err
 	}
 
-	result := make(map[string]float64)
-	result["total"] = float64(total)
+	return newVoteTally(options, selections, voters), nil
+}
+
+func newVoteTally(options []*PollOption, selections map[kallax.ULID]int64, voters int64) VoteTally {
+	tally := VoteTally{
+		Options:    options,
+		Selections: make(map[kallax.ULID]int64),
+		Voters:     voters,
+	}
 
-	remainPerc := 100.0
 	for _, opt := range options {
-		countVote := count[opt.Content]
+		tally.Selections[opt.ID] = selections[opt.ID]
+	}
+
+	return tally
+}
 
-		perct := float64(countVote*100) / float64(total)
-		realPerc := math.Round(perct*100) / 100
-		result[opt.Content] = realPerc
+//BallotTally counts how many ballots selected each option and how many ballots there are.
+func BallotTally(options []*PollOption, ballots []Ballot) VoteTally {
+	selections := make(map[kallax.ULID]int64)
+	for _, ballot := range ballots {
+		for _, ID := range ballot {
+			selections[ID]++
+		}
+	}
+
+	return newVoteTally(options, selections, int64(len(ballots)))
+}
+
+//SelectionShares gives the share of the selections each option got, by option ID. Shares
+//add up to 100, so the rounding remainder goes to the last option.
+func (t VoteTally) SelectionShares() map[kallax.ULID]float64 {
+	total := t.TotalSelections()
+	result := make(map[kallax.ULID]float64)
+
+	if total == 0 {
+		for _, opt := range t.Options {
+			result[opt.ID] = 0.0
+		}
+
+		return result
+	}
+
+	remainPerc := 100.0
+	for _, opt := range t.Options {
+		realPerc := sharePercent(t.Selections[opt.ID], total)
+		result[opt.ID] = realPerc
 
 		remainPerc = remainPerc - realPerc
 	}
 
 	if remainPerc > 0.0 {
-		lastIndex := len(options) - 1
-		lastOption := options[lastIndex].Content
+		lastOption := t.Options[len(t.Options)-1].ID
 		result[lastOption] = result[lastOption] + remainPerc
 	}
 
 	return result
 }
 
+//TotalSelections tells how many options were selected in every vote together.
+func (t VoteTally) TotalSelections() int64 {
+	total := int64(0)
+	for _, selections := range t.Selections {
+		total += selections
+	}
+
+	return total
+}
+
+//VoterShares gives the share of the voters that selected each option, by option ID. On
+//multiple-choice polls they add up to more than 100.
+func (t VoteTally) VoterShares() map[kallax.ULID]float64 {
+	result := make(map[kallax.ULID]float64)
+
+	for _, opt := range t.Options {
+		result[opt.ID] = 0.0
+		if t.Voters > 0 {
+			result[opt.ID] = sharePercent(t.Selections[opt.ID], t.Voters)
+		}
+	}
+
+	return result
+}
+
+func sharePercent(part, total int64) float64 {
+	perct := float64(part*100) / float64(total)
+	return math.Round(perct*100) / 100
+}
+
+//CountVotes gives the share of the selections each option got, keyed by option ID.
+//"total" holds the number of selections.
+func CountVotes(pollID kallax.ULID, pollOptionHandler PollOptionHandler,
+	pollVoteHandler PollVoteHandler) (map[string]float64, error) {
+	tally, err := TallyVotes(pollID, pollOptionHandler, pollVoteHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCountingView(tally.SelectionShares(), tally.TotalSelections()), nil
+}
+
+//RunoffVotes decides the poll by instant runoff over the ranking of every vote.
+func RunoffVotes(pollID kallax.ULID, pollOptionHandler PollOptionHandler,
+	pollVoteHandler PollVoteHandler) (RunoffResult, error) {
+	options, err := pollOptionHandler.FindPollOptions(pollID)
+	if err != nil {
+		return RunoffResult{}, err
+	}
+
+	ballots, err := pollVoteHandler.BallotsOf(pollID)
+	if err != nil {
+		return RunoffResult{}, err
+	}
+
+	return InstantRunoff(options, ballots), nil
+}
+
+//CountVoters gives the share of the voters that selected each option, keyed by option ID.
+//"total" holds the number of voters.
+func CountVoters(pollID kallax.ULID, pollOptionHandler PollOptionHandler,
+	pollVoteHandler PollVoteHandler) (map[string]float64, error) {
+	tally, err := TallyVotes(pollID, pollOptionHandler, pollVoteHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCountingView(tally.VoterShares(), tally.Voters), nil
+}
+
 //ExecuteSessioned ...
 func ExecuteSessioned(helper HTTPHelper, v interface{}, blocks ...ProcessingBlock) {
 	errCheck := helper.ValidateSession()
@@ -2,7 +2,9 @@ package app
 
 import (
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"gopkg.in/src-d/go-kallax.v1"
 
@@ -122,8 +124,8 @@ func TestCreateUser(t *testing.T) {
 		CreateUserFromDataFunc: func(d *UserCreationData) (User, error) {
 			return User{}, nil
 		},
-		SaveUserFunc: func(user User) User {
-			return user
+		SaveUserFunc: func(user User, events ...Event) (User, error) {
+			return user, nil
 		},
 	}
 
@@ -131,21 +133,24 @@ func TestCreateUser(t *testing.T) {
 
 	assert.AssertEqual(t, 1, len(handlerMock.CreateUserFromDataCalls()))
 	assert.AssertEqual(t, 1, len(handlerMock.SaveUserCalls()))
+	saved := handlerMock.SaveUserCalls()[0]
+	assert.AssertEqual(t, 1, len(saved.Events))
+	assert.AssertEqual(t, saved.User.ID, saved.Events[0].(UserRegisteredEvent).UserID)
 }
 
 func TestVisit(t *testing.T) {
 	helperMock := createBasicHelperMock()
 	userHandlerMock := &UserHandlerMock{
-		CreateAnonUserFunc: func() User {
+		CreateAnonUserFunc: func() (User, error) {
 			return User{
 				ID: kallax.NewULID(),
-			}
+			}, nil
 		},
 	}
 
 	sessionHandlerMock := &SessionHandlerMock{
-		CreateSessionFunc: func(ID kallax.ULID, flag bool) *Session {
-			return &Session{}
+		CreateSessionFunc: func(ID kallax.ULID, flag bool) (*Session, error) {
+			return &Session{}, nil
 		},
 	}
 
@@ -166,8 +171,8 @@ func TestLogin(t *testing.T) {
 	}
 
 	sessionHandlerMock := &SessionHandlerMock{
-		CreateSessionFunc: func(ID kallax.ULID, flag bool) *Session {
-			return &Session{}
+		CreateSessionFunc: func(ID kallax.ULID, flag bool) (*Session, error) {
+			return &Session{}, nil
 		},
 	}
 
@@ -181,9 +186,9 @@ func TestStartCreatePoll(t *testing.T) {
 	var savedPoll Poll
 	helperMock := createAuthenticatedHelperMock()
 	pollHandlerMock := &PollHandlerMock{
-		SavePollFunc: func(poll Poll) Poll {
+		SavePollFunc: func(poll Poll, events ...Event) (Poll, error) {
 			savedPoll = poll
-			return poll
+			return poll, nil
 		},
 	}
 
@@ -191,6 +196,9 @@ func TestStartCreatePoll(t *testing.T) {
 
 	assert.AssertEqual(t, loggedUserID(), savedPoll.Owner)
 	assert.AssertEqual(t, 1, len(pollHandlerMock.SavePollCalls()))
+	created := pollHandlerMock.SavePollCalls()[0].Events[0].(PollCreatedEvent)
+	assert.AssertEqual(t, savedPoll.ID, created.PollID)
+	assert.AssertEqual(t, loggedUserID(), created.Owner)
 }
 
 func TestShouldGetErrorForSessionInvalidOnCheckAuthentication(t *testing.T) {
@@ -240,6 +248,7 @@ func TestShouldChangePollCryWhenNotExtractPollID(t *testing.T) {
 	changePollOrCry(helperMock, &AddOptionData{}, nil, nil, nil)
 
 	assert.AssertEqual(t, "uuid: UUID string too short: avocado", box.ErrorOcurred.Error())
+	assert.AssertEqual(t, CodeValidation, box.ErrorOcurred.(CodedError).Code())
 }
 
 func TestShouldChangeCryWhenNotFindPoll(t *testing.T) {
@@ -257,21 +266,22 @@ func TestShouldChangeCryWhenNotFindPoll(t *testing.T) {
 	assert.AssertEqual(t, "Deadpoll", box.ErrorOcurred.Error())
 }
 
-func TestShouldChangeCryWhenPollPublished(t *testing.T) {
+func TestShouldChangeCryWhenPollNotDraft(t *testing.T) {
 	box := &ProcessErrorBox{}
 	helperMock := createPollChangeProcessBoxedHelperMock(box)
 
 	pollHandlerMock := &PollHandlerMock{
 		FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
 			return &Poll{
-				Published: true,
+				Status: PollOpen,
 			}, nil
 		},
 	}
 
 	changePollOrCry(helperMock, &AddOptionData{}, pollHandlerMock, nil, nil)
 
-	assert.AssertEqual(t, "Can't change a published poll.", box.ErrorOcurred.Error())
+	assert.AssertEqual(t, "Can't change a poll that is not a draft.", box.ErrorOcurred.Error())
+	assert.AssertEqual(t, CodePollNotChangeable, box.ErrorOcurred.(CodedError).Code())
 }
 
 func TestShouldChangeCryWhenPollOwnedByOtherUser(t *testing.T) {
@@ -290,6 +300,7 @@ func TestShouldChangeCryWhenPollOwnedByOtherUser(t *testing.T) {
 	changePollOrCry(helperMock, &AddOptionData{}, pollHandlerMock, nil, nil)
 
 	assert.AssertEqual(t, "Can't change a poll from other user.", box.ErrorOcurred.Error())
+	assert.AssertEqual(t, CodeForbidden, box.ErrorOcurred.(CodedError).Code())
 }
 
 func TestAddOption(t *testing.T) {
@@ -298,18 +309,18 @@ func TestAddOption(t *testing.T) {
 	pollHandlerMock := &PollHandlerMock{
 		FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
 			return &Poll{
-				Published: false,
-				Owner:     loggedUserID(),
+				Status: PollDraft,
+				Owner:  loggedUserID(),
 			}, nil
 		},
-		SavePollFunc: func(v Poll) Poll {
-			return v
+		SavePollFunc: func(v Poll, events ...Event) (Poll, error) {
+			return v, nil
 		},
 	}
 
 	pollOptionHandlerMock := &PollOptionHandlerMock{
-		SavePollOptionFunc: func(v PollOption) PollOption {
-			return v
+		SavePollOptionFunc: func(v PollOption, events ...Event) (PollOption, error) {
+			return v, nil
 		},
 	}
 
@@ -319,6 +330,10 @@ func TestAddOption(t *testing.T) {
 	assert.AssertEqual(t, 1, len(pollHandlerMock.FindPollByIDCalls()))
 	assert.AssertEqual(t, 1, len(pollHandlerMock.SavePollCalls()))
 	assert.AssertEqual(t, 1, len(pollOptionHandlerMock.SavePollOptionCalls()))
+	saved := pollOptionHandlerMock.SavePollOptionCalls()[0]
+	added := saved.Events[0].(OptionAddedEvent)
+	assert.AssertEqual(t, saved.Poll.ID, added.OptionID)
+	assert.AssertEqual(t, saved.Poll.Content, added.Content)
 }
 
 func TestCreatePollOptionFromData(t *testing.T) {
@@ -330,6 +345,16 @@ func TestCreatePollOptionFromData(t *testing.T) {
 	assert.AssertEqual(t, "Opt", option.Content)
 }
 
+func newPollWithOption(ID string) *Poll {
+	optionID, _ := kallax.NewULIDFromText(ID)
+
+	return &Poll{
+		Status:  PollDraft,
+		Owner:   loggedUserID(),
+		Options: []*PollOption{&PollOption{ID: optionID, Content: "A"}},
+	}
+}
+
 func TestRemoveOption(t *testing.T) {
 	helperMock := createPollChangeHelperMock()
 	helperMock.ProcessFunc = helperMockProcessFuncInputed(&RemoveOptionData{
@@ -338,18 +363,15 @@ func TestRemoveOption(t *testing.T) {
 
 	pollHandlerMock := &PollHandlerMock{
 		FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
-			return &Poll{
-				Published: false,
-				Owner:     loggedUserID(),
-			}, nil
+			return newPollWithOption("9d627cdc-8e4a-435e-a2f7-c9bafaa41e45"), nil
 		},
-		SavePollFunc: func(v Poll) Poll {
-			return v
+		SavePollFunc: func(v Poll, events ...Event) (Poll, error) {
+			return v, nil
 		},
 	}
 
 	pollOptionHandlerMock := &PollOptionHandlerMock{
-		DeletePollOptionFunc: func(id kallax.ULID) error {
+		DeletePollOptionFunc: func(pollID, id kallax.ULID, events ...Event) error {
 			return nil
 		},
 	}
@@ -360,6 +382,33 @@ func TestRemoveOption(t *testing.T) {
 	assert.AssertEqual(t, 1, len(pollHandlerMock.FindPollByIDCalls()))
 	assert.AssertEqual(t, 1, len(pollHandlerMock.SavePollCalls()))
 	assert.AssertEqual(t, 1, len(pollOptionHandlerMock.DeletePollOptionCalls()))
+	deleted := pollOptionHandlerMock.DeletePollOptionCalls()[0]
+	assert.AssertEqual(t, pollHandlerMock.FindPollByIDCalls()[0].ID, deleted.PollID)
+	removed := deleted.Events[0].(OptionRemovedEvent)
+	assert.AssertEqual(t, deleted.ID, removed.OptionID)
+	assert.AssertEqual(t, deleted.PollID, removed.PollID)
+}
+
+func TestShouldNotRemoveOptionOfOtherPoll(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &RemoveOptionData{
+		Value: "9d627cdc-8e4a-435e-a2f7-c9bafaa41e45",
+	})
+
+	pollHandlerMock := &PollHandlerMock{
+		FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
+			return newPollWithOption(kallax.NewULID().String()), nil
+		},
+	}
+	pollOptionHandlerMock := &PollOptionHandlerMock{}
+
+	RemoveOption(helperMock, pollHandlerMock, pollOptionHandlerMock)
+
+	assert.AssertEqual(t, "The poll has no such option.", box.ErrorOcurred.Error())
+	assert.AssertEqual(t, CodeNotFound, AsCodedError(box.ErrorOcurred).Code())
+	assert.AssertEqual(t, 0, len(pollOptionHandlerMock.DeletePollOptionCalls()))
+	assert.AssertEqual(t, 0, len(pollHandlerMock.SavePollCalls()))
 }
 
 func TestRemoveOptionWhenIdDoesNotExists(t *testing.T) {
@@ -368,17 +417,17 @@ func TestRemoveOptionWhenIdDoesNotExists(t *testing.T) {
 	pollHandlerMock := &PollHandlerMock{
 		FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
 			return &Poll{
-				Published: false,
-				Owner:     loggedUserID(),
+				Status: PollDraft,
+				Owner:  loggedUserID(),
 			}, nil
 		},
-		SavePollFunc: func(v Poll) Poll {
-			return v
+		SavePollFunc: func(v Poll, events ...Event) (Poll, error) {
+			return v, nil
 		},
 	}
 
 	pollOptionHandlerMock := &PollOptionHandlerMock{
-		DeletePollOptionFunc: func(id kallax.ULID) error {
+		DeletePollOptionFunc: func(pollID, id kallax.ULID, events ...Event) error {
 			return nil
 		},
 	}
@@ -391,20 +440,188 @@ func TestRemoveOptionWhenIdDoesNotExists(t *testing.T) {
 	assert.AssertEqual(t, 0, len(pollOptionHandlerMock.DeletePollOptionCalls()))
 }
 
+func TestAddOptionAtLastPosition(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &AddOptionData{Value: "C"})
+
+	pollHandlerMock := &PollHandlerMock{
+		FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
+			return &Poll{
+				Owner: loggedUserID(),
+				Options: []*PollOption{
+					&PollOption{ID: kallax.NewULID(), Content: "A", Position: 0},
+					&PollOption{ID: kallax.NewULID(), Content: "B", Position: 1},
+				},
+			}, nil
+		},
+		SavePollFunc: func(v Poll, events ...Event) (Poll, error) {
+			return v, nil
+		},
+	}
+
+	pollOptionHandlerMock := &PollOptionHandlerMock{
+		SavePollOptionFunc: func(v PollOption, events ...Event) (PollOption, error) {
+			return v, nil
+		},
+	}
+
+	AddOption(helperMock, pollHandlerMock, pollOptionHandlerMock)
+
+	assert.AssertNil(t, box.ErrorOcurred)
+	assert.AssertEqual(t, 1, len(pollOptionHandlerMock.SavePollOptionCalls()))
+	assert.AssertEqual(t, 2, pollOptionHandlerMock.SavePollOptionCalls()[0].Poll.Position)
+	options := box.Object.(PollView).Options
+	assert.AssertEqual(t, 3, len(options))
+	assert.AssertEqual(t, "C", options[2].Content)
+}
+
+func TestRemoveOptionKeepsPositionsDense(t *testing.T) {
+	box := &ProcessErrorBox{}
+	removedID := kallax.NewULID()
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &RemoveOptionData{
+		Value: removedID.String(),
+	})
+
+	pollHandlerMock := &PollHandlerMock{
+		FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
+			return &Poll{
+				Owner: loggedUserID(),
+				Options: []*PollOption{
+					&PollOption{ID: kallax.NewULID(), Content: "A", Position: 0},
+					&PollOption{ID: removedID, Content: "B", Position: 1},
+					&PollOption{ID: kallax.NewULID(), Content: "C", Position: 2},
+				},
+			}, nil
+		},
+		SavePollFunc: func(v Poll, events ...Event) (Poll, error) {
+			return v, nil
+		},
+	}
+
+	pollOptionHandlerMock := &PollOptionHandlerMock{
+		DeletePollOptionFunc: func(pollID, id kallax.ULID, events ...Event) error {
+			return nil
+		},
+		SavePollOptionFunc: func(v PollOption, events ...Event) (PollOption, error) {
+			return v, nil
+		},
+	}
+
+	RemoveOption(helperMock, pollHandlerMock, pollOptionHandlerMock)
+
+	assert.AssertNil(t, box.ErrorOcurred)
+	assert.AssertEqual(t, 1, len(pollOptionHandlerMock.SavePollOptionCalls()))
+	saved := pollOptionHandlerMock.SavePollOptionCalls()[0].Poll
+	assert.AssertEqual(t, "C", saved.Content)
+	assert.AssertEqual(t, 1, saved.Position)
+	options := box.Object.(PollView).Options
+	assert.AssertEqual(t, 2, len(options))
+	assert.AssertEqual(t, "C", options[1].Content)
+}
+
+func createReorderPollHandlerMock(options ...*PollOption) *PollHandlerMock {
+	return &PollHandlerMock{
+		FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
+			return &Poll{
+				Owner:   loggedUserID(),
+				Options: options,
+			}, nil
+		},
+		SavePollFunc: func(v Poll, events ...Event) (Poll, error) {
+			return v, nil
+		},
+	}
+}
+
+func TestReorderOptions(t *testing.T) {
+	a := &PollOption{ID: kallax.NewULID(), Content: "A", Position: 0}
+	b := &PollOption{ID: kallax.NewULID(), Content: "B", Position: 1}
+	c := &PollOption{ID: kallax.NewULID(), Content: "C", Position: 2}
+
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &ReorderOptionsData{
+		Order: []string{c.ID.String(), a.ID.String(), b.ID.String()},
+	})
+
+	pollHandlerMock := createReorderPollHandlerMock(a, b, c)
+	pollOptionHandlerMock := &PollOptionHandlerMock{
+		SavePollOptionFunc: func(v PollOption, events ...Event) (PollOption, error) {
+			return v, nil
+		},
+	}
+
+	ReorderOptions(helperMock, pollHandlerMock, pollOptionHandlerMock)
+
+	assert.AssertNil(t, box.ErrorOcurred)
+	assert.AssertEqual(t, 1, len(pollHandlerMock.SavePollCalls()))
+	assert.AssertEqual(t, 3, len(pollOptionHandlerMock.SavePollOptionCalls()))
+	options := box.Object.(PollView).Options
+	assert.AssertEqual(t, "C", options[0].Content)
+	assert.AssertEqual(t, 0, options[0].Position)
+	assert.AssertEqual(t, "A", options[1].Content)
+	assert.AssertEqual(t, 1, options[1].Position)
+	assert.AssertEqual(t, "B", options[2].Content)
+	assert.AssertEqual(t, 2, options[2].Position)
+}
+
+func TestShouldNotReorderOptionsWithIncompleteOrder(t *testing.T) {
+	a := &PollOption{ID: kallax.NewULID(), Content: "A", Position: 0}
+	b := &PollOption{ID: kallax.NewULID(), Content: "B", Position: 1}
+
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &ReorderOptionsData{
+		Order: []string{b.ID.String()},
+	})
+
+	pollHandlerMock := createReorderPollHandlerMock(a, b)
+	pollOptionHandlerMock := &PollOptionHandlerMock{}
+
+	ReorderOptions(helperMock, pollHandlerMock, pollOptionHandlerMock)
+
+	assert.AssertEqual(t, "Order must list every option of the poll once.", box.ErrorOcurred.Error())
+	assert.AssertEqual(t, CodeValidation, box.ErrorOcurred.(CodedError).Code())
+	assert.AssertEqual(t, 0, len(pollHandlerMock.SavePollCalls()))
+	assert.AssertEqual(t, 0, len(pollOptionHandlerMock.SavePollOptionCalls()))
+}
+
+func TestShouldNotReorderOptionsWithRepeatedOption(t *testing.T) {
+	a := &PollOption{ID: kallax.NewULID(), Content: "A", Position: 0}
+	b := &PollOption{ID: kallax.NewULID(), Content: "B", Position: 1}
+
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &ReorderOptionsData{
+		Order: []string{b.ID.String(), b.ID.String()},
+	})
+
+	pollHandlerMock := createReorderPollHandlerMock(a, b)
+	pollOptionHandlerMock := &PollOptionHandlerMock{}
+
+	ReorderOptions(helperMock, pollHandlerMock, pollOptionHandlerMock)
+
+	assert.AssertEqual(t, "Order must list every option of the poll once.", box.ErrorOcurred.Error())
+	assert.AssertEqual(t, CodeValidation, box.ErrorOcurred.(CodedError).Code())
+	assert.AssertEqual(t, 0, len(pollOptionHandlerMock.SavePollOptionCalls()))
+}
+
 func TestPublish(t *testing.T) {
 	helperMock := createPollChangeHelperMock()
 
 	poll := &Poll{
-		Published: false,
-		Owner:     loggedUserID(),
+		Status: PollDraft,
+		Owner:  loggedUserID(),
 	}
 
 	pollHandlerMock := &PollHandlerMock{
 		FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
 			return poll, nil
 		},
-		SavePollFunc: func(v Poll) Poll {
-			return v
+		SavePollFunc: func(v Poll, events ...Event) (Poll, error) {
+			return v, nil
 		},
 	}
 
@@ -415,7 +632,159 @@ func TestPublish(t *testing.T) {
 	assert.AssertEqual(t, 1, len(helperMock.GetVarCalls()))
 	assert.AssertEqual(t, 1, len(pollHandlerMock.FindPollByIDCalls()))
 	assert.AssertEqual(t, 1, len(pollHandlerMock.SavePollCalls()))
-	assert.AssertTrue(t, poll.Published)
+	assert.AssertEqual(t, PollOpen, poll.Status)
+	published := pollHandlerMock.SavePollCalls()[0].Events[0].(PollPublishedEvent)
+	assert.AssertEqual(t, PollOpen, published.Status)
+}
+
+func createTransitionHelperMock(box *ProcessErrorBox) *HTTPHelperMock {
+	helperMock := createPollChangeProcessBoxedHelperMock(box)
+	helperMock.GetVarFunc = func(name string) string {
+		return "c5c1827e-2649-49ee-b960-cd04ac34c1a8"
+	}
+
+	return helperMock
+}
+
+func createPollInStatusHandlerMock(poll *Poll) *PollHandlerMock {
+	return &PollHandlerMock{
+		FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
+			return poll, nil
+		},
+		SavePollFunc: func(v Poll, events ...Event) (Poll, error) {
+			return v, nil
+		},
+	}
+}
+
+func TestClosePoll(t *testing.T) {
+	box := &ProcessErrorBox{}
+	poll := &Poll{Status: PollOpen, Owner: loggedUserID()}
+	pollHandlerMock := createPollInStatusHandlerMock(poll)
+
+	ClosePoll(createTransitionHelperMock(box), pollHandlerMock)
+
+	assert.AssertNil(t, box.ErrorOcurred)
+	assert.AssertEqual(t, 1, len(pollHandlerMock.SavePollCalls()))
+	assert.AssertEqual(t, PollClosed, poll.Status)
+	assert.AssertEqual(t, EventPollClosed, pollHandlerMock.SavePollCalls()[0].Events[0].EventName())
+}
+
+func TestReopenPoll(t *testing.T) {
+	box := &ProcessErrorBox{}
+	poll := &Poll{Status: PollClosed, Owner: loggedUserID()}
+	pollHandlerMock := createPollInStatusHandlerMock(poll)
+
+	ReopenPoll(createTransitionHelperMock(box), pollHandlerMock)
+
+	assert.AssertNil(t, box.ErrorOcurred)
+	assert.AssertEqual(t, PollOpen, poll.Status)
+}
+
+func TestShouldOnlyReopenClosedPoll(t *testing.T) {
+	for _, status := range []string{PollDraft, PollScheduled, PollOpen, PollArchived} {
+		box := &ProcessErrorBox{}
+		poll := &Poll{Status: status, Owner: loggedUserID()}
+		pollHandlerMock := createPollInStatusHandlerMock(poll)
+
+		ReopenPoll(createTransitionHelperMock(box), pollHandlerMock)
+
+		assert.AssertEqual(t, "Can't reopen a poll that is "+status+".", box.ErrorOcurred.Error())
+		assert.AssertEqual(t, CodePollNotChangeable, box.ErrorOcurred.(CodedError).Code())
+		assert.AssertEqual(t, 0, len(pollHandlerMock.SavePollCalls()))
+		assert.AssertEqual(t, status, poll.Status)
+	}
+}
+
+func TestShouldNotReopenPollPastItsClosingTime(t *testing.T) {
+	box := &ProcessErrorBox{}
+	closesAt := time.Now().Add(-time.Hour)
+	poll := &Poll{Status: PollClosed, Owner: loggedUserID(), ClosesAt: &closesAt}
+	pollHandlerMock := createPollInStatusHandlerMock(poll)
+
+	ReopenPoll(createTransitionHelperMock(box), pollHandlerMock)
+
+	assert.AssertEqual(t, CodePollNotChangeable, box.ErrorOcurred.(CodedError).Code())
+	assert.AssertEqual(t, 0, len(pollHandlerMock.SavePollCalls()))
+	assert.AssertEqual(t, PollClosed, poll.Status)
+}
+
+func TestReopenPollPastItsClosingTimeWithNewClosingTime(t *testing.T) {
+	box := &ProcessErrorBox{}
+	closesAt := time.Now().Add(-time.Hour)
+	newClosesAt := time.Now().Add(time.Hour)
+	poll := &Poll{Status: PollClosed, Owner: loggedUserID(), ClosesAt: &closesAt}
+	pollHandlerMock := createPollInStatusHandlerMock(poll)
+	helperMock := createTransitionHelperMock(box)
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &ReopenPollData{ClosesAt: &newClosesAt})
+
+	ReopenPoll(helperMock, pollHandlerMock)
+
+	assert.AssertNil(t, box.ErrorOcurred)
+	assert.AssertEqual(t, PollOpen, poll.Status)
+	assert.AssertEqual(t, newClosesAt, *pollHandlerMock.SavePollCalls()[0].Poll.ClosesAt)
+	assert.AssertTrue(t, poll.AcceptsVotesAt(time.Now()))
+}
+
+func TestShouldNotReopenPollWithPastClosingTime(t *testing.T) {
+	box := &ProcessErrorBox{}
+	newClosesAt := time.Now().Add(-time.Minute)
+	poll := &Poll{Status: PollClosed, Owner: loggedUserID()}
+	pollHandlerMock := createPollInStatusHandlerMock(poll)
+	helperMock := createTransitionHelperMock(box)
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &ReopenPollData{ClosesAt: &newClosesAt})
+
+	ReopenPoll(helperMock, pollHandlerMock)
+
+	assert.AssertEqual(t, CodeValidation, box.ErrorOcurred.(CodedError).Code())
+	assert.AssertEqual(t, PollClosed, poll.Status)
+}
+
+func TestArchivePoll(t *testing.T) {
+	box := &ProcessErrorBox{}
+	poll := &Poll{Status: PollClosed, Owner: loggedUserID()}
+	pollHandlerMock := createPollInStatusHandlerMock(poll)
+
+	ArchivePoll(createTransitionHelperMock(box), pollHandlerMock)
+
+	assert.AssertNil(t, box.ErrorOcurred)
+	assert.AssertEqual(t, PollArchived, poll.Status)
+}
+
+func TestShouldNotArchiveOpenPoll(t *testing.T) {
+	box := &ProcessErrorBox{}
+	poll := &Poll{Status: PollOpen, Owner: loggedUserID()}
+	pollHandlerMock := createPollInStatusHandlerMock(poll)
+
+	ArchivePoll(createTransitionHelperMock(box), pollHandlerMock)
+
+	assert.AssertEqual(t, "Can't move a poll from open to archived.", box.ErrorOcurred.Error())
+	assert.AssertEqual(t, CodePollNotChangeable, box.ErrorOcurred.(CodedError).Code())
+	assert.AssertEqual(t, 0, len(pollHandlerMock.SavePollCalls()))
+	assert.AssertEqual(t, PollOpen, poll.Status)
+}
+
+func TestShouldNotPublishArchivedPoll(t *testing.T) {
+	box := &ProcessErrorBox{}
+	poll := &Poll{Status: PollArchived, Owner: loggedUserID()}
+	pollHandlerMock := createPollInStatusHandlerMock(poll)
+
+	Publish(createTransitionHelperMock(box), pollHandlerMock, &PollOptionHandlerMock{})
+
+	assert.AssertEqual(t, CodePollNotChangeable, box.ErrorOcurred.(CodedError).Code())
+	assert.AssertEqual(t, PollArchived, poll.Status)
+}
+
+func TestShouldNotClosePollOfOtherUser(t *testing.T) {
+	box := &ProcessErrorBox{}
+	poll := &Poll{Status: PollOpen, Owner: kallax.NewULID()}
+	pollHandlerMock := createPollInStatusHandlerMock(poll)
+
+	ClosePoll(createTransitionHelperMock(box), pollHandlerMock)
+
+	assert.AssertEqual(t, CodeForbidden, box.ErrorOcurred.(CodedError).Code())
+	assert.AssertEqual(t, PollOpen, poll.Status)
+	assert.AssertEqual(t, 0, len(pollHandlerMock.SavePollCalls()))
 }
 
 func TestCreateVote(t *testing.T) {
@@ -427,38 +796,108 @@ func TestCreateVote(t *testing.T) {
 		Value: "Terceira",
 	})
 
-	pollOptionHandlerMock := &PollOptionHandlerMock{
-		ExistsOptionFunc: func(pollID kallax.ULID, candidate string) (bool, error) {
-			return true, nil
-		},
-		FindPollOptionsFunc: func(pollID kallax.ULID) ([]*PollOption, error) {
-			return []*PollOption{
-				&PollOption{Content: "A"},
-				&PollOption{Content: "B"},
-				&PollOption{Content: "C"},
-			}, nil
-		},
-	}
+	options := newOptions("Primeira", "Segunda", "Terceira")
+	pollOptionHandlerMock := createOptionsMock(options)
 
 	pollVoteHandlerMock := &PollVoteHandlerMock{
 		PollAlreadyVotedByUserFunc: func(pollID kallax.ULID, userID kallax.ULID) (bool, error) {
 			return false, nil
 		},
-		SaveVoteFunc: func(vote PollVote) PollVote {
-			return vote
+		SaveVoteFunc: func(vote PollVote, selections []PollVoteSelection, events ...Event) (PollVote, error) {
+			return vote, nil
 		},
-		VotesForFunc: func(pollID kallax.ULID, option string) int64 {
-			return 1
+		TallyByPollFunc: tallyEach(options, 1),
+		VotersOfFunc: func(pollID kallax.ULID) (int64, error) {
+			return 3, nil
 		},
 	}
 
-	CreateVote(helperMock, pollOptionHandlerMock, pollVoteHandlerMock)
+	pollHandlerMock := createOpenPollHandlerMock()
+	publisherMock := createTallyPublisherMock()
 
-	assert.AssertEqual(t, 1, len(pollOptionHandlerMock.ExistsOptionCalls()))
-	assert.AssertEqual(t, 1, len(pollOptionHandlerMock.FindPollOptionsCalls()))
+	CreateVote(helperMock, pollHandlerMock, pollOptionHandlerMock, pollVoteHandlerMock, publisherMock)
+
+	assert.AssertEqual(t, 1, len(pollHandlerMock.FindPollByIDCalls()))
+	assert.AssertEqual(t, "c5c1827e-2649-49ee-b960-cd04ac34c1a8", pollHandlerMock.FindPollByIDCalls()[0].ID.String())
+	assert.AssertEqual(t, 1, len(publisherMock.PublishCalls()))
+	assert.AssertEqual(t, "c5c1827e-2649-49ee-b960-cd04ac34c1a8", publisherMock.PublishCalls()[0].PollID.String())
+	assert.AssertEqual(t, VotingPlurality, publisherMock.PublishCalls()[0].Tally.(PluralityView).Method)
+	assert.AssertEqual(t, 1, len(publisherMock.NextVersionCalls()))
+	assert.AssertEqual(t, int64(1), publisherMock.PublishCalls()[0].Version)
+	cast := pollVoteHandlerMock.SaveVoteCalls()[0].Events[0].(VoteCastEvent)
+	assert.AssertEqual(t, pollVoteHandlerMock.SaveVoteCalls()[0].Vote.ID, cast.VoteID)
+	assert.AssertEqual(t, []kallax.ULID{options[2].ID}, cast.OptionIDs)
+	assert.AssertEqual(t, 2, len(pollOptionHandlerMock.FindPollOptionsCalls()))
 	assert.AssertEqual(t, 1, len(pollVoteHandlerMock.PollAlreadyVotedByUserCalls()))
 	assert.AssertEqual(t, 1, len(pollVoteHandlerMock.SaveVoteCalls()))
-	assert.AssertEqual(t, 3, len(pollVoteHandlerMock.VotesForCalls()))
+	assert.AssertEqual(t, 1, len(pollVoteHandlerMock.TallyByPollCalls()))
+	assert.AssertEqual(t, "Terceira", pollVoteHandlerMock.SaveVoteCalls()[0].Vote.ChosenOption)
+	assert.AssertEqual(t, 1, len(pollVoteHandlerMock.SaveVoteCalls()[0].Selections))
+}
+
+func createOpenPollHandlerMock() *PollHandlerMock {
+	return &PollHandlerMock{
+		FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
+			return &Poll{ID: ID, Status: PollOpen}, nil
+		},
+	}
+}
+
+func TestShouldNotCreateVoteOnDraftPoll(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &PollVoteData{Value: "A"})
+
+	pollHandlerMock := &PollHandlerMock{
+		FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
+			return &Poll{ID: ID, Status: PollDraft}, nil
+		},
+	}
+	pollOptionHandlerMock := &PollOptionHandlerMock{}
+	pollVoteHandlerMock := &PollVoteHandlerMock{}
+
+	CreateVote(helperMock, pollHandlerMock, pollOptionHandlerMock, pollVoteHandlerMock, createTallyPublisherMock())
+
+	assert.AssertEqual(t, "Poll is not open for votes.", box.ErrorOcurred.Error())
+	assert.AssertEqual(t, CodePollNotOpen, box.ErrorOcurred.(CodedError).Code())
+	assert.AssertEqual(t, 0, len(pollOptionHandlerMock.FindPollOptionsCalls()))
+	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.SaveVoteCalls()))
+}
+
+func TestShouldNotCreateVoteOnClosedPoll(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &PollVoteData{Value: "A"})
+
+	pollHandlerMock := &PollHandlerMock{
+		FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
+			return &Poll{ID: ID, Status: PollClosed}, nil
+		},
+	}
+	pollVoteHandlerMock := &PollVoteHandlerMock{}
+
+	CreateVote(helperMock, pollHandlerMock, &PollOptionHandlerMock{}, pollVoteHandlerMock, createTallyPublisherMock())
+
+	assert.AssertEqual(t, CodePollNotOpen, box.ErrorOcurred.(CodedError).Code())
+	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.SaveVoteCalls()))
+}
+
+func TestShouldNotCreateVoteWhenPollNotFound(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &PollVoteData{Value: "A"})
+
+	pollHandlerMock := &PollHandlerMock{
+		FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
+			return nil, kallax.ErrNotFound
+		},
+	}
+	pollVoteHandlerMock := &PollVoteHandlerMock{}
+
+	CreateVote(helperMock, pollHandlerMock, &PollOptionHandlerMock{}, pollVoteHandlerMock, createTallyPublisherMock())
+
+	assert.AssertEqual(t, CodeNotFound, AsCodedError(box.ErrorOcurred).Code())
+	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.SaveVoteCalls()))
 }
 
 func TestShouldNotCreateVoteWithoutPollID(t *testing.T) {
@@ -490,23 +929,23 @@ func TestShouldNotCreateVoteWithoutPollID(t *testing.T) {
 		// PollAlreadyVotedByUserFunc: func(pollID kallax.ULID, userID kallax.ULID) (bool, error) {
 		// 	return false, nil
 		// },
-		// SaveVoteFunc: func(vote PollVote) PollVote {
-		// 	return vote
+		// SaveVoteFunc: func(vote PollVote, selections []PollVoteSelection) (PollVote, error) {
+		// 	return vote, nil
 		// },
-		// VotesForFunc: func(pollID kallax.ULID, option string) int64 {
-		// 	return 1
+		// SelectionsForFunc: func(pollID kallax.ULID, optionID kallax.ULID) (int64, error) {
+		// 	return 1, nil
 		// },
 	}
 
-	CreateVote(helperMock, pollOptionHandlerMock, pollVoteHandlerMock)
+	CreateVote(helperMock, createOpenPollHandlerMock(), pollOptionHandlerMock, pollVoteHandlerMock, createTallyPublisherMock())
 
-	assert.AssertEqual(t, 0, len(pollOptionHandlerMock.ExistsOptionCalls()))
 	assert.AssertEqual(t, 0, len(pollOptionHandlerMock.FindPollOptionsCalls()))
 	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.PollAlreadyVotedByUserCalls()))
 	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.SaveVoteCalls()))
-	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.VotesForCalls()))
+	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.TallyByPollCalls()))
 
 	assert.AssertEqual(t, "uuid: UUID string too short: no-uuid", box.ErrorOcurred.Error())
+	assert.AssertEqual(t, CodeValidation, box.ErrorOcurred.(CodedError).Code())
 }
 
 func TestShouldCreateVoteFailWhenOptionExistsFail(t *testing.T) {
@@ -521,8 +960,8 @@ func TestShouldCreateVoteFailWhenOptionExistsFail(t *testing.T) {
 	})
 
 	pollOptionHandlerMock := &PollOptionHandlerMock{
-		ExistsOptionFunc: func(pollID kallax.ULID, candidate string) (bool, error) {
-			return false, fmt.Errorf("Fail")
+		FindPollOptionsFunc: func(pollID kallax.ULID) ([]*PollOption, error) {
+			return nil, fmt.Errorf("Fail")
 		},
 		// FindPollOptionsFunc: func(pollID kallax.ULID) ([]*PollOption, error) {
 		// 	return []*PollOption{
@@ -537,21 +976,20 @@ func TestShouldCreateVoteFailWhenOptionExistsFail(t *testing.T) {
 		// PollAlreadyVotedByUserFunc: func(pollID kallax.ULID, userID kallax.ULID) (bool, error) {
 		// 	return false, nil
 		// },
-		// SaveVoteFunc: func(vote PollVote) PollVote {
-		// 	return vote
+		// SaveVoteFunc: func(vote PollVote, selections []PollVoteSelection) (PollVote, error) {
+		// 	return vote, nil
 		// },
-		// VotesForFunc: func(pollID kallax.ULID, option string) int64 {
-		// 	return 1
+		// SelectionsForFunc: func(pollID kallax.ULID, optionID kallax.ULID) (int64, error) {
+		// 	return 1, nil
 		// },
 	}
 
-	CreateVote(helperMock, pollOptionHandlerMock, pollVoteHandlerMock)
+	CreateVote(helperMock, createOpenPollHandlerMock(), pollOptionHandlerMock, pollVoteHandlerMock, createTallyPublisherMock())
 
-	assert.AssertEqual(t, 1, len(pollOptionHandlerMock.ExistsOptionCalls()))
-	assert.AssertEqual(t, 0, len(pollOptionHandlerMock.FindPollOptionsCalls()))
+	assert.AssertEqual(t, 1, len(pollOptionHandlerMock.FindPollOptionsCalls()))
 	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.PollAlreadyVotedByUserCalls()))
 	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.SaveVoteCalls()))
-	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.VotesForCalls()))
+	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.TallyByPollCalls()))
 
 	assert.AssertEqual(t, "Fail", box.ErrorOcurred.Error())
 }
@@ -568,8 +1006,8 @@ func TestShouldCreateVoteFailWhenOptionNotExists(t *testing.T) {
 	})
 
 	pollOptionHandlerMock := &PollOptionHandlerMock{
-		ExistsOptionFunc: func(pollID kallax.ULID, candidate string) (bool, error) {
-			return false, nil
+		FindPollOptionsFunc: func(pollID kallax.ULID) ([]*PollOption, error) {
+			return []*PollOption{&PollOption{Content: "Primeira"}}, nil
 		},
 		// FindPollOptionsFunc: func(pollID kallax.ULID) ([]*PollOption, error) {
 		// 	return []*PollOption{
@@ -584,23 +1022,23 @@ func TestShouldCreateVoteFailWhenOptionNotExists(t *testing.T) {
 		// PollAlreadyVotedByUserFunc: func(pollID kallax.ULID, userID kallax.ULID) (bool, error) {
 		// 	return false, nil
 		// },
-		// SaveVoteFunc: func(vote PollVote) PollVote {
-		// 	return vote
+		// SaveVoteFunc: func(vote PollVote, selections []PollVoteSelection) (PollVote, error) {
+		// 	return vote, nil
 		// },
-		// VotesForFunc: func(pollID kallax.ULID, option string) int64 {
-		// 	return 1
+		// SelectionsForFunc: func(pollID kallax.ULID, optionID kallax.ULID) (int64, error) {
+		// 	return 1, nil
 		// },
 	}
 
-	CreateVote(helperMock, pollOptionHandlerMock, pollVoteHandlerMock)
+	CreateVote(helperMock, createOpenPollHandlerMock(), pollOptionHandlerMock, pollVoteHandlerMock, createTallyPublisherMock())
 
-	assert.AssertEqual(t, 1, len(pollOptionHandlerMock.ExistsOptionCalls()))
-	assert.AssertEqual(t, 0, len(pollOptionHandlerMock.FindPollOptionsCalls()))
+	assert.AssertEqual(t, 1, len(pollOptionHandlerMock.FindPollOptionsCalls()))
 	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.PollAlreadyVotedByUserCalls()))
 	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.SaveVoteCalls()))
-	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.VotesForCalls()))
+	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.TallyByPollCalls()))
 
 	assert.AssertEqual(t, "There is no option Terceira for vote on this poll", box.ErrorOcurred.Error())
+	assert.AssertEqual(t, CodeValidation, box.ErrorOcurred.(CodedError).Code())
 }
 
 func TestShouldCreateVoteFailWhenVerifyVoteExistsFail(t *testing.T) {
@@ -615,8 +1053,8 @@ func TestShouldCreateVoteFailWhenVerifyVoteExistsFail(t *testing.T) {
 	})
 
 	pollOptionHandlerMock := &PollOptionHandlerMock{
-		ExistsOptionFunc: func(pollID kallax.ULID, candidate string) (bool, error) {
-			return true, nil
+		FindPollOptionsFunc: func(pollID kallax.ULID) ([]*PollOption, error) {
+			return []*PollOption{&PollOption{Content: "Terceira"}}, nil
 		},
 		// FindPollOptionsFunc: func(pollID kallax.ULID) ([]*PollOption, error) {
 		// 	return []*PollOption{
@@ -631,21 +1069,20 @@ func TestShouldCreateVoteFailWhenVerifyVoteExistsFail(t *testing.T) {
 		PollAlreadyVotedByUserFunc: func(pollID kallax.ULID, userID kallax.ULID) (bool, error) {
 			return false, fmt.Errorf("Fail")
 		},
-		// SaveVoteFunc: func(vote PollVote) PollVote {
-		// 	return vote
+		// SaveVoteFunc: func(vote PollVote, selections []PollVoteSelection) (PollVote, error) {
+		// 	return vote, nil
 		// },
-		// VotesForFunc: func(pollID kallax.ULID, option string) int64 {
-		// 	return 1
+		// SelectionsForFunc: func(pollID kallax.ULID, optionID kallax.ULID) (int64, error) {
+		// 	return 1, nil
 		// },
 	}
 
-	CreateVote(helperMock, pollOptionHandlerMock, pollVoteHandlerMock)
+	CreateVote(helperMock, createOpenPollHandlerMock(), pollOptionHandlerMock, pollVoteHandlerMock, createTallyPublisherMock())
 
-	assert.AssertEqual(t, 1, len(pollOptionHandlerMock.ExistsOptionCalls()))
-	assert.AssertEqual(t, 0, len(pollOptionHandlerMock.FindPollOptionsCalls()))
+	assert.AssertEqual(t, 1, len(pollOptionHandlerMock.FindPollOptionsCalls()))
 	assert.AssertEqual(t, 1, len(pollVoteHandlerMock.PollAlreadyVotedByUserCalls()))
 	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.SaveVoteCalls()))
-	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.VotesForCalls()))
+	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.TallyByPollCalls()))
 
 	assert.AssertEqual(t, "Fail", box.ErrorOcurred.Error())
 }
@@ -662,8 +1099,8 @@ func TestShouldCreateVoteFailWhenAlreadyVoted(t *testing.T) {
 	})
 
 	pollOptionHandlerMock := &PollOptionHandlerMock{
-		ExistsOptionFunc: func(pollID kallax.ULID, candidate string) (bool, error) {
-			return true, nil
+		FindPollOptionsFunc: func(pollID kallax.ULID) ([]*PollOption, error) {
+			return []*PollOption{&PollOption{Content: "Terceira"}}, nil
 		},
 		// FindPollOptionsFunc: func(pollID kallax.ULID) ([]*PollOption, error) {
 		// 	return []*PollOption{
@@ -678,83 +1115,115 @@ func TestShouldCreateVoteFailWhenAlreadyVoted(t *testing.T) {
 		PollAlreadyVotedByUserFunc: func(pollID kallax.ULID, userID kallax.ULID) (bool, error) {
 			return true, nil
 		},
-		// SaveVoteFunc: func(vote PollVote) PollVote {
-		// 	return vote
+		// SaveVoteFunc: func(vote PollVote, selections []PollVoteSelection) (PollVote, error) {
+		// 	return vote, nil
 		// },
-		// VotesForFunc: func(pollID kallax.ULID, option string) int64 {
-		// 	return 1
+		// SelectionsForFunc: func(pollID kallax.ULID, optionID kallax.ULID) (int64, error) {
+		// 	return 1, nil
 		// },
 	}
 
-	CreateVote(helperMock, pollOptionHandlerMock, pollVoteHandlerMock)
+	CreateVote(helperMock, createOpenPollHandlerMock(), pollOptionHandlerMock, pollVoteHandlerMock, createTallyPublisherMock())
 
-	assert.AssertEqual(t, 1, len(pollOptionHandlerMock.ExistsOptionCalls()))
-	assert.AssertEqual(t, 0, len(pollOptionHandlerMock.FindPollOptionsCalls()))
+	assert.AssertEqual(t, 1, len(pollOptionHandlerMock.FindPollOptionsCalls()))
 	assert.AssertEqual(t, 1, len(pollVoteHandlerMock.PollAlreadyVotedByUserCalls()))
 	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.SaveVoteCalls()))
-	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.VotesForCalls()))
+	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.TallyByPollCalls()))
 
 	assert.AssertEqual(t, "You already voted in this poll", box.ErrorOcurred.Error())
+	assert.AssertEqual(t, CodeConflict, box.ErrorOcurred.(CodedError).Code())
 }
 
-func TestShouldCountVotes(t *testing.T) {
-	pollOptionHandlerMock := &PollOptionHandlerMock{
-		FindPollOptionsFunc: func(pollID kallax.ULID) ([]*PollOption, error) {
-			return []*PollOption{
-				&PollOption{Content: "A"},
-				&PollOption{Content: "B"},
-				&PollOption{Content: "C"},
-			}, nil
-		},
+func newOptions(contents ...string) []*PollOption {
+	options := make([]*PollOption, 0, len(contents))
+	for _, content := range contents {
+		options = append(options, &PollOption{ID: kallax.NewULID(), Content: content})
 	}
 
-	pollVoteHandlerMock := &PollVoteHandlerMock{
-		VotesForFunc: func(pollID kallax.ULID, option string) int64 {
+	return options
+}
+
+//tallyEach gives every option the same number of selections.
+func tallyEach(options []*PollOption, selections int64) func(kallax.ULID) (map[kallax.ULID]int64, error) {
+	return func(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+		counts := make(map[kallax.ULID]int64)
+		for _, opt := range options {
+			counts[opt.ID] = selections
+		}
+
+		return counts, nil
+	}
+}
+
+func createTallyPublisherMock() *TallyPublisherMock {
+	return &TallyPublisherMock{
+		NextVersionFunc: func() int64 {
 			return 1
 		},
+		PublishFunc: func(pollID kallax.ULID, version int64, tally interface{}) {},
+	}
+}
+
+func createOptionsMock(options []*PollOption) *PollOptionHandlerMock {
+	return &PollOptionHandlerMock{
+		FindPollOptionsFunc: func(pollID kallax.ULID) ([]*PollOption, error) {
+			return options, nil
+		},
+	}
+}
+
+func TestShouldCountVotes(t *testing.T) {
+	options := newOptions("A", "B", "C")
+	pollOptionHandlerMock := createOptionsMock(options)
+
+	pollVoteHandlerMock := &PollVoteHandlerMock{
+		TallyByPollFunc: tallyEach(options, 1),
+		VotersOfFunc: func(pollID kallax.ULID) (int64, error) {
+			return 3, nil
+		},
 	}
 
-	votes := CountVotes(kallax.NewULID(), pollOptionHandlerMock, pollVoteHandlerMock)
+	votes, err := CountVotes(kallax.NewULID(), pollOptionHandlerMock, pollVoteHandlerMock)
+
+	assert.AssertNil(t, err)
 
 	assert.AssertEqual(t, 1, len(pollOptionHandlerMock.FindPollOptionsCalls()))
-	assert.AssertEqual(t, 3, len(pollVoteHandlerMock.VotesForCalls()))
+	assert.AssertEqual(t, 1, len(pollVoteHandlerMock.TallyByPollCalls()))
 
-	assert.AssertEqual(t, 33.33, votes["A"])
-	assert.AssertEqual(t, 33.33, votes["B"])
-	assert.AssertEqual(t, 33.34, votes["C"])
+	assert.AssertEqual(t, 33.33, votes[options[0].ID.String()])
+	assert.AssertEqual(t, 33.33, votes[options[1].ID.String()])
+	assert.AssertEqual(t, 33.34, votes[options[2].ID.String()])
 	assert.AssertEqual(t, 3, votes["total"])
 }
 
 func TestShouldCountVotesWithoutRounding(t *testing.T) {
-	pollOptionHandlerMock := &PollOptionHandlerMock{
-		FindPollOptionsFunc: func(pollID kallax.ULID) ([]*PollOption, error) {
-			return []*PollOption{
-				&PollOption{Content: "A"},
-				&PollOption{Content: "B"},
-				&PollOption{Content: "C"},
-			}, nil
-		},
-	}
+	options := newOptions("A", "B", "C")
+	pollOptionHandlerMock := createOptionsMock(options)
 
-	counts := map[string]int64{
-		"A": 1,
-		"B": 2,
-		"C": 1,
+	counts := map[kallax.ULID]int64{
+		options[0].ID: 1,
+		options[1].ID: 2,
+		options[2].ID: 1,
 	}
 	pollVoteHandlerMock := &PollVoteHandlerMock{
-		VotesForFunc: func(pollID kallax.ULID, option string) int64 {
-			return counts[option]
+		TallyByPollFunc: func(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+			return counts, nil
+		},
+		VotersOfFunc: func(pollID kallax.ULID) (int64, error) {
+			return 4, nil
 		},
 	}
 
-	votes := CountVotes(kallax.NewULID(), pollOptionHandlerMock, pollVoteHandlerMock)
+	votes, err := CountVotes(kallax.NewULID(), pollOptionHandlerMock, pollVoteHandlerMock)
+
+	assert.AssertNil(t, err)
 
 	assert.AssertEqual(t, 1, len(pollOptionHandlerMock.FindPollOptionsCalls()))
-	assert.AssertEqual(t, 3, len(pollVoteHandlerMock.VotesForCalls()))
+	assert.AssertEqual(t, 1, len(pollVoteHandlerMock.TallyByPollCalls()))
 
-	assert.AssertEqual(t, 25.0, votes["A"])
-	assert.AssertEqual(t, 50.0, votes["B"])
-	assert.AssertEqual(t, 25.0, votes["C"])
+	assert.AssertEqual(t, 25.0, votes[options[0].ID.String()])
+	assert.AssertEqual(t, 50.0, votes[options[1].ID.String()])
+	assert.AssertEqual(t, 25.0, votes[options[2].ID.String()])
 	assert.AssertEqual(t, 4, votes["total"])
 }
 
@@ -768,13 +1237,1233 @@ func TestShouldCountVotesFailWhenFindExistentOptionsFail(t *testing.T) {
 
 	pollVoteHandlerMock := &PollVoteHandlerMock{}
 
-	votes := CountVotes(kallax.NewULID(), pollOptionHandlerMock, pollVoteHandlerMock)
+	votes, err := CountVotes(kallax.NewULID(), pollOptionHandlerMock, pollVoteHandlerMock)
 
 	assert.AssertEqual(t, 1, len(pollOptionHandlerMock.FindPollOptionsCalls()))
-	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.VotesForCalls()))
+	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.TallyByPollCalls()))
 
-	count, existsKey := votes[errorMsg]
-	assert.AssertEqual(t, true, existsKey)
-	assert.AssertEqual(t, -1, count)
-	assert.AssertEqual(t, 1, len(votes))
+	assert.AssertNil(t, votes)
+	assert.AssertEqual(t, errorMsg, err.Error())
+}
+
+func TestShouldCountVotesFailWhenTallyByPollFail(t *testing.T) {
+	pollOptionHandlerMock := createOptionsMock(newOptions("A", "B"))
+
+	pollVoteHandlerMock := &PollVoteHandlerMock{
+		TallyByPollFunc: func(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+			return nil, fmt.Errorf("Count failed")
+		},
+	}
+
+	votes, err := CountVotes(kallax.NewULID(), pollOptionHandlerMock, pollVoteHandlerMock)
+
+	assert.AssertEqual(t, 1, len(pollVoteHandlerMock.TallyByPollCalls()))
+	assert.AssertNil(t, votes)
+	assert.AssertEqual(t, "Count failed", err.Error())
+}
+
+func TestShouldCountVotesWithoutVotes(t *testing.T) {
+	options := newOptions("A", "B")
+	pollOptionHandlerMock := createOptionsMock(options)
+
+	pollVoteHandlerMock := &PollVoteHandlerMock{
+		TallyByPollFunc: func(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+			return map[kallax.ULID]int64{}, nil
+		},
+		VotersOfFunc: func(pollID kallax.ULID) (int64, error) {
+			return 0, nil
+		},
+	}
+
+	votes, err := CountVotes(kallax.NewULID(), pollOptionHandlerMock, pollVoteHandlerMock)
+
+	assert.AssertNil(t, err)
+
+	assert.AssertEqual(t, 0.0, votes[options[0].ID.String()])
+	assert.AssertEqual(t, 0.0, votes[options[1].ID.String()])
+	assert.AssertEqual(t, 0, votes["total"])
+}
+
+func TestGetPoll(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeProcessBoxedHelperMock(box)
+
+	pollHandlerMock := &PollHandlerMock{
+		FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
+			return &Poll{
+				ID:   ID,
+				Name: "Best Pizza",
+			}, nil
+		},
+	}
+
+	GetPoll(helperMock, pollHandlerMock)
+
+	assert.AssertEqual(t, 1, len(pollHandlerMock.FindPollByIDCalls()))
+	assert.AssertEqual(t, getPollIDVarValue("id"), pollHandlerMock.FindPollByIDCalls()[0].ID.String())
+	assert.AssertNil(t, box.ErrorOcurred)
+	assert.AssertEqual(t, "Best Pizza", box.Object.(PollView).Name)
+}
+
+func TestShouldNotGetPollWithoutPollID(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeProcessBoxedHelperMock(box)
+	helperMock.GetVarFunc = func(name string) string {
+		return "avocado"
+	}
+
+	pollHandlerMock := &PollHandlerMock{}
+
+	GetPoll(helperMock, pollHandlerMock)
+
+	assert.AssertEqual(t, 0, len(pollHandlerMock.FindPollByIDCalls()))
+	assert.AssertEqual(t, "uuid: UUID string too short: avocado", box.ErrorOcurred.Error())
+	assert.AssertEqual(t, CodeValidation, box.ErrorOcurred.(CodedError).Code())
+}
+
+func TestShouldNotGetPollWhenNotFound(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeProcessBoxedHelperMock(box)
+
+	pollHandlerMock := &PollHandlerMock{
+		FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
+			return nil, fmt.Errorf("Deadpoll")
+		},
+	}
+
+	GetPoll(helperMock, pollHandlerMock)
+
+	assert.AssertEqual(t, 1, len(pollHandlerMock.FindPollByIDCalls()))
+	assert.AssertEqual(t, "Deadpoll", box.ErrorOcurred.Error())
+}
+
+func TestGetPolls(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeProcessBoxedHelperMock(box)
+
+	var sqlExecuted string
+	pollHandlerMock := &PollHandlerMock{
+		FindPollsFunc: func(query *PollQuery) ([]*Poll, error) {
+			sqlExecuted = query.String()
+			return []*Poll{&Poll{}, &Poll{}}, nil
+		},
+	}
+
+	GetPolls(helperMock, pollHandlerMock)
+
+	assert.AssertEqual(t, 1, len(pollHandlerMock.FindPollsCalls()))
+	assert.AssertEqual(t, 2, len(box.Object.([]PollView)))
+	sqlExpected := "SELECT __poll.id, __poll.created_at, __poll.updated_at, __poll.name, __poll.owner, __poll.status, __poll.opens_at, __poll.closes_at, " +
+		"__poll.min_choices, __poll.max_choices, __poll.voting_method, __poll.max_score " +
+		"FROM poll __poll ORDER BY __poll.created_at ASC"
+	assert.AssertEqual(t, sqlExpected, sqlExecuted)
+}
+
+func TestGetPollsMine(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeProcessBoxedHelperMock(box)
+
+	pollHandlerMock := &PollHandlerMock{
+		FindPollsByOwnerFunc: func(userID kallax.ULID) ([]*Poll, error) {
+			return []*Poll{&Poll{Owner: userID}}, nil
+		},
+	}
+
+	GetPollsMine(helperMock, pollHandlerMock)
+
+	assert.AssertEqual(t, 1, len(pollHandlerMock.FindPollsByOwnerCalls()))
+	assert.AssertEqual(t, loggedUserID(), pollHandlerMock.FindPollsByOwnerCalls()[0].UserID)
+	assert.AssertEqual(t, 1, len(box.Object.([]PollView)))
+	assert.AssertTrue(t, box.Object.([]PollView)[0].Mine)
+}
+
+func TestShouldNotGetPollsWithoutSession(t *testing.T) {
+	helperMock := &HTTPHelperMock{
+		ValidateSessionFunc: func() error { return fmt.Errorf("Invalid session") },
+		ForbidFunc:          func(err error) {},
+	}
+
+	pollHandlerMock := &PollHandlerMock{}
+
+	GetPolls(helperMock, pollHandlerMock)
+
+	assert.AssertEqual(t, 1, len(helperMock.ForbidCalls()))
+	assert.AssertEqual(t, 0, len(pollHandlerMock.FindPollsCalls()))
+}
+
+func TestCountingPollVotes(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeProcessBoxedHelperMock(box)
+
+	options := newOptions("A", "B")
+	pollOptionHandlerMock := createOptionsMock(options)
+
+	pollVoteHandlerMock := &PollVoteHandlerMock{
+		TallyByPollFunc: tallyEach(options, 1),
+		VotersOfFunc: func(pollID kallax.ULID) (int64, error) {
+			return 2, nil
+		},
+	}
+
+	CountingPollVotes(helperMock, createOpenPollHandlerMock(), pollOptionHandlerMock, pollVoteHandlerMock)
+
+	assert.AssertEqual(t, 1, len(pollOptionHandlerMock.FindPollOptionsCalls()))
+	assert.AssertEqual(t, 1, len(pollVoteHandlerMock.TallyByPollCalls()))
+
+	view := box.Object.(PluralityView)
+	assert.AssertEqual(t, VotingPlurality, view.Method)
+	assert.AssertEqual(t, 2.0, view.VoterCounting["total"])
+	votes := view.Counting
+	assert.AssertEqual(t, 50.0, votes[options[0].ID.String()])
+	assert.AssertEqual(t, 50.0, votes[options[1].ID.String()])
+	assert.AssertEqual(t, 2, votes["total"])
+}
+
+func createClaimHelperMock(box *ProcessErrorBox, registered bool) *HTTPHelperMock {
+	return &HTTPHelperMock{
+		ProcessFunc:          helperMockProcessFuncBoxedInputed(box, &UserCreationData{Login: "isabella@disney.com"}),
+		ValidateSessionFunc:  func() error { return nil },
+		IsRegisteredUserFunc: func() bool { return registered },
+		LoggedUserIDFunc:     loggedUserID,
+		GetRequestSessionIDFunc: func() (string, error) {
+			return "7d97abb1-2f1b-4542-8173-67e78a590ab9", nil
+		},
+	}
+}
+
+func TestClaimUser(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createClaimHelperMock(box, false)
+
+	userHandlerMock := &UserHandlerMock{
+		ClaimAnonUserFunc: func(ID kallax.ULID, d *UserCreationData, events ...Event) (*User, error) {
+			return &User{ID: ID, Login: d.Login, Password: "bcrypt$hash"}, nil
+		},
+	}
+	sessionHandlerMock := &SessionHandlerMock{
+		CreateSessionFunc: func(ID kallax.ULID, flag bool) (*Session, error) {
+			return &Session{ID: kallax.NewULID(), UserID: ID, RegisteredUser: flag}, nil
+		},
+		DeleteSessionFunc: func(id kallax.ULID) error {
+			return nil
+		},
+	}
+
+	ClaimUser(helperMock, userHandlerMock, sessionHandlerMock)
+
+	assert.AssertNil(t, box.ErrorOcurred)
+	assert.AssertEqual(t, loggedUserID(), userHandlerMock.ClaimAnonUserCalls()[0].ID)
+	registered := userHandlerMock.ClaimAnonUserCalls()[0].Events[0].(UserRegisteredEvent)
+	assert.AssertEqual(t, loggedUserID(), registered.UserID)
+	assert.AssertEqual(t, 1, len(sessionHandlerMock.CreateSessionCalls()))
+	assert.AssertEqual(t, loggedUserID(), sessionHandlerMock.CreateSessionCalls()[0].UserID)
+	assert.AssertTrue(t, sessionHandlerMock.CreateSessionCalls()[0].RegisteredUser)
+	assert.AssertEqual(t, "7d97abb1-2f1b-4542-8173-67e78a590ab9", sessionHandlerMock.DeleteSessionCalls()[0].ID.String())
+
+	view := box.Object.(SessionView)
+	assert.AssertEqual(t, loggedUserID().String(), view.UserID)
+	assert.AssertTrue(t, view.RegisteredUser)
+}
+
+func TestShouldNotClaimUserWithRegisteredSession(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createClaimHelperMock(box, true)
+
+	userHandlerMock := &UserHandlerMock{}
+	sessionHandlerMock := &SessionHandlerMock{}
+
+	ClaimUser(helperMock, userHandlerMock, sessionHandlerMock)
+
+	assert.AssertEqual(t, "User already registered.", box.ErrorOcurred.Error())
+	assert.AssertEqual(t, CodeConflict, box.ErrorOcurred.(CodedError).Code())
+	assert.AssertEqual(t, 0, len(userHandlerMock.ClaimAnonUserCalls()))
+}
+
+func TestShouldNotRotateSessionWhenClaimFail(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createClaimHelperMock(box, false)
+
+	userHandlerMock := &UserHandlerMock{
+		ClaimAnonUserFunc: func(ID kallax.ULID, d *UserCreationData, events ...Event) (*User, error) {
+			return nil, ErrLoginTaken("Login already taken.")
+		},
+	}
+	sessionHandlerMock := &SessionHandlerMock{}
+
+	ClaimUser(helperMock, userHandlerMock, sessionHandlerMock)
+
+	assert.AssertEqual(t, CodeLoginTaken, box.ErrorOcurred.(CodedError).Code())
+	assert.AssertEqual(t, 0, len(sessionHandlerMock.CreateSessionCalls()))
+	assert.AssertEqual(t, 0, len(sessionHandlerMock.DeleteSessionCalls()))
+}
+
+func TestLogout(t *testing.T) {
+	helperMock := &HTTPHelperMock{
+		ProcessFunc:         helperMockProcessFunc,
+		ValidateSessionFunc: func() error { return nil },
+		GetRequestSessionIDFunc: func() (string, error) {
+			return "7d97abb1-2f1b-4542-8173-67e78a590ab9", nil
+		},
+	}
+
+	sessionHandlerMock := &SessionHandlerMock{
+		DeleteSessionFunc: func(id kallax.ULID) error {
+			return nil
+		},
+	}
+
+	Logout(helperMock, sessionHandlerMock)
+
+	assert.AssertEqual(t, 1, len(sessionHandlerMock.DeleteSessionCalls()))
+	assert.AssertEqual(t, "7d97abb1-2f1b-4542-8173-67e78a590ab9", sessionHandlerMock.DeleteSessionCalls()[0].ID.String())
+}
+
+func TestShouldNotLogoutWithoutSession(t *testing.T) {
+	helperMock := &HTTPHelperMock{
+		ValidateSessionFunc: func() error { return ErrSessionExpired("Session expired. Must log in again.") },
+		ForbidFunc:          func(err error) {},
+	}
+
+	sessionHandlerMock := &SessionHandlerMock{}
+
+	Logout(helperMock, sessionHandlerMock)
+
+	assert.AssertEqual(t, 1, len(helperMock.ForbidCalls()))
+	assert.AssertEqual(t, 0, len(sessionHandlerMock.DeleteSessionCalls()))
+}
+
+func TestShouldNotCreateUserWhenSaveFail(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := &HTTPHelperMock{
+		ProcessFunc: helperMockProcessFuncBoxedInputed(box, &UserCreationData{}),
+	}
+	handlerMock := &UserHandlerMock{
+		CreateUserFromDataFunc: func(d *UserCreationData) (User, error) {
+			return User{}, nil
+		},
+		SaveUserFunc: func(user User, events ...Event) (User, error) {
+			return user, fmt.Errorf("Disk full")
+		},
+	}
+
+	CreateUser(helperMock, handlerMock)
+
+	assert.AssertEqual(t, "Disk full", box.ErrorOcurred.Error())
+	assert.AssertEqual(t, CodeInternal, AsCodedError(box.ErrorOcurred).Code())
+}
+
+func TestShouldNotVisitWhenAnonUserNotSaved(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := &HTTPHelperMock{
+		ProcessFunc: helperMockProcessFuncBoxed(box),
+	}
+	userHandlerMock := &UserHandlerMock{
+		CreateAnonUserFunc: func() (User, error) {
+			return User{}, fmt.Errorf("Disk full")
+		},
+	}
+	sessionHandlerMock := &SessionHandlerMock{}
+
+	Visit(helperMock, userHandlerMock, sessionHandlerMock)
+
+	assert.AssertEqual(t, "Disk full", box.ErrorOcurred.Error())
+	assert.AssertEqual(t, 0, len(sessionHandlerMock.CreateSessionCalls()))
+}
+
+func TestShouldNotLoginWhenSessionNotSaved(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := &HTTPHelperMock{
+		ProcessFunc: helperMockProcessFuncBoxedInputed(box, &LoginData{}),
+	}
+	userHandlerMock := &UserHandlerMock{
+		FindUserByLoginAndPasswordFunc: func(login, password string) (*User, error) {
+			return &User{ID: kallax.NewULID()}, nil
+		},
+	}
+	sessionHandlerMock := &SessionHandlerMock{
+		CreateSessionFunc: func(ID kallax.ULID, flag bool) (*Session, error) {
+			return nil, fmt.Errorf("Connection reset")
+		},
+	}
+
+	Login(helperMock, userHandlerMock, sessionHandlerMock)
+
+	assert.AssertEqual(t, "Connection reset", box.ErrorOcurred.Error())
+}
+
+func TestShouldNotStartCreatePollWhenSaveFail(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createAuthenticatedHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &CreatePollData{Name: "Lunch"})
+	pollHandlerMock := &PollHandlerMock{
+		SavePollFunc: func(poll Poll, events ...Event) (Poll, error) {
+			return poll, fmt.Errorf("Disk full")
+		},
+	}
+
+	StartCreatePoll(helperMock, pollHandlerMock)
+
+	assert.AssertEqual(t, "Disk full", box.ErrorOcurred.Error())
+}
+
+func TestShouldNotAddOptionWhenSaveOptionFail(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &AddOptionData{Value: "A"})
+
+	pollHandlerMock := &PollHandlerMock{
+		FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
+			return &Poll{Owner: loggedUserID()}, nil
+		},
+	}
+	pollOptionHandlerMock := &PollOptionHandlerMock{
+		SavePollOptionFunc: func(v PollOption, events ...Event) (PollOption, error) {
+			return v, fmt.Errorf("Disk full")
+		},
+	}
+
+	AddOption(helperMock, pollHandlerMock, pollOptionHandlerMock)
+
+	assert.AssertEqual(t, "Disk full", box.ErrorOcurred.Error())
+	assert.AssertEqual(t, 0, len(pollHandlerMock.SavePollCalls()))
+}
+
+func TestShouldNotRemoveOptionWhenDeleteFail(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &RemoveOptionData{
+		Value: "9d627cdc-8e4a-435e-a2f7-c9bafaa41e45",
+	})
+
+	pollHandlerMock := &PollHandlerMock{
+		FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
+			return newPollWithOption("9d627cdc-8e4a-435e-a2f7-c9bafaa41e45"), nil
+		},
+	}
+	pollOptionHandlerMock := &PollOptionHandlerMock{
+		DeletePollOptionFunc: func(pollID, id kallax.ULID, events ...Event) error {
+			return kallax.ErrNotFound
+		},
+	}
+
+	RemoveOption(helperMock, pollHandlerMock, pollOptionHandlerMock)
+
+	assert.AssertEqual(t, kallax.ErrNotFound, box.ErrorOcurred)
+	assert.AssertEqual(t, CodeNotFound, AsCodedError(box.ErrorOcurred).Code())
+	assert.AssertEqual(t, 0, len(pollHandlerMock.SavePollCalls()))
+}
+
+func TestShouldNotPublishWhenSavePollFail(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeProcessBoxedHelperMock(box)
+
+	pollHandlerMock := &PollHandlerMock{
+		FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
+			return &Poll{Owner: loggedUserID()}, nil
+		},
+		SavePollFunc: func(v Poll, events ...Event) (Poll, error) {
+			return v, fmt.Errorf("Disk full")
+		},
+	}
+
+	Publish(helperMock, pollHandlerMock, &PollOptionHandlerMock{})
+
+	assert.AssertEqual(t, "Disk full", box.ErrorOcurred.Error())
+}
+
+func createVoteFailureMocks() (*PollOptionHandlerMock, *PollVoteHandlerMock) {
+	options := newOptions("A")
+	pollOptionHandlerMock := createOptionsMock(options)
+
+	pollVoteHandlerMock := &PollVoteHandlerMock{
+		PollAlreadyVotedByUserFunc: func(pollID kallax.ULID, userID kallax.ULID) (bool, error) {
+			return false, nil
+		},
+		SaveVoteFunc: func(vote PollVote, selections []PollVoteSelection, events ...Event) (PollVote, error) {
+			return vote, nil
+		},
+		TallyByPollFunc: tallyEach(options, 1),
+		VotersOfFunc: func(pollID kallax.ULID) (int64, error) {
+			return 1, nil
+		},
+	}
+
+	return pollOptionHandlerMock, pollVoteHandlerMock
+}
+
+func TestShouldNotCreateVoteWhenSaveVoteFail(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &PollVoteData{Value: "A"})
+
+	pollOptionHandlerMock, pollVoteHandlerMock := createVoteFailureMocks()
+	pollVoteHandlerMock.SaveVoteFunc = func(vote PollVote, selections []PollVoteSelection, events ...Event) (PollVote, error) {
+		return vote, fmt.Errorf("Disk full")
+	}
+
+	CreateVote(helperMock, createOpenPollHandlerMock(), pollOptionHandlerMock, pollVoteHandlerMock, createTallyPublisherMock())
+
+	assert.AssertEqual(t, "Disk full", box.ErrorOcurred.Error())
+	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.TallyByPollCalls()))
+}
+
+func TestShouldNotCreateVoteWhenCountingFail(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &PollVoteData{Value: "A"})
+
+	pollOptionHandlerMock, pollVoteHandlerMock := createVoteFailureMocks()
+	pollVoteHandlerMock.TallyByPollFunc = func(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+		return nil, fmt.Errorf("Count failed")
+	}
+
+	publisherMock := createTallyPublisherMock()
+
+	CreateVote(helperMock, createOpenPollHandlerMock(), pollOptionHandlerMock, pollVoteHandlerMock, publisherMock)
+
+	assert.AssertEqual(t, "Count failed", box.ErrorOcurred.Error())
+	assert.AssertEqual(t, 0, len(publisherMock.PublishCalls()))
+}
+
+func TestShouldNotCountPollVotesWhenCountingFail(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeProcessBoxedHelperMock(box)
+
+	pollOptionHandlerMock := &PollOptionHandlerMock{
+		FindPollOptionsFunc: func(pollID kallax.ULID) ([]*PollOption, error) {
+			return nil, fmt.Errorf("Connection reset")
+		},
+	}
+
+	CountingPollVotes(helperMock, createOpenPollHandlerMock(), pollOptionHandlerMock, &PollVoteHandlerMock{})
+
+	assert.AssertEqual(t, "Connection reset", box.ErrorOcurred.Error())
+	assert.AssertEqual(t, CodeInternal, AsCodedError(box.ErrorOcurred).Code())
+}
+
+func TestCreateVoteConcurrentlyRegistersOneVote(t *testing.T) {
+	store, votes := newMemoryPollVoteStore()
+	pollVoteHandler := PollVoteHandlerImpl{
+		Store: store,
+	}
+
+	pollOptionHandlerMock := createOptionsMock(newOptions("A"))
+
+	const attempts = 50
+	boxes := make([]*ProcessErrorBox, attempts)
+	var wg sync.WaitGroup
+
+	for i := 0; i < attempts; i++ {
+		boxes[i] = &ProcessErrorBox{}
+		helperMock := createPollChangeHelperMock()
+		helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(boxes[i], &PollVoteData{Value: "A"})
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			CreateVote(helperMock, createOpenPollHandlerMock(), pollOptionHandlerMock, pollVoteHandler, createTallyPublisherMock())
+		}()
+	}
+
+	wg.Wait()
+
+	succeeded := 0
+	for _, box := range boxes {
+		if box.ErrorOcurred == nil {
+			succeeded++
+			continue
+		}
+
+		assert.AssertEqual(t, "You already voted in this poll", box.ErrorOcurred.Error())
+	}
+
+	assert.AssertEqual(t, 1, succeeded)
+	assert.AssertEqual(t, 1, len(votes))
+}
+
+func fixedClock(now time.Time) Clock {
+	return func() time.Time {
+		return now
+	}
+}
+
+func TestShouldNotStartCreatePollClosingBeforeOpening(t *testing.T) {
+	box := &ProcessErrorBox{}
+	opensAt := time.Date(2018, 12, 10, 12, 0, 0, 0, time.UTC)
+	closesAt := opensAt.Add(-time.Hour)
+	helperMock := createAuthenticatedHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &CreatePollData{
+		Name:     "Lunch",
+		OpensAt:  &opensAt,
+		ClosesAt: &closesAt,
+	})
+	pollHandlerMock := &PollHandlerMock{}
+
+	StartCreatePoll(helperMock, pollHandlerMock)
+
+	assert.AssertEqual(t, CodeValidation, box.ErrorOcurred.(CodedError).Code())
+	assert.AssertEqual(t, "must be after opensAt", box.ErrorOcurred.(ErrInvalidFields)["closesAt"])
+	assert.AssertEqual(t, 0, len(pollHandlerMock.SavePollCalls()))
+}
+
+func TestStartCreatePollWithTimeWindow(t *testing.T) {
+	var savedPoll Poll
+	opensAt := time.Date(2018, 12, 10, 12, 0, 0, 0, time.UTC)
+	closesAt := opensAt.Add(time.Hour)
+	helperMock := createAuthenticatedHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncInputed(&CreatePollData{
+		Name:     "Lunch",
+		OpensAt:  &opensAt,
+		ClosesAt: &closesAt,
+	})
+	pollHandlerMock := &PollHandlerMock{
+		SavePollFunc: func(poll Poll, events ...Event) (Poll, error) {
+			savedPoll = poll
+			return poll, nil
+		},
+	}
+
+	StartCreatePoll(helperMock, pollHandlerMock)
+
+	assert.AssertEqual(t, opensAt, *savedPoll.OpensAt)
+	assert.AssertEqual(t, closesAt, *savedPoll.ClosesAt)
+	assert.AssertEqual(t, PollDraft, savedPoll.Status)
+}
+
+func TestPublishSchedulesPollOpeningLater(t *testing.T) {
+	box := &ProcessErrorBox{}
+	opensAt := time.Now().Add(time.Hour)
+	poll := &Poll{Status: PollDraft, Owner: loggedUserID(), OpensAt: &opensAt}
+	pollHandlerMock := createPollInStatusHandlerMock(poll)
+
+	Publish(createTransitionHelperMock(box), pollHandlerMock, &PollOptionHandlerMock{})
+
+	assert.AssertNil(t, box.ErrorOcurred)
+	assert.AssertEqual(t, PollScheduled, poll.Status)
+	events := pollHandlerMock.SavePollCalls()[0].Events
+	assert.AssertEqual(t, []Event{PollScheduledEvent{PollID: poll.ID, OpensAt: opensAt, At: events[0].(PollScheduledEvent).At}}, events)
+}
+
+func TestShouldNotCreateVoteOutsideTimeWindow(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	for _, poll := range []*Poll{
+		&Poll{Status: PollOpen, OpensAt: &future},
+		&Poll{Status: PollOpen, ClosesAt: &past},
+	} {
+		box := &ProcessErrorBox{}
+		helperMock := createPollChangeHelperMock()
+		helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &PollVoteData{Value: "A"})
+		pollHandlerMock := &PollHandlerMock{
+			FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
+				return poll, nil
+			},
+		}
+		pollVoteHandlerMock := &PollVoteHandlerMock{}
+
+		CreateVote(helperMock, pollHandlerMock, &PollOptionHandlerMock{}, pollVoteHandlerMock, createTallyPublisherMock())
+
+		assert.AssertEqual(t, CodePollNotOpen, box.ErrorOcurred.(CodedError).Code())
+		assert.AssertEqual(t, 0, len(pollVoteHandlerMock.SaveVoteCalls()))
+	}
+}
+
+func TestRunPollSchedule(t *testing.T) {
+	now := time.Date(2018, 12, 10, 12, 0, 0, 0, time.UTC)
+	scheduled := &Poll{ID: kallax.NewULID(), Status: PollScheduled}
+	expired := &Poll{ID: kallax.NewULID(), Status: PollOpen}
+	saved := make(map[kallax.ULID]string)
+
+	pollHandlerMock := &PollHandlerMock{
+		FindPollsToOpenFunc: func(at time.Time) ([]*Poll, error) {
+			return []*Poll{scheduled}, nil
+		},
+		FindPollsToCloseFunc: func(at time.Time) ([]*Poll, error) {
+			return []*Poll{expired}, nil
+		},
+		SavePollFunc: func(poll Poll, events ...Event) (Poll, error) {
+			saved[poll.ID] = poll.Status
+			return poll, nil
+		},
+	}
+
+	changed, err := RunPollSchedule(pollHandlerMock, now)
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, 2, changed)
+	assert.AssertEqual(t, now, pollHandlerMock.FindPollsToOpenCalls()[0].Now)
+	assert.AssertEqual(t, now, pollHandlerMock.FindPollsToCloseCalls()[0].Now)
+	assert.AssertEqual(t, PollOpen, saved[scheduled.ID])
+	assert.AssertEqual(t, PollClosed, saved[expired.ID])
+	assert.AssertEqual(t, []Event{PollPublishedEvent{PollID: scheduled.ID, Status: PollOpen, At: now}},
+		pollHandlerMock.SavePollCalls()[0].Events)
+	assert.AssertEqual(t, []Event{PollClosedEvent{PollID: expired.ID, At: now}}, pollHandlerMock.SavePollCalls()[1].Events)
+}
+
+func TestRebuildTalliesOfEveryPoll(t *testing.T) {
+	polls := []*Poll{&Poll{ID: kallax.NewULID()}, &Poll{ID: kallax.NewULID()}}
+	pollHandlerMock := &PollHandlerMock{
+		FindPollsFunc: func(query *PollQuery) ([]*Poll, error) {
+			return polls, nil
+		},
+	}
+	pollVoteHandlerMock := &PollVoteHandlerMock{
+		RebuildTallyFunc: func(pollID kallax.ULID) error {
+			return nil
+		},
+	}
+
+	rebuilt, err := RebuildTallies(pollHandlerMock, pollVoteHandlerMock, nil)
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, 2, rebuilt)
+	assert.AssertEqual(t, polls[0].ID, pollVoteHandlerMock.RebuildTallyCalls()[0].PollID)
+	assert.AssertEqual(t, polls[1].ID, pollVoteHandlerMock.RebuildTallyCalls()[1].PollID)
+}
+
+func TestRebuildTalliesOfGivenPolls(t *testing.T) {
+	pollID := kallax.NewULID()
+	pollHandlerMock := &PollHandlerMock{}
+	pollVoteHandlerMock := &PollVoteHandlerMock{
+		RebuildTallyFunc: func(pollID kallax.ULID) error {
+			return nil
+		},
+	}
+
+	rebuilt, err := RebuildTallies(pollHandlerMock, pollVoteHandlerMock, []kallax.ULID{pollID})
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, 1, rebuilt)
+	assert.AssertEqual(t, 0, len(pollHandlerMock.FindPollsCalls()))
+	assert.AssertEqual(t, pollID, pollVoteHandlerMock.RebuildTallyCalls()[0].PollID)
+}
+
+func TestShouldStopRebuildingTalliesWhenRebuildFail(t *testing.T) {
+	pollVoteHandlerMock := &PollVoteHandlerMock{
+		RebuildTallyFunc: func(pollID kallax.ULID) error {
+			return fmt.Errorf("Connection reset")
+		},
+	}
+
+	rebuilt, err := RebuildTallies(&PollHandlerMock{}, pollVoteHandlerMock, []kallax.ULID{kallax.NewULID(), kallax.NewULID()})
+
+	assert.AssertEqual(t, "Connection reset", err.Error())
+	assert.AssertEqual(t, 0, rebuilt)
+	assert.AssertEqual(t, 1, len(pollVoteHandlerMock.RebuildTallyCalls()))
+}
+
+func TestShouldStopPollScheduleWhenSaveFail(t *testing.T) {
+	pollHandlerMock := &PollHandlerMock{
+		FindPollsToOpenFunc: func(at time.Time) ([]*Poll, error) {
+			return []*Poll{&Poll{Status: PollScheduled}}, nil
+		},
+		SavePollFunc: func(poll Poll, events ...Event) (Poll, error) {
+			return poll, fmt.Errorf("Disk full")
+		},
+	}
+
+	changed, err := RunPollSchedule(pollHandlerMock, time.Now())
+
+	assert.AssertEqual(t, "Disk full", err.Error())
+	assert.AssertEqual(t, 0, changed)
+	assert.AssertEqual(t, 0, len(pollHandlerMock.FindPollsToCloseCalls()))
+}
+
+func TestSchedulePollsUsesClock(t *testing.T) {
+	now := time.Date(2018, 12, 10, 12, 0, 0, 0, time.UTC)
+	asked := make(chan time.Time, 1)
+	pollHandlerMock := &PollHandlerMock{
+		FindPollsToOpenFunc: func(at time.Time) ([]*Poll, error) {
+			select {
+			case asked <- at:
+			default:
+			}
+			return []*Poll{}, nil
+		},
+		FindPollsToCloseFunc: func(at time.Time) ([]*Poll, error) {
+			return []*Poll{}, nil
+		},
+	}
+
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		SchedulePolls(pollHandlerMock, fixedClock(now), time.Millisecond, done)
+		close(finished)
+	}()
+
+	at := <-asked
+	close(done)
+	<-finished
+
+	assert.AssertEqual(t, now, at)
+}
+
+func TestChangeVote(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &PollVoteData{Value: "B"})
+	voteID := kallax.NewULID()
+	options := newOptions("A", "B")
+
+	pollVoteHandlerMock := &PollVoteHandlerMock{
+		ChangeVoteFunc: func(pollID kallax.ULID, userID kallax.ULID, chosen string, selections []PollVoteSelection, events ...Event) (PollVote, error) {
+			return PollVote{ID: voteID, PollID: pollID, UserID: userID, ChosenOption: chosen}, nil
+		},
+		TallyByPollFunc: tallyEach(options, 1),
+		VotersOfFunc: func(pollID kallax.ULID) (int64, error) {
+			return 2, nil
+		},
+	}
+
+	ChangeVote(helperMock, createOpenPollHandlerMock(), createOptionsMock(options), pollVoteHandlerMock, createTallyPublisherMock())
+
+	assert.AssertNil(t, box.ErrorOcurred)
+	assert.AssertEqual(t, 1, len(pollVoteHandlerMock.ChangeVoteCalls()))
+	assert.AssertEqual(t, loggedUserID(), pollVoteHandlerMock.ChangeVoteCalls()[0].UserID)
+	assert.AssertEqual(t, "B", pollVoteHandlerMock.ChangeVoteCalls()[0].Chosen)
+	assert.AssertEqual(t, 1, len(pollVoteHandlerMock.ChangeVoteCalls()[0].Selections))
+	changed := pollVoteHandlerMock.ChangeVoteCalls()[0].Events[0].(VoteChangedEvent)
+	assert.AssertEqual(t, EventVoteChanged, changed.EventName())
+	assert.AssertEqual(t, loggedUserID(), changed.UserID)
+	assert.AssertEqual(t, []kallax.ULID{options[1].ID}, changed.OptionIDs)
+	view := box.Object.(VoteResultView)
+	assert.AssertEqual(t, voteID.String(), view.VoteID)
+	assert.AssertEqual(t, 2.0, view.Counting["total"])
+}
+
+func TestShouldNotChangeVoteToUnknownOption(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &PollVoteData{Value: "Z"})
+	pollOptionHandlerMock := createOptionsMock(newOptions("Primeira"))
+	pollVoteHandlerMock := &PollVoteHandlerMock{}
+
+	ChangeVote(helperMock, createOpenPollHandlerMock(), pollOptionHandlerMock, pollVoteHandlerMock, createTallyPublisherMock())
+
+	assert.AssertEqual(t, CodeValidation, box.ErrorOcurred.(CodedError).Code())
+	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.ChangeVoteCalls()))
+}
+
+func TestShouldNotChangeVoteOnClosedPoll(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &PollVoteData{Value: "B"})
+	pollHandlerMock := &PollHandlerMock{
+		FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
+			return &Poll{ID: ID, Status: PollClosed}, nil
+		},
+	}
+	pollVoteHandlerMock := &PollVoteHandlerMock{}
+
+	ChangeVote(helperMock, pollHandlerMock, &PollOptionHandlerMock{}, pollVoteHandlerMock, createTallyPublisherMock())
+
+	assert.AssertEqual(t, CodePollNotOpen, box.ErrorOcurred.(CodedError).Code())
+	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.ChangeVoteCalls()))
+}
+
+func TestRetractVote(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &PollVoteData{})
+	voteID := kallax.NewULID()
+
+	pollVoteHandlerMock := &PollVoteHandlerMock{
+		RetractVoteFunc: func(pollID kallax.ULID, userID kallax.ULID, events ...Event) (PollVote, error) {
+			return PollVote{ID: voteID, PollID: pollID, UserID: userID, ChosenOption: "A"}, nil
+		},
+		TallyByPollFunc: func(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+			return map[kallax.ULID]int64{}, nil
+		},
+		VotersOfFunc: func(pollID kallax.ULID) (int64, error) {
+			return 0, nil
+		},
+	}
+
+	RetractVote(helperMock, createOpenPollHandlerMock(), createOptionsMock(newOptions("A", "B")), pollVoteHandlerMock, createTallyPublisherMock())
+
+	assert.AssertNil(t, box.ErrorOcurred)
+	assert.AssertEqual(t, 1, len(pollVoteHandlerMock.RetractVoteCalls()))
+	retracted := pollVoteHandlerMock.RetractVoteCalls()[0].Events[0].(VoteRetractedEvent)
+	assert.AssertEqual(t, EventVoteRetracted, retracted.EventName())
+	assert.AssertEqual(t, loggedUserID(), retracted.UserID)
+	view := box.Object.(VoteResultView)
+	assert.AssertEqual(t, voteID.String(), view.VoteID)
+	assert.AssertEqual(t, 0.0, view.Counting["total"])
+}
+
+func TestShouldNotRetractVoteWhenNotVoted(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &PollVoteData{})
+	pollVoteHandlerMock := &PollVoteHandlerMock{
+		RetractVoteFunc: func(pollID kallax.ULID, userID kallax.ULID, events ...Event) (PollVote, error) {
+			return PollVote{}, errNotVoted
+		},
+	}
+
+	RetractVote(helperMock, createOpenPollHandlerMock(), &PollOptionHandlerMock{}, pollVoteHandlerMock, createTallyPublisherMock())
+
+	assert.AssertEqual(t, "You didn't vote in this poll", box.ErrorOcurred.Error())
+	assert.AssertEqual(t, CodeNotFound, box.ErrorOcurred.(CodedError).Code())
+}
+
+func createMultipleChoiceVoteMocks(options []*PollOption) (*PollHandlerMock, *PollVoteHandlerMock) {
+	pollHandlerMock := &PollHandlerMock{
+		FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
+			return &Poll{ID: ID, Status: PollOpen, MinChoices: 1, MaxChoices: 2}, nil
+		},
+	}
+	pollVoteHandlerMock := &PollVoteHandlerMock{
+		PollAlreadyVotedByUserFunc: func(pollID kallax.ULID, userID kallax.ULID) (bool, error) {
+			return false, nil
+		},
+		SaveVoteFunc: func(vote PollVote, selections []PollVoteSelection, events ...Event) (PollVote, error) {
+			return vote, nil
+		},
+		TallyByPollFunc: func(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+			counts := map[kallax.ULID]int64{options[0].ID: 2}
+			for _, opt := range options[1:] {
+				counts[opt.ID] = 1
+			}
+
+			return counts, nil
+		},
+		VotersOfFunc: func(pollID kallax.ULID) (int64, error) {
+			return 2, nil
+		},
+	}
+
+	return pollHandlerMock, pollVoteHandlerMock
+}
+
+func TestCreateMultipleChoiceVote(t *testing.T) {
+	options := newOptions("A", "B", "C")
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &PollVoteData{
+		Options: []string{options[0].ID.String(), options[2].ID.String()},
+	})
+	pollHandlerMock, pollVoteHandlerMock := createMultipleChoiceVoteMocks(options)
+
+	CreateVote(helperMock, pollHandlerMock, createOptionsMock(options), pollVoteHandlerMock, createTallyPublisherMock())
+
+	assert.AssertNil(t, box.ErrorOcurred)
+	saved := pollVoteHandlerMock.SaveVoteCalls()[0]
+	assert.AssertEqual(t, "A, C", saved.Vote.ChosenOption)
+	assert.AssertEqual(t, 2, len(saved.Selections))
+	assert.AssertEqual(t, options[0].ID, saved.Selections[0].OptionID)
+	assert.AssertEqual(t, options[2].ID, saved.Selections[1].OptionID)
+
+	view := box.Object.(VoteResultView)
+	assert.AssertEqual(t, 4.0, view.Counting["total"])
+	assert.AssertEqual(t, 50.0, view.Counting[options[0].ID.String()])
+	assert.AssertEqual(t, 2.0, view.VoterCounting["total"])
+	assert.AssertEqual(t, 100.0, view.VoterCounting[options[0].ID.String()])
+	assert.AssertEqual(t, 50.0, view.VoterCounting[options[2].ID.String()])
+}
+
+func TestShouldNotCreateVoteWithTooManyChoices(t *testing.T) {
+	options := newOptions("A", "B", "C")
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &PollVoteData{
+		Options: []string{options[0].ID.String(), options[1].ID.String(), options[2].ID.String()},
+	})
+	pollHandlerMock, pollVoteHandlerMock := createMultipleChoiceVoteMocks(options)
+
+	CreateVote(helperMock, pollHandlerMock, createOptionsMock(options), pollVoteHandlerMock, createTallyPublisherMock())
+
+	assert.AssertEqual(t, CodeValidation, box.ErrorOcurred.(CodedError).Code())
+	assert.AssertEqual(t, "Choose from 1 to 2 options on this poll", box.ErrorOcurred.Error())
+	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.SaveVoteCalls()))
+}
+
+func TestShouldNotCreateVoteChoosingOptionTwice(t *testing.T) {
+	options := newOptions("A", "B")
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &PollVoteData{
+		Options: []string{options[1].ID.String(), options[1].ID.String()},
+	})
+	pollHandlerMock, pollVoteHandlerMock := createMultipleChoiceVoteMocks(options)
+
+	CreateVote(helperMock, pollHandlerMock, createOptionsMock(options), pollVoteHandlerMock, createTallyPublisherMock())
+
+	assert.AssertEqual(t, CodeValidation, box.ErrorOcurred.(CodedError).Code())
+	assert.AssertEqual(t, fmt.Sprintf("Option %s was chosen more than once", options[1].ID), box.ErrorOcurred.Error())
+	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.SaveVoteCalls()))
+}
+
+func TestCountingPollVoters(t *testing.T) {
+	options := newOptions("A", "B")
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeProcessBoxedHelperMock(box)
+	_, pollVoteHandlerMock := createMultipleChoiceVoteMocks(options)
+
+	CountingPollVoters(helperMock, createOptionsMock(options), pollVoteHandlerMock)
+
+	assert.AssertNil(t, box.ErrorOcurred)
+	counting := box.Object.(map[string]float64)
+	assert.AssertEqual(t, 2.0, counting["total"])
+	assert.AssertEqual(t, 100.0, counting[options[0].ID.String()])
+	assert.AssertEqual(t, 50.0, counting[options[1].ID.String()])
+}
+
+func createRankedPollHandlerMock() *PollHandlerMock {
+	return &PollHandlerMock{
+		FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
+			return &Poll{ID: ID, Status: PollOpen, VotingMethod: VotingRanked}, nil
+		},
+	}
+}
+
+func TestCreateRankedVoteRanksEveryOption(t *testing.T) {
+	options := newOptions("A", "B", "C")
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &PollVoteData{
+		Options: []string{options[2].ID.String(), options[0].ID.String(), options[1].ID.String()},
+	})
+	_, pollVoteHandlerMock := createMultipleChoiceVoteMocks(options)
+	pollVoteHandlerMock.BallotsOfFunc = func(pollID kallax.ULID) ([]Ballot, error) {
+		return []Ballot{{options[2].ID, options[0].ID, options[1].ID}}, nil
+	}
+
+	optionsMock := createOptionsMock(options)
+
+	CreateVote(helperMock, createRankedPollHandlerMock(), optionsMock, pollVoteHandlerMock, createTallyPublisherMock())
+
+	assert.AssertNil(t, box.ErrorOcurred)
+	view := box.Object.(VoteResultView)
+	assert.AssertEqual(t, options[2].ID.String(), view.Tally.(RunoffView).Winner)
+	assert.AssertEqual(t, 1.0, view.VoterCounting["total"])
+	assert.AssertEqual(t, 100.0, view.VoterCounting[options[1].ID.String()])
+	assert.AssertEqual(t, 1, len(pollVoteHandlerMock.BallotsOfCalls()))
+	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.TallyByPollCalls()))
+	saved := pollVoteHandlerMock.SaveVoteCalls()[0]
+	assert.AssertEqual(t, "C, A, B", saved.Vote.ChosenOption)
+	assert.AssertEqual(t, options[2].ID, saved.Selections[0].OptionID)
+	assert.AssertEqual(t, options[1].ID, saved.Selections[2].OptionID)
+}
+
+func TestCountingRankedPollVotes(t *testing.T) {
+	options := newOptions("A", "B", "C")
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeProcessBoxedHelperMock(box)
+	pollVoteHandlerMock := &PollVoteHandlerMock{
+		BallotsOfFunc: func(pollID kallax.ULID) ([]Ballot, error) {
+			return []Ballot{
+				{options[0].ID},
+				{options[0].ID},
+				{options[1].ID, options[0].ID},
+				{options[2].ID, options[1].ID},
+				{options[2].ID, options[1].ID},
+			}, nil
+		},
+	}
+
+	CountingPollVotes(helperMock, createRankedPollHandlerMock(), createOptionsMock(options), pollVoteHandlerMock)
+
+	assert.AssertNil(t, box.ErrorOcurred)
+	view := box.Object.(RunoffView)
+	assert.AssertEqual(t, VotingRanked, view.Method)
+	assert.AssertEqual(t, options[0].ID.String(), view.Winner)
+	assert.AssertEqual(t, 2, len(view.Rounds))
+	assert.AssertEqual(t, 1, view.Rounds[0].Round)
+	assert.AssertEqual(t, []string{options[1].ID.String()}, view.Rounds[0].Eliminated)
+	assert.AssertEqual(t, int64(3), view.Rounds[1].Counting[options[0].ID.String()])
+	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.TallyByPollCalls()))
+}
+
+func TestShouldNotCountRankedPollVotesWhenBallotsFail(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeProcessBoxedHelperMock(box)
+	pollVoteHandlerMock := &PollVoteHandlerMock{
+		BallotsOfFunc: func(pollID kallax.ULID) ([]Ballot, error) {
+			return nil, fmt.Errorf("Connection reset")
+		},
+	}
+
+	CountingPollVotes(helperMock, createRankedPollHandlerMock(), createOptionsMock(newOptions("A")), pollVoteHandlerMock)
+
+	assert.AssertEqual(t, "Connection reset", box.ErrorOcurred.Error())
+}
+
+func createScorePollHandlerMock() *PollHandlerMock {
+	return &PollHandlerMock{
+		FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
+			return &Poll{ID: ID, Status: PollOpen, VotingMethod: VotingScore, MaxScore: 10}, nil
+		},
+	}
+}
+
+func TestCreateScoreVote(t *testing.T) {
+	options := newOptions("A", "B", "C")
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &PollVoteData{
+		Scores: map[string]int{options[2].ID.String(): 10, options[0].ID.String(): 0},
+	})
+	_, pollVoteHandlerMock := createMultipleChoiceVoteMocks(options)
+	pollVoteHandlerMock.ScoresOfFunc = func(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+		return map[kallax.ULID]int64{options[2].ID: 10}, nil
+	}
+
+	CreateVote(helperMock, createScorePollHandlerMock(), createOptionsMock(options), pollVoteHandlerMock, createTallyPublisherMock())
+
+	assert.AssertNil(t, box.ErrorOcurred)
+	saved := pollVoteHandlerMock.SaveVoteCalls()[0]
+	assert.AssertEqual(t, "A, C", saved.Vote.ChosenOption)
+	assert.AssertEqual(t, 0, saved.Selections[0].Score)
+	assert.AssertEqual(t, 10, saved.Selections[1].Score)
+	tally := box.Object.(VoteResultView).Tally.(ScoreView)
+	assert.AssertEqual(t, []string{options[2].ID.String()}, tally.Winners)
+}
+
+func TestShouldNotCreateScoreVoteAboveScale(t *testing.T) {
+	options := newOptions("A", "B")
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &PollVoteData{
+		Scores: map[string]int{options[1].ID.String(): 11},
+	})
+	_, pollVoteHandlerMock := createMultipleChoiceVoteMocks(options)
+
+	CreateVote(helperMock, createScorePollHandlerMock(), createOptionsMock(options), pollVoteHandlerMock, createTallyPublisherMock())
+
+	assert.AssertEqual(t, CodeValidation, box.ErrorOcurred.(CodedError).Code())
+	assert.AssertEqual(t, fmt.Sprintf("Score of option %s must be from 0 to 10", options[1].ID), box.ErrorOcurred.Error())
+	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.SaveVoteCalls()))
+}
+
+func TestCountingBordaPollVotes(t *testing.T) {
+	options := newOptions("A", "B")
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeProcessBoxedHelperMock(box)
+	pollHandlerMock := &PollHandlerMock{
+		FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
+			return &Poll{ID: ID, VotingMethod: VotingBorda}, nil
+		},
+	}
+	pollVoteHandlerMock := &PollVoteHandlerMock{
+		BallotsOfFunc: func(pollID kallax.ULID) ([]Ballot, error) {
+			return []Ballot{{options[1].ID, options[0].ID}}, nil
+		},
+	}
+
+	CountingPollVotes(helperMock, pollHandlerMock, createOptionsMock(options), pollVoteHandlerMock)
+
+	assert.AssertNil(t, box.ErrorOcurred)
+	view := box.Object.(BordaView)
+	assert.AssertEqual(t, VotingBorda, view.Method)
+	assert.AssertEqual(t, int64(1), view.Points[options[1].ID.String()])
+	assert.AssertEqual(t, []string{options[1].ID.String()}, view.Winners)
+}
+
+func TestCreateVoteByOptionID(t *testing.T) {
+	options := newOptions("Same", "Same")
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &PollVoteData{Option: options[1].ID.String()})
+	_, pollVoteHandlerMock := createMultipleChoiceVoteMocks(options)
+
+	CreateVote(helperMock, createOpenPollHandlerMock(), createOptionsMock(options), pollVoteHandlerMock, createTallyPublisherMock())
+
+	assert.AssertNil(t, box.ErrorOcurred)
+	saved := pollVoteHandlerMock.SaveVoteCalls()[0]
+	assert.AssertEqual(t, 1, len(saved.Selections))
+	assert.AssertEqual(t, options[1].ID, saved.Selections[0].OptionID)
+}
+
+func TestShouldNotCreateVoteByAmbiguousContent(t *testing.T) {
+	options := newOptions("Same", "Same")
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &PollVoteData{Value: "Same"})
+	_, pollVoteHandlerMock := createMultipleChoiceVoteMocks(options)
+
+	CreateVote(helperMock, createOpenPollHandlerMock(), createOptionsMock(options), pollVoteHandlerMock, createTallyPublisherMock())
+
+	assert.AssertEqual(t, CodeValidation, box.ErrorOcurred.(CodedError).Code())
+	assert.AssertEqual(t, "There are 2 options Same on this poll, choose one by its ID", box.ErrorOcurred.Error())
+	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.SaveVoteCalls()))
+}
+
+func createWebhookHandlerMock() *WebhookHandlerMock {
+	return &WebhookHandlerMock{
+		SaveWebhookFunc: func(webhook PollWebhook) (PollWebhook, error) {
+			return webhook, nil
+		},
+	}
+}
+
+func TestCreateWebhook(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &CreateWebhookData{
+		URL:    "https://example.com/hooks/poll",
+		Events: []string{TriggerClosed, TriggerVotes},
+		Votes:  10,
+	})
+	pollHandlerMock := createPollInStatusHandlerMock(&Poll{Status: PollOpen, Owner: loggedUserID()})
+	webhookHandlerMock := createWebhookHandlerMock()
+
+	CreateWebhook(helperMock, pollHandlerMock, webhookHandlerMock)
+
+	assert.AssertNil(t, box.ErrorOcurred)
+	saved := webhookHandlerMock.SaveWebhookCalls()[0].Webhook
+	assert.AssertEqual(t, getPollIDVarValue("id"), saved.PollID.String())
+	assert.AssertEqual(t, "https://example.com/hooks/poll", saved.URL)
+	assert.AssertFalse(t, saved.OnPublish)
+	assert.AssertTrue(t, saved.OnClose)
+	assert.AssertEqual(t, 10, saved.VoteThreshold)
+	assert.AssertEqual(t, 64, len(saved.Secret))
+	assert.AssertEqual(t, []string{TriggerClosed, TriggerVotes}, saved.Triggers())
+}
+
+func TestCreateWebhookKeepsGivenSecret(t *testing.T) {
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncInputed(&CreateWebhookData{
+		URL:    "https://example.com/hook",
+		Secret: "s3cr3t",
+		Events: []string{TriggerPublished},
+	})
+	webhookHandlerMock := createWebhookHandlerMock()
+
+	CreateWebhook(helperMock, createPollInStatusHandlerMock(&Poll{Owner: loggedUserID()}), webhookHandlerMock)
+
+	assert.AssertEqual(t, "s3cr3t", webhookHandlerMock.SaveWebhookCalls()[0].Webhook.Secret)
+}
+
+func TestShouldNotCreateWebhookOnPollOfOtherUser(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &CreateWebhookData{
+		URL:    "https://example.com/hook",
+		Events: []string{TriggerPublished},
+	})
+	webhookHandlerMock := createWebhookHandlerMock()
+
+	CreateWebhook(helperMock, createPollInStatusHandlerMock(&Poll{Owner: kallax.NewULID()}), webhookHandlerMock)
+
+	assert.AssertEqual(t, CodeForbidden, box.ErrorOcurred.(CodedError).Code())
+	assert.AssertEqual(t, 0, len(webhookHandlerMock.SaveWebhookCalls()))
+}
+
+func TestShouldNotCreateInvalidWebhook(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createPollChangeHelperMock()
+	helperMock.ProcessFunc = helperMockProcessFuncBoxedInputed(box, &CreateWebhookData{
+		URL:    "ftp://example.com/hook",
+		Events: []string{TriggerVotes},
+	})
+	pollHandlerMock := createPollInStatusHandlerMock(&Poll{Owner: loggedUserID()})
+
+	CreateWebhook(helperMock, pollHandlerMock, createWebhookHandlerMock())
+
+	assert.AssertEqual(t, CodeValidation, box.ErrorOcurred.(CodedError).Code())
+	assert.AssertEqual(t, "url: must be an absolute http or https URL; votes: must be positive", box.ErrorOcurred.Error())
+	assert.AssertEqual(t, 0, len(pollHandlerMock.FindPollByIDCalls()))
 }
@@ -1,5 +1,11 @@
 package app
 
+import (
+	"time"
+
+	kallax "gopkg.in/src-d/go-kallax.v1"
+)
+
 //UserCreationData ...
 type UserCreationData struct {
 	Login           string `json:"login,omitempty"`
@@ -16,7 +22,18 @@ type LoginData struct {
 
 //CreatePollData ...
 type CreatePollData struct {
-	Name string `json:"name,omitempty"`
+	Name         string     `json:"name,omitempty"`
+	OpensAt      *time.Time `json:"opensAt,omitempty"`
+	ClosesAt     *time.Time `json:"closesAt,omitempty"`
+	MinChoices   int        `json:"minChoices,omitempty"`
+	MaxChoices   int        `json:"maxChoices,omitempty"`
+	VotingMethod string     `json:"votingMethod,omitempty"`
+	MaxScore     int        `json:"maxScore,omitempty"`
+}
+
+//ReopenPollData can move the closing time of a poll that is reopened.
+type ReopenPollData struct {
+	ClosesAt *time.Time `json:"closesAt,omitempty"`
 }
 
 //AddOptionData ...
@@ -29,13 +46,450 @@ type RemoveOptionData struct {
 	Value string `json:"value,omitempty"`
 }
 
-//PollVoteData ...
+//ReorderOptionsData lists every option ID of the poll in the wanted order.
+type ReorderOptionsData struct {
+	Order []string `json:"order,omitempty"`
+}
+
+//PollVoteData carries the IDs of the chosen options, in order of preference on ranked
+//and Borda polls. Single-choice clients can send the ID in Option instead. Score polls
+//take Scores, the score given to each option ID. Value is kept for older clients and
+//holds the content of the chosen option; it is refused when options share that content.
 type PollVoteData struct {
-	Value string `json:"value,omitempty"`
+	Option  string         `json:"option,omitempty"`
+	Options []string       `json:"options,omitempty"`
+	Scores  map[string]int `json:"scores,omitempty"`
+	Value   string         `json:"value,omitempty"`
+}
+
+//CreateWebhookData tells where to notify and on which triggers: published, closed and
+//votes. Votes is the number of voters that fires the votes trigger. A secret is generated
+//when none is given.
+type CreateWebhookData struct {
+	URL    string   `json:"url,omitempty"`
+	Secret string   `json:"secret,omitempty"`
+	Events []string `json:"events,omitempty"`
+	Votes  int      `json:"votes,omitempty"`
 }
 
 //PollVoteResult ...
 type PollVoteResult struct {
-	VoteID       string
-	VoteCounting map[string]float64
+	VoteID        string
+	VoteCounting  map[string]float64
+	VoterCounting map[string]float64
+	Tally         TallyResult
+}
+
+// Response views. They are the public contract of the API: renaming a json
+// tag here breaks clients, so add new fields instead of changing existing ones.
+
+//ErrorResponse is the envelope sent for every failed request.
+type ErrorResponse struct {
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+//UserView ...
+type UserView struct {
+	ID         string `json:"id"`
+	Login      string `json:"login"`
+	Name       string `json:"name"`
+	Registered bool   `json:"registered"`
+}
+
+//SessionView ...
+type SessionView struct {
+	ID             string    `json:"id"`
+	UserID         string    `json:"userId"`
+	RegisteredUser bool      `json:"registeredUser"`
+	ExpiresAt      time.Time `json:"expiresAt"`
+}
+
+//PollView ...
+type PollView struct {
+	ID           string           `json:"id"`
+	Name         string           `json:"name"`
+	Published    bool             `json:"published"`
+	Status       string           `json:"status"`
+	Mine         bool             `json:"mine"`
+	Options      []PollOptionView `json:"options"`
+	MinChoices   int              `json:"minChoices"`
+	MaxChoices   int              `json:"maxChoices"`
+	VotingMethod string           `json:"votingMethod"`
+	MaxScore     int              `json:"maxScore,omitempty"`
+	OpensAt      *time.Time       `json:"opensAt,omitempty"`
+	ClosesAt     *time.Time       `json:"closesAt,omitempty"`
+	CreatedAt    time.Time        `json:"createdAt"`
+	UpdatedAt    time.Time        `json:"updatedAt"`
+}
+
+//PollOptionView ...
+type PollOptionView struct {
+	ID       string `json:"id"`
+	Content  string `json:"content"`
+	Position int    `json:"position"`
+}
+
+//VoteResultView ...
+type VoteResultView struct {
+	VoteID        string             `json:"voteId"`
+	Counting      map[string]float64 `json:"counting"`
+	VoterCounting map[string]float64 `json:"voterCounting"`
+	Tally         interface{}        `json:"tally"`
+}
+
+//The tally views key their counts by option ID, and name winners, eliminated and tied
+//options by ID too, since options may share a content. Options gives what each ID reads.
+
+//PluralityView ...
+type PluralityView struct {
+	Method        string             `json:"method"`
+	Options       []PollOptionView   `json:"options"`
+	Counting      map[string]float64 `json:"counting"`
+	VoterCounting map[string]float64 `json:"voterCounting"`
+}
+
+//ApprovalView counts the voters that approved each option. Counting holds their share of the voters.
+type ApprovalView struct {
+	Method    string             `json:"method"`
+	Options   []PollOptionView   `json:"options"`
+	Approvals map[string]int64   `json:"approvals"`
+	Counting  map[string]float64 `json:"counting"`
+	Voters    int64              `json:"voters"`
+	Winners   []string           `json:"winners"`
+}
+
+//ScoreView ...
+type ScoreView struct {
+	Method   string             `json:"method"`
+	Options  []PollOptionView   `json:"options"`
+	Totals   map[string]int64   `json:"totals"`
+	Averages map[string]float64 `json:"averages"`
+	MaxScore int                `json:"maxScore"`
+	Voters   int64              `json:"voters"`
+	Winners  []string           `json:"winners"`
+}
+
+//BordaView ...
+type BordaView struct {
+	Method  string           `json:"method"`
+	Options []PollOptionView `json:"options"`
+	Points  map[string]int64 `json:"points"`
+	Winners []string         `json:"winners"`
+}
+
+//RunoffView ...
+type RunoffView struct {
+	Method  string            `json:"method"`
+	Options []PollOptionView  `json:"options"`
+	Rounds  []RunoffRoundView `json:"rounds"`
+	Winner  string            `json:"winner,omitempty"`
+	Tied    []string          `json:"tied,omitempty"`
+}
+
+//RunoffRoundView ...
+type RunoffRoundView struct {
+	Round      int              `json:"round"`
+	Counting   map[string]int64 `json:"counting"`
+	Eliminated []string         `json:"eliminated"`
+	Exhausted  int64            `json:"exhausted"`
+}
+
+//WebhookView ...
+type WebhookView struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Votes     int       `json:"votes,omitempty"`
+	Secret    string    `json:"secret"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+//WebhookPayload is the body posted to the webhooks.
+type WebhookPayload struct {
+	Event      string    `json:"event"`
+	PollID     string    `json:"pollId"`
+	Status     string    `json:"status,omitempty"`
+	Votes      int64     `json:"votes,omitempty"`
+	DeliveryID string    `json:"deliveryId"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+//OutboxMetricsView ...
+type OutboxMetricsView struct {
+	Relayed    int64     `json:"relayed"`
+	Failures   int64     `json:"failures"`
+	Dead       int64     `json:"dead"`
+	Pending    int64     `json:"pending"`
+	LagSeconds float64   `json:"lagSeconds"`
+	LastRunAt  time.Time `json:"lastRunAt"`
+}
+
+//PollExportView is the poll part of a results export.
+type PollExportView struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	Status       string     `json:"status"`
+	VotingMethod string     `json:"votingMethod"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	OpensAt      *time.Time `json:"opensAt,omitempty"`
+	ClosesAt     *time.Time `json:"closesAt,omitempty"`
+	Voters       int64      `json:"voters"`
+	Selections   int64      `json:"selections"`
+}
+
+//OptionResultView is how many selections an option got in a results export.
+type OptionResultView struct {
+	ID       string  `json:"id"`
+	Position int     `json:"position"`
+	Content  string  `json:"content"`
+	Votes    int64   `json:"votes"`
+	Percent  float64 `json:"percent"`
+}
+
+//NewUserView ...
+func NewUserView(user User) UserView {
+	return UserView{
+		ID:         user.ID.String(),
+		Login:      user.Login,
+		Name:       user.Name,
+		Registered: user.IsRegistered(),
+	}
+}
+
+//NewSessionView ...
+func NewSessionView(session *Session) SessionView {
+	return SessionView{
+		ID:             session.ID.String(),
+		UserID:         session.UserID.String(),
+		RegisteredUser: session.RegisteredUser,
+		ExpiresAt:      session.ExpiresAt,
+	}
+}
+
+//NewPollView maps the poll as seen by the viewer.
+func NewPollView(poll *Poll, viewerID kallax.ULID) PollView {
+	options := make([]PollOptionView, 0, len(poll.Options))
+	for _, option := range poll.Options {
+		options = append(options, NewPollOptionView(option))
+	}
+
+	min, max := poll.ChoiceRange()
+	maxScore := 0
+	if poll.IsScored() {
+		maxScore = poll.ScoreScale()
+	}
+
+	return PollView{
+		ID:           poll.ID.String(),
+		Name:         poll.Name,
+		Published:    poll.IsPublished(),
+		Status:       poll.CurrentStatus(),
+		Mine:         poll.Owner == viewerID,
+		Options:      options,
+		MinChoices:   min,
+		MaxChoices:   max,
+		VotingMethod: poll.CurrentVotingMethod(),
+		MaxScore:     maxScore,
+		OpensAt:      poll.OpensAt,
+		ClosesAt:     poll.ClosesAt,
+		CreatedAt:    poll.CreatedAt,
+		UpdatedAt:    poll.UpdatedAt,
+	}
+}
+
+//NewPollViews ...
+func NewPollViews(polls []*Poll, viewerID kallax.ULID) []PollView {
+	views := make([]PollView, 0, len(polls))
+	for _, poll := range polls {
+		views = append(views, NewPollView(poll, viewerID))
+	}
+
+	return views
+}
+
+//NewPollOptionView ...
+func NewPollOptionView(option *PollOption) PollOptionView {
+	return PollOptionView{
+		ID:       option.ID.String(),
+		Content:  option.Content,
+		Position: option.Position,
+	}
+}
+
+//NewPollOptionViews ...
+func NewPollOptionViews(options []*PollOption) []PollOptionView {
+	views := make([]PollOptionView, 0, len(options))
+	for _, option := range options {
+		views = append(views, NewPollOptionView(option))
+	}
+
+	return views
+}
+
+//NewOptionIDs names the options by ID.
+func NewOptionIDs(options []*PollOption) []string {
+	IDs := make([]string, 0, len(options))
+	for _, option := range options {
+		IDs = append(IDs, option.ID.String())
+	}
+
+	return IDs
+}
+
+//NewCountingView keys the shares by option ID. "total" holds what they are shares of.
+func NewCountingView(shares map[kallax.ULID]float64, total int64) map[string]float64 {
+	view := make(map[string]float64)
+	view["total"] = float64(total)
+	for ID, share := range shares {
+		view[ID.String()] = share
+	}
+
+	return view
+}
+
+func newCountsView(options []*PollOption, counts map[kallax.ULID]int64) map[string]int64 {
+	view := make(map[string]int64)
+	for _, opt := range options {
+		view[opt.ID.String()] = counts[opt.ID]
+	}
+
+	return view
+}
+
+//NewVoteResultView ...
+func NewVoteResultView(result PollVoteResult) VoteResultView {
+	return VoteResultView{
+		VoteID:        result.VoteID,
+		Counting:      result.VoteCounting,
+		VoterCounting: result.VoterCounting,
+		Tally:         NewTallyView(result.Tally),
+	}
+}
+
+//NewTallyView maps the result of each voting method to its own view.
+func NewTallyView(result TallyResult) interface{} {
+	switch r := result.(type) {
+	case VoteTally:
+		return PluralityView{
+			Method:        r.Method(),
+			Options:       NewPollOptionViews(r.Options),
+			Counting:      NewCountingView(r.SelectionShares(), r.TotalSelections()),
+			VoterCounting: NewCountingView(r.VoterShares(), r.Voters),
+		}
+	case ApprovalResult:
+		return ApprovalView{
+			Method:    r.Method(),
+			Options:   NewPollOptionViews(r.Options),
+			Approvals: newCountsView(r.Options, r.Selections),
+			Counting:  NewCountingView(r.VoterShares(), r.Voters),
+			Voters:    r.Voters,
+			Winners:   NewOptionIDs(r.Winners),
+		}
+	case ScoreResult:
+		averages := make(map[string]float64)
+		for _, opt := range r.Options {
+			averages[opt.ID.String()] = r.Average(opt)
+		}
+
+		return ScoreView{
+			Method:   r.Method(),
+			Options:  NewPollOptionViews(r.Options),
+			Totals:   newCountsView(r.Options, r.Totals),
+			Averages: averages,
+			MaxScore: r.MaxScore,
+			Voters:   r.Voters,
+			Winners:  NewOptionIDs(r.Winners),
+		}
+	case BordaResult:
+		return BordaView{
+			Method:  r.Method(),
+			Options: NewPollOptionViews(r.Options),
+			Points:  newCountsView(r.Options, r.Points),
+			Winners: NewOptionIDs(r.Winners),
+		}
+	case RunoffResult:
+		return NewRunoffView(r)
+	}
+
+	return nil
+}
+
+//NewRunoffView numbers the rounds from 1.
+func NewRunoffView(result RunoffResult) RunoffView {
+	rounds := make([]RunoffRoundView, 0, len(result.Rounds))
+	for i, round := range result.Rounds {
+		counting := make(map[string]int64)
+		for ID, ballots := range round.Counting {
+			counting[ID.String()] = ballots
+		}
+
+		rounds = append(rounds, RunoffRoundView{
+			Round:      i + 1,
+			Counting:   counting,
+			Eliminated: NewOptionIDs(round.Eliminated),
+			Exhausted:  round.Exhausted,
+		})
+	}
+
+	view := RunoffView{
+		Method:  VotingRanked,
+		Options: NewPollOptionViews(result.Tally.Options),
+		Rounds:  rounds,
+	}
+	if result.Winner != nil {
+		view.Winner = result.Winner.ID.String()
+	}
+	if result.Tied != nil {
+		view.Tied = NewOptionIDs(result.Tied)
+	}
+
+	return view
+}
+
+//NewWebhookView ...
+func NewWebhookView(webhook *PollWebhook) WebhookView {
+	return WebhookView{
+		ID:        webhook.ID.String(),
+		URL:       webhook.URL,
+		Events:    webhook.Triggers(),
+		Votes:     webhook.VoteThreshold,
+		Secret:    webhook.Secret,
+		CreatedAt: webhook.CreatedAt,
+	}
+}
+
+//NewPollExportView ...
+func NewPollExportView(poll *Poll, tally VoteTally) PollExportView {
+	return PollExportView{
+		ID:           poll.ID.String(),
+		Name:         poll.Name,
+		Status:       poll.CurrentStatus(),
+		VotingMethod: poll.CurrentVotingMethod(),
+		CreatedAt:    poll.CreatedAt,
+		OpensAt:      poll.OpensAt,
+		ClosesAt:     poll.ClosesAt,
+		Voters:       tally.Voters,
+		Selections:   tally.TotalSelections(),
+	}
+}
+
+//NewOptionResultViews gives the results of the options in the order of the tally, with the
+//percentages of CountVotes.
+func NewOptionResultViews(tally VoteTally) []OptionResultView {
+	shares := tally.SelectionShares()
+
+	views := make([]OptionResultView, 0, len(tally.Options))
+	for _, option := range tally.Options {
+		views = append(views, OptionResultView{
+			ID:       option.ID.String(),
+			Position: option.Position,
+			Content:  option.Content,
+			Votes:    tally.Selections[option.ID],
+			Percent:  shares[option.ID],
+		})
+	}
+
+	return views
 }
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/src-d/go-kallax.v1"
+
+	"github.com/chai2010/assert"
+)
+
+func TestNewPollView(t *testing.T) {
+	ownerID := kallax.NewULID()
+	poll := &Poll{
+		ID:     kallax.NewULID(),
+		Name:   "Best Pizza",
+		Owner:  ownerID,
+		Status: PollClosed,
+		Options: []*PollOption{
+			&PollOption{ID: kallax.NewULID(), Content: "Margherita"},
+			&PollOption{ID: kallax.NewULID(), Content: "Pepperoni"},
+		},
+	}
+
+	view := NewPollView(poll, ownerID)
+
+	assert.AssertEqual(t, poll.ID.String(), view.ID)
+	assert.AssertEqual(t, "Best Pizza", view.Name)
+	assert.AssertTrue(t, view.Published)
+	assert.AssertEqual(t, PollClosed, view.Status)
+	assert.AssertTrue(t, view.Mine)
+	assert.AssertEqual(t, 2, len(view.Options))
+	assert.AssertEqual(t, poll.Options[1].ID.String(), view.Options[1].ID)
+	assert.AssertEqual(t, "Pepperoni", view.Options[1].Content)
+}
+
+func TestNewPollViewForOtherViewer(t *testing.T) {
+	poll := &Poll{ID: kallax.NewULID(), Owner: kallax.NewULID()}
+
+	view := NewPollView(poll, kallax.NewULID())
+
+	assert.AssertFalse(t, view.Mine)
+	assert.AssertFalse(t, view.Published)
+	assert.AssertEqual(t, PollDraft, view.Status)
+	assert.AssertEqual(t, 0, len(view.Options))
+	assert.AssertEqual(t, 1, view.MinChoices)
+	assert.AssertEqual(t, 1, view.MaxChoices)
+	assert.AssertEqual(t, VotingPlurality, view.VotingMethod)
+}
+
+func TestPollViewJSONContract(t *testing.T) {
+	view := NewPollView(&Poll{ID: kallax.NewULID(), Name: "Best Pizza"}, kallax.NewULID())
+
+	encoded, err := json.Marshal(view)
+	assert.AssertNil(t, err)
+
+	var fields map[string]interface{}
+	json.Unmarshal(encoded, &fields)
+
+	for _, key := range []string{"id", "name", "published", "status", "mine", "options", "minChoices", "maxChoices",
+		"votingMethod", "createdAt", "updatedAt"} {
+		_, present := fields[key]
+		assert.AssertTrue(t, present, key)
+	}
+	assert.AssertEqual(t, 11, len(fields))
+}
+
+func TestNewSessionView(t *testing.T) {
+	session := &Session{
+		ID:             kallax.NewULID(),
+		UserID:         kallax.NewULID(),
+		RegisteredUser: true,
+	}
+
+	view := NewSessionView(session)
+
+	assert.AssertEqual(t, session.ID.String(), view.ID)
+	assert.AssertEqual(t, session.UserID.String(), view.UserID)
+	assert.AssertTrue(t, view.RegisteredUser)
+}
+
+func TestNewVoteResultView(t *testing.T) {
+	view := NewVoteResultView(PollVoteResult{
+		VoteID:       "vote",
+		VoteCounting: map[string]float64{"A": 100, "total": 1},
+	})
+
+	assert.AssertEqual(t, "vote", view.VoteID)
+	assert.AssertEqual(t, 100.0, view.Counting["A"])
+}
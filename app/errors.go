@@ -1,5 +1,137 @@
 package app
 
+import (
+	"log"
+	"net/http"
+
+	"github.com/lib/pq"
+	"gopkg.in/src-d/go-kallax.v1"
+)
+
+//Error codes sent to clients. They are part of the API contract, so don't rename them.
+const (
+	CodeNotFound          = "not_found"
+	CodeValidation        = "validation_failed"
+	CodeConflict          = "conflict"
+	CodeInternal          = "internal_error"
+	CodeForbidden         = "forbidden"
+	CodeUnauthenticated   = "unauthenticated"
+	CodeSessionExpired    = "session_expired"
+	CodePasswordMismatch  = "password_mismatch"
+	CodePollNotChangeable = "poll_not_changeable"
+	CodeLoginTaken        = "login_taken"
+	CodePollNotOpen       = "poll_not_open"
+)
+
+//CodedError is an error that knows the code and the HTTP status it must be answered with.
+type CodedError interface {
+	error
+	Code() string
+	Status() int
+}
+
+//ErrNotFound ...
+type ErrNotFound string
+
+func (e ErrNotFound) Error() string {
+	return string(e)
+}
+
+//Code ...
+func (e ErrNotFound) Code() string {
+	return CodeNotFound
+}
+
+//Status ...
+func (e ErrNotFound) Status() int {
+	return http.StatusNotFound
+}
+
+//ErrValidation ...
+type ErrValidation string
+
+func (e ErrValidation) Error() string {
+	return string(e)
+}
+
+//Code ...
+func (e ErrValidation) Code() string {
+	return CodeValidation
+}
+
+//Status ...
+func (e ErrValidation) Status() int {
+	return http.StatusBadRequest
+}
+
+//ErrConflict ...
+type ErrConflict string
+
+func (e ErrConflict) Error() string {
+	return string(e)
+}
+
+//Code ...
+func (e ErrConflict) Code() string {
+	return CodeConflict
+}
+
+//Status ...
+func (e ErrConflict) Status() int {
+	return http.StatusConflict
+}
+
+//ErrInternal ...
+type ErrInternal string
+
+func (e ErrInternal) Error() string {
+	return string(e)
+}
+
+//Code ...
+func (e ErrInternal) Code() string {
+	return CodeInternal
+}
+
+//Status ...
+func (e ErrInternal) Status() int {
+	return http.StatusInternalServerError
+}
+
+//ErrForbidden ...
+type ErrForbidden string
+
+func (e ErrForbidden) Error() string {
+	return string(e)
+}
+
+//Code ...
+func (e ErrForbidden) Code() string {
+	return CodeForbidden
+}
+
+//Status ...
+func (e ErrForbidden) Status() int {
+	return http.StatusForbidden
+}
+
+//ErrLoginTaken ...
+type ErrLoginTaken string
+
+func (e ErrLoginTaken) Error() string {
+	return string(e)
+}
+
+//Code ...
+func (e ErrLoginTaken) Code() string {
+	return CodeLoginTaken
+}
+
+//Status ...
+func (e ErrLoginTaken) Status() int {
+	return http.StatusConflict
+}
+
 //ErrPasswordDoNotMatch ...
 type ErrPasswordDoNotMatch string
 
@@ -7,6 +139,16 @@ func (e ErrPasswordDoNotMatch) Error() string {
 	return string(e)
 }
 
+//Code ...
+func (e ErrPasswordDoNotMatch) Code() string {
+	return CodePasswordMismatch
+}
+
+//Status ...
+func (e ErrPasswordDoNotMatch) Status() int {
+	return http.StatusBadRequest
+}
+
 //ErrUserNotLogged ...
 type ErrUserNotLogged string
 
@@ -14,9 +156,101 @@ func (e ErrUserNotLogged) Error() string {
 	return string(e)
 }
 
+//Code ...
+func (e ErrUserNotLogged) Code() string {
+	return CodeUnauthenticated
+}
+
+//Status ...
+func (e ErrUserNotLogged) Status() int {
+	return http.StatusUnauthorized
+}
+
 //ErrNotChangePoll ...
 type ErrNotChangePoll string
 
 func (e ErrNotChangePoll) Error() string {
 	return string(e)
 }
+
+//Code ...
+func (e ErrNotChangePoll) Code() string {
+	return CodePollNotChangeable
+}
+
+//Status ...
+func (e ErrNotChangePoll) Status() int {
+	return http.StatusConflict
+}
+
+//ErrPollNotOpen ...
+type ErrPollNotOpen string
+
+func (e ErrPollNotOpen) Error() string {
+	return string(e)
+}
+
+//Code ...
+func (e ErrPollNotOpen) Code() string {
+	return CodePollNotOpen
+}
+
+//Status ...
+func (e ErrPollNotOpen) Status() int {
+	return http.StatusConflict
+}
+
+//ErrSessionExpired ...
+type ErrSessionExpired string
+
+func (e ErrSessionExpired) Error() string {
+	return string(e)
+}
+
+//Code ...
+func (e ErrSessionExpired) Code() string {
+	return CodeSessionExpired
+}
+
+//Status ...
+func (e ErrSessionExpired) Status() int {
+	return http.StatusUnauthorized
+}
+
+//AsCodedError classifies any error. Errors that are not coded are reported
+//as internal, and their message is only logged so storage details don't leak.
+func AsCodedError(err error) CodedError {
+	if coded, ok := err.(CodedError); ok {
+		return coded
+	}
+
+	if err == kallax.ErrNotFound {
+		return ErrNotFound("Resource not found.")
+	}
+
+	log.Println("Internal error:", err)
+
+	return ErrInternal("Internal error.")
+}
+
+//NewErrorResponse builds the envelope and the HTTP status for the error.
+//When no details are given, the ones carried by the error are used.
+func NewErrorResponse(err error, details interface{}) (int, ErrorResponse) {
+	coded := AsCodedError(err)
+
+	if detailed, ok := coded.(interface{ Details() interface{} }); ok && details == nil {
+		details = detailed.Details()
+	}
+
+	return coded.Status(), ErrorResponse{
+		Code:    coded.Code(),
+		Message: coded.Error(),
+		Details: details,
+	}
+}
+
+//violatesConstraint tells if the database refused the statement because of the named constraint.
+func violatesConstraint(err error, constraint string) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Constraint == constraint
+}
@@ -0,0 +1,328 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"gopkg.in/src-d/go-kallax.v1"
+)
+
+//Names of the domain events.
+const (
+	EventPollCreated    = "poll.created"
+	EventOptionAdded    = "poll.option_added"
+	EventOptionRemoved  = "poll.option_removed"
+	EventPollScheduled  = "poll.scheduled"
+	EventPollPublished  = "poll.published"
+	EventPollClosed     = "poll.closed"
+	EventVoteCast       = "vote.cast"
+	EventVoteChanged    = "vote.changed"
+	EventVoteRetracted  = "vote.retracted"
+	EventUserRegistered = "user.registered"
+)
+
+//Event is something that happened to a poll or a user. Each event type is named after its
+//name, poll.closed being told by PollClosedEvent.
+type Event interface {
+	EventName() string
+}
+
+//OutboxKey is embedded by the events recorded in the outbox. The relay fills it with the
+//idempotency key of the record, so handlers can tell an event they already handled.
+type OutboxKey struct {
+	Key string `json:"-"`
+}
+
+//IdempotencyKey ...
+func (k OutboxKey) IdempotencyKey() string {
+	return k.Key
+}
+
+//KeyOf gives the idempotency key of the event, or "" when it was not relayed from the outbox.
+func KeyOf(event Event) string {
+	if keyed, ok := event.(interface{ IdempotencyKey() string }); ok {
+		return keyed.IdempotencyKey()
+	}
+
+	return ""
+}
+
+//PollCreatedEvent ...
+type PollCreatedEvent struct {
+	OutboxKey
+	PollID kallax.ULID
+	Owner  kallax.ULID
+	Name   string
+	At     time.Time
+}
+
+//EventName ...
+func (e PollCreatedEvent) EventName() string {
+	return EventPollCreated
+}
+
+//OptionAddedEvent ...
+type OptionAddedEvent struct {
+	OutboxKey
+	PollID   kallax.ULID
+	OptionID kallax.ULID
+	Content  string
+	At       time.Time
+}
+
+//EventName ...
+func (e OptionAddedEvent) EventName() string {
+	return EventOptionAdded
+}
+
+//OptionRemovedEvent ...
+type OptionRemovedEvent struct {
+	OutboxKey
+	PollID   kallax.ULID
+	OptionID kallax.ULID
+	At       time.Time
+}
+
+//EventName ...
+func (e OptionRemovedEvent) EventName() string {
+	return EventOptionRemoved
+}
+
+//PollScheduledEvent tells the poll left the draft to open at OpensAt. PollPublishedEvent follows
+//when it opens.
+type PollScheduledEvent struct {
+	OutboxKey
+	PollID  kallax.ULID
+	OpensAt time.Time
+	At      time.Time
+}
+
+//EventName ...
+func (e PollScheduledEvent) EventName() string {
+	return EventPollScheduled
+}
+
+//PollPublishedEvent tells the poll opened for votes, published by its owner or by schedule.
+//Status is always open.
+type PollPublishedEvent struct {
+	OutboxKey
+	PollID kallax.ULID
+	Status string
+	At     time.Time
+}
+
+//EventName ...
+func (e PollPublishedEvent) EventName() string {
+	return EventPollPublished
+}
+
+//PollClosedEvent tells the poll stopped accepting votes, closed by its owner or by schedule.
+type PollClosedEvent struct {
+	OutboxKey
+	PollID kallax.ULID
+	At     time.Time
+}
+
+//EventName ...
+func (e PollClosedEvent) EventName() string {
+	return EventPollClosed
+}
+
+//VoteCastEvent ...
+type VoteCastEvent struct {
+	OutboxKey
+	PollID    kallax.ULID
+	VoteID    kallax.ULID
+	UserID    kallax.ULID
+	OptionIDs []kallax.ULID
+	At        time.Time
+}
+
+//EventName ...
+func (e VoteCastEvent) EventName() string {
+	return EventVoteCast
+}
+
+//VoteChangedEvent tells the user chose other options. Users vote once in each poll, so the
+//poll and the user name the vote.
+type VoteChangedEvent struct {
+	OutboxKey
+	PollID    kallax.ULID
+	UserID    kallax.ULID
+	OptionIDs []kallax.ULID
+	At        time.Time
+}
+
+//EventName ...
+func (e VoteChangedEvent) EventName() string {
+	return EventVoteChanged
+}
+
+//VoteRetractedEvent tells the user retracted the vote.
+type VoteRetractedEvent struct {
+	OutboxKey
+	PollID kallax.ULID
+	UserID kallax.ULID
+	At     time.Time
+}
+
+//EventName ...
+func (e VoteRetractedEvent) EventName() string {
+	return EventVoteRetracted
+}
+
+//UserRegisteredEvent ...
+type UserRegisteredEvent struct {
+	OutboxKey
+	UserID kallax.ULID
+	Login  string
+	At     time.Time
+}
+
+//EventName ...
+func (e UserRegisteredEvent) EventName() string {
+	return EventUserRegistered
+}
+
+//EventPublisher lets the business functions tell what happened without knowing who listens.
+//go:generate moq -out eventpublisher_moq.go . EventPublisher
+type EventPublisher interface {
+	Publish(event Event) error
+}
+
+//EventHandler reacts to an event. It fails when the event was not handled, so the
+//publisher can hand it again.
+type EventHandler func(event Event) error
+
+//ErrEventBusClosed is returned when publishing on a bus that was closed.
+var ErrEventBusClosed = errors.New("Event bus closed")
+
+//SyncEventBus hands each event to its handlers, one after the other, before Publish returns.
+//A handler that fails or panics doesn't keep the others from running; Publish returns the
+//first failure.
+type SyncEventBus struct {
+	lock     sync.RWMutex
+	handlers map[string][]EventHandler
+}
+
+//NewSyncEventBus ...
+func NewSyncEventBus() *SyncEventBus {
+	return &SyncEventBus{
+		handlers: make(map[string][]EventHandler),
+	}
+}
+
+//Subscribe registers the handler for the events with the given names, or for every event
+//when no name is given.
+func (b *SyncEventBus) Subscribe(handler EventHandler, names ...string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if len(names) == 0 {
+		names = []string{""}
+	}
+
+	for _, name := range names {
+		b.handlers[name] = append(b.handlers[name], handler)
+	}
+}
+
+//Publish ...
+func (b *SyncEventBus) Publish(event Event) error {
+	b.lock.RLock()
+	handlers := make([]EventHandler, 0, len(b.handlers[event.EventName()])+len(b.handlers[""]))
+	handlers = append(handlers, b.handlers[event.EventName()]...)
+	handlers = append(handlers, b.handlers[""]...)
+	b.lock.RUnlock()
+
+	var failure error
+	for _, handler := range handlers {
+		if err := dispatch(handler, event); err != nil {
+			log.Println("Event handler failed on", event.EventName(), err)
+			if failure == nil {
+				failure = err
+			}
+		}
+	}
+
+	return failure
+}
+
+func dispatch(handler EventHandler, event Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("Event handler panicked: %v", r)
+		}
+	}()
+
+	return handler(event)
+}
+
+//AsyncEventBus queues the events and hands them to the handlers in a goroutine of its own,
+//in the order they were published. Publish only waits when the queue is full, so it can't
+//tell whether the handlers failed; publishers that must know use Sync.
+type AsyncEventBus struct {
+	bus     *SyncEventBus
+	lock    sync.RWMutex
+	closed  bool
+	events  chan Event
+	drained chan struct{}
+}
+
+//NewAsyncEventBus creates the bus and starts handing out its events. The queue holds up
+//to buffer events.
+func NewAsyncEventBus(buffer int) *AsyncEventBus {
+	b := &AsyncEventBus{
+		bus:     NewSyncEventBus(),
+		events:  make(chan Event, buffer),
+		drained: make(chan struct{}),
+	}
+
+	go func() {
+		defer close(b.drained)
+		for event := range b.events {
+			b.bus.Publish(event)
+		}
+	}()
+
+	return b
+}
+
+//Subscribe works like SyncEventBus.Subscribe.
+func (b *AsyncEventBus) Subscribe(handler EventHandler, names ...string) {
+	b.bus.Subscribe(handler, names...)
+}
+
+//Sync gives the bus that hands the events to the same handlers without queueing, for
+//publishers that must know the handlers ran, like the outbox relay.
+func (b *AsyncEventBus) Sync() *SyncEventBus {
+	return b.bus
+}
+
+//Publish queues the event. Events published after Close are refused with ErrEventBusClosed.
+func (b *AsyncEventBus) Publish(event Event) error {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if b.closed {
+		return ErrEventBusClosed
+	}
+
+	b.events <- event
+	return nil
+}
+
+//Close stops taking events and waits for the queued ones to be handled.
+func (b *AsyncEventBus) Close() {
+	b.lock.Lock()
+	if !b.closed {
+		b.closed = true
+		close(b.events)
+	}
+	b.lock.Unlock()
+
+	<-b.drained
+}
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/chai2010/assert"
+
+	"gopkg.in/src-d/go-kallax.v1"
+)
+
+func TestSyncEventBusHandsEventsToTheirSubscribers(t *testing.T) {
+	bus := NewSyncEventBus()
+	created := make([]Event, 0)
+	everything := make([]Event, 0)
+	bus.Subscribe(func(event Event) error {
+		created = append(created, event)
+		return nil
+	}, EventPollCreated)
+	bus.Subscribe(func(event Event) error {
+		everything = append(everything, event)
+		return nil
+	})
+
+	assert.AssertNil(t, bus.Publish(PollCreatedEvent{PollID: kallax.NewULID()}))
+	assert.AssertNil(t, bus.Publish(VoteCastEvent{PollID: kallax.NewULID()}))
+
+	assert.AssertEqual(t, 1, len(created))
+	assert.AssertEqual(t, EventPollCreated, created[0].EventName())
+	assert.AssertEqual(t, 2, len(everything))
+	assert.AssertEqual(t, EventVoteCast, everything[1].EventName())
+}
+
+func TestSyncEventBusReportsHandlersThatPanic(t *testing.T) {
+	bus := NewSyncEventBus()
+	handled := 0
+	bus.Subscribe(func(event Event) error { panic("broken integration") })
+	bus.Subscribe(func(event Event) error {
+		handled++
+		return nil
+	})
+
+	err := bus.Publish(PollPublishedEvent{})
+
+	assert.AssertEqual(t, "Event handler panicked: broken integration", err.Error())
+	assert.AssertEqual(t, 1, handled)
+}
+
+func TestSyncEventBusReportsTheFirstFailure(t *testing.T) {
+	bus := NewSyncEventBus()
+	handled := 0
+	bus.Subscribe(func(event Event) error { return fmt.Errorf("Connection lost") })
+	bus.Subscribe(func(event Event) error { return fmt.Errorf("Disk full") })
+	bus.Subscribe(func(event Event) error {
+		handled++
+		return nil
+	})
+
+	err := bus.Publish(PollClosedEvent{})
+
+	assert.AssertEqual(t, "Connection lost", err.Error())
+	assert.AssertEqual(t, 1, handled)
+}
+
+func TestAsyncEventBusHandsQueuedEventsInOrder(t *testing.T) {
+	bus := NewAsyncEventBus(2)
+	var lock sync.Mutex
+	handled := make([]string, 0)
+	bus.Subscribe(func(event Event) error {
+		lock.Lock()
+		defer lock.Unlock()
+
+		handled = append(handled, event.(UserRegisteredEvent).Login)
+		return nil
+	}, EventUserRegistered)
+
+	for _, login := range []string{"ana", "bia", "caio", "duda"} {
+		assert.AssertNil(t, bus.Publish(UserRegisteredEvent{Login: login}))
+	}
+	bus.Close()
+
+	assert.AssertEqual(t, []string{"ana", "bia", "caio", "duda"}, handled)
+}
+
+func TestAsyncEventBusRefusesEventsAfterClose(t *testing.T) {
+	bus := NewAsyncEventBus(1)
+	handled := 0
+	bus.Subscribe(func(event Event) error {
+		handled++
+		return nil
+	})
+
+	bus.Close()
+	err := bus.Publish(OptionAddedEvent{})
+	bus.Close()
+
+	assert.AssertEqual(t, ErrEventBusClosed, err)
+	assert.AssertEqual(t, 0, handled)
+}
@@ -0,0 +1,385 @@
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"time"
+
+	"gopkg.in/src-d/go-kallax.v1"
+)
+
+//Export formats.
+const (
+	ExportCSV  = "csv"
+	ExportJSON = "json"
+	ExportXLSX = "xlsx"
+)
+
+//resultWriter streams a results export. The results go first, then each vote of the
+//timeline when asked for, one at a time, so large polls are never held in memory.
+type resultWriter interface {
+	begin(poll PollExportView, options []OptionResultView, timeline bool) error
+	vote(at time.Time) error
+	end() error
+}
+
+type exportFormat struct {
+	contentType string
+	newWriter   func(w io.Writer) resultWriter
+}
+
+var exportFormats = map[string]exportFormat{
+	ExportCSV: {"text/csv; charset=utf-8", func(w io.Writer) resultWriter {
+		return &csvResultWriter{csv: csv.NewWriter(w)}
+	}},
+	ExportJSON: {"application/json", func(w io.Writer) resultWriter {
+		return &jsonResultWriter{w: w}
+	}},
+	ExportXLSX: {"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", func(w io.Writer) resultWriter {
+		return &xlsxResultWriter{zip: zip.NewWriter(w)}
+	}},
+}
+
+func exportTime(at *time.Time) string {
+	if at == nil {
+		return ""
+	}
+
+	return at.UTC().Format(time.RFC3339)
+}
+
+func exportNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+//ExportPoll streams the results of the poll to its owner as CSV, JSON or XLSX, chosen by
+//the format query parameter. timeline=true adds the time of every vote, without the voters.
+func ExportPoll(helper HTTPHelper, pollHandler PollHandler, pollOptionHandler PollOptionHandler,
+	pollVoteHandler PollVoteHandler) {
+	if err := helper.ValidateSession(); err != nil {
+		helper.Forbid(err)
+		return
+	}
+
+	pollID, err := getPollIDFromRequest(helper)(nil)
+	if err != nil {
+		helper.Fail(err)
+		return
+	}
+
+	name := helper.GetQuery("format")
+	if name == "" {
+		name = ExportJSON
+	}
+
+	format, known := exportFormats[name]
+	if !known {
+		helper.Fail(ErrValidation(fmt.Sprintf("Unknown export format %s. Use csv, json or xlsx.", name)))
+		return
+	}
+
+	poll, err := pollHandler.FindPollByID(pollID.(kallax.ULID))
+	if err != nil {
+		helper.Fail(err)
+		return
+	}
+
+	if poll.Owner != helper.LoggedUserID() {
+		helper.Fail(ErrForbidden("Can't export a poll from other user."))
+		return
+	}
+
+	tally, err := TallyVotes(poll.ID, pollOptionHandler, pollVoteHandler)
+	if err != nil {
+		helper.Fail(err)
+		return
+	}
+
+	w, _ := helper.Raw()
+	w.Header().Set("Content-Type", format.contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="poll-%s.%s"`, poll.ID, name))
+
+	//The status is sent with the first bytes, so errors from here on can only be logged.
+	if err := writeExport(format.newWriter(w), poll, tally, helper.GetQuery("timeline") == "true", pollVoteHandler); err != nil {
+		log.Println("Unable to export poll", poll.ID, err)
+	}
+}
+
+func writeExport(writer resultWriter, poll *Poll, tally VoteTally, timeline bool,
+	pollVoteHandler PollVoteHandler) error {
+	if err := writer.begin(NewPollExportView(poll, tally), NewOptionResultViews(tally), timeline); err != nil {
+		return err
+	}
+
+	if timeline {
+		if err := pollVoteHandler.VoteTimeline(poll.ID, writer.vote); err != nil {
+			return err
+		}
+	}
+
+	return writer.end()
+}
+
+//csvResultWriter writes a row per record, the kind of record in the first column. Each
+//kind is preceded by a row naming its columns.
+type csvResultWriter struct {
+	csv *csv.Writer
+}
+
+func (c *csvResultWriter) begin(poll PollExportView, options []OptionResultView, timeline bool) error {
+	c.csv.Write([]string{"record", "id", "name", "status", "votingMethod", "createdAt", "opensAt", "closesAt",
+		"voters", "selections"})
+	c.csv.Write([]string{"poll", poll.ID, poll.Name, poll.Status, poll.VotingMethod, exportTime(&poll.CreatedAt),
+		exportTime(poll.OpensAt), exportTime(poll.ClosesAt), strconv.FormatInt(poll.Voters, 10),
+		strconv.FormatInt(poll.Selections, 10)})
+
+	c.csv.Write([]string{"record", "id", "position", "content", "votes", "percent"})
+	for _, option := range options {
+		c.csv.Write([]string{"option", option.ID, strconv.Itoa(option.Position), option.Content,
+			strconv.FormatInt(option.Votes, 10), exportNumber(option.Percent)})
+	}
+
+	if timeline {
+		c.csv.Write([]string{"record", "votedAt"})
+	}
+
+	return c.csv.Error()
+}
+
+func (c *csvResultWriter) vote(at time.Time) error {
+	return c.csv.Write([]string{"vote", exportTime(&at)})
+}
+
+func (c *csvResultWriter) end() error {
+	c.csv.Flush()
+	return c.csv.Error()
+}
+
+//jsonResultWriter writes {"poll": ..., "options": [...], "timeline": [...]}, the timeline
+//holding the time of each vote.
+type jsonResultWriter struct {
+	w        io.Writer
+	timeline bool
+	votes    int
+}
+
+func (j *jsonResultWriter) begin(poll PollExportView, options []OptionResultView, timeline bool) error {
+	j.timeline = timeline
+
+	pollJSON, err := json.Marshal(poll)
+	if err != nil {
+		return err
+	}
+
+	optionsJSON, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(j.w, `{"poll":%s,"options":%s`, pollJSON, optionsJSON)
+	if err == nil && timeline {
+		_, err = io.WriteString(j.w, `,"timeline":[`)
+	}
+
+	return err
+}
+
+func (j *jsonResultWriter) vote(at time.Time) error {
+	separator := ","
+	if j.votes == 0 {
+		separator = ""
+	}
+	j.votes++
+
+	_, err := fmt.Fprintf(j.w, `%s"%s"`, separator, exportTime(&at))
+	return err
+}
+
+func (j *jsonResultWriter) end() error {
+	closing := "}\n"
+	if j.timeline {
+		closing = "]}\n"
+	}
+
+	_, err := io.WriteString(j.w, closing)
+	return err
+}
+
+//xlsxResultWriter writes a workbook with a Poll, an Options and, when asked for, a Timeline
+//sheet. The Timeline is the last entry of the zip, so its rows are written as they come.
+type xlsxResultWriter struct {
+	zip      *zip.Writer
+	sheet    io.Writer
+	timeline bool
+	rows     int
+}
+
+const xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ` +
+	`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`%s</Types>`
+
+const xlsxSheetContentType = `<Override PartName="/xl/worksheets/sheet%d.xml" ` +
+	`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`
+
+const xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" ` +
+	`Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" ` +
+	`Target="xl/workbook.xml"/></Relationships>`
+
+const xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+	`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>%s</sheets></workbook>`
+
+const xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">%s</Relationships>`
+
+const xlsxWorkbookRel = `<Relationship Id="rId%d" ` +
+	`Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" ` +
+	`Target="worksheets/sheet%d.xml"/>`
+
+const xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+const xlsxSheetEnd = `</sheetData></worksheet>`
+
+func (x *xlsxResultWriter) begin(poll PollExportView, options []OptionResultView, timeline bool) error {
+	x.timeline = timeline
+
+	sheets := []string{"Poll", "Options"}
+	if timeline {
+		sheets = append(sheets, "Timeline")
+	}
+
+	var types, entries, rels bytes.Buffer
+	for i, name := range sheets {
+		fmt.Fprintf(&types, xlsxSheetContentType, i+1)
+		fmt.Fprintf(&entries, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, name, i+1, i+1)
+		fmt.Fprintf(&rels, xlsxWorkbookRel, i+1, i+1)
+	}
+
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", fmt.Sprintf(xlsxContentTypes, types.String())},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, entries.String())},
+		{"xl/_rels/workbook.xml.rels", fmt.Sprintf(xlsxWorkbookRels, rels.String())},
+	}
+
+	for _, part := range parts {
+		if err := x.writePart(part.name, part.content); err != nil {
+			return err
+		}
+	}
+
+	if err := x.startSheet(1); err != nil {
+		return err
+	}
+	x.row("Id", poll.ID)
+	x.row("Name", poll.Name)
+	x.row("Status", poll.Status)
+	x.row("Voting method", poll.VotingMethod)
+	x.row("Created at", exportTime(&poll.CreatedAt))
+	x.row("Opens at", exportTime(poll.OpensAt))
+	x.row("Closes at", exportTime(poll.ClosesAt))
+	x.row("Voters", poll.Voters)
+	x.row("Selections", poll.Selections)
+	if _, err := io.WriteString(x.sheet, xlsxSheetEnd); err != nil {
+		return err
+	}
+
+	if err := x.startSheet(2); err != nil {
+		return err
+	}
+	x.row("ID", "Position", "Content", "Votes", "Percent")
+	for _, option := range options {
+		x.row(option.ID, option.Position, option.Content, option.Votes, option.Percent)
+	}
+	if _, err := io.WriteString(x.sheet, xlsxSheetEnd); err != nil {
+		return err
+	}
+
+	if !timeline {
+		return nil
+	}
+
+	if err := x.startSheet(3); err != nil {
+		return err
+	}
+
+	return x.row("Voted at")
+}
+
+func (x *xlsxResultWriter) vote(at time.Time) error {
+	return x.row(exportTime(&at))
+}
+
+func (x *xlsxResultWriter) end() error {
+	if x.timeline {
+		if _, err := io.WriteString(x.sheet, xlsxSheetEnd); err != nil {
+			return err
+		}
+	}
+
+	return x.zip.Close()
+}
+
+func (x *xlsxResultWriter) writePart(name, content string) error {
+	part, err := x.zip.Create(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(part, content)
+	return err
+}
+
+func (x *xlsxResultWriter) startSheet(number int) error {
+	sheet, err := x.zip.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", number))
+	if err != nil {
+		return err
+	}
+
+	x.sheet = sheet
+	x.rows = 0
+	_, err = io.WriteString(sheet, xlsxSheetStart)
+	return err
+}
+
+//row writes numbers as numeric cells and everything else as inline strings.
+func (x *xlsxResultWriter) row(values ...interface{}) error {
+	x.rows++
+
+	var row bytes.Buffer
+	fmt.Fprintf(&row, `<row r="%d">`, x.rows)
+	for i, value := range values {
+		ref := fmt.Sprintf("%c%d", 'A'+i, x.rows)
+
+		switch v := value.(type) {
+		case int:
+			fmt.Fprintf(&row, `<c r="%s"><v>%d</v></c>`, ref, v)
+		case int64:
+			fmt.Fprintf(&row, `<c r="%s"><v>%d</v></c>`, ref, v)
+		case float64:
+			fmt.Fprintf(&row, `<c r="%s"><v>%s</v></c>`, ref, exportNumber(v))
+		default:
+			fmt.Fprintf(&row, `<c r="%s" t="inlineStr"><is><t>`, ref)
+			xml.EscapeText(&row, []byte(fmt.Sprint(v)))
+			row.WriteString(`</t></is></c>`)
+		}
+	}
+	row.WriteString(`</row>`)
+
+	_, err := x.sheet.Write(row.Bytes())
+	return err
+}
@@ -0,0 +1,240 @@
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chai2010/assert"
+	"github.com/gorilla/mux"
+
+	"gopkg.in/src-d/go-kallax.v1"
+)
+
+var exportedAt = time.Date(2018, 12, 21, 12, 0, 0, 0, time.UTC)
+
+func newExportOptions() []*PollOption {
+	options := newOptions("Pizza", "Sushi")
+	options[0].Position = 1
+	options[1].Position = 2
+
+	return options
+}
+
+var exportedOptions = newExportOptions()
+
+func newExportServer(owner, viewer kallax.ULID) *httptest.Server {
+	options := exportedOptions
+
+	pollHandlerMock := &PollHandlerMock{
+		FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
+			poll := &Poll{ID: ID, Name: "Lunch", Owner: owner, Status: PollClosed}
+			poll.CreatedAt = exportedAt
+			return poll, nil
+		},
+	}
+	pollVoteHandlerMock := &PollVoteHandlerMock{
+		TallyByPollFunc: func(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+			return map[kallax.ULID]int64{options[0].ID: 3, options[1].ID: 1}, nil
+		},
+		VotersOfFunc: func(pollID kallax.ULID) (int64, error) {
+			return 4, nil
+		},
+		VoteTimelineFunc: func(pollID kallax.ULID, fn func(time.Time) error) error {
+			for i := 0; i < 4; i++ {
+				if err := fn(exportedAt.Add(time.Duration(i) * time.Minute)); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	router := mux.NewRouter()
+	router.HandleFunc("/polls/{id}/export", func(w http.ResponseWriter, r *http.Request) {
+		helper := NewHTTPHelper(w, r)
+		helper.CheckSession = func(ID string) error {
+			helper.Session = &Session{UserID: viewer}
+			return nil
+		}
+
+		ExportPoll(helper, pollHandlerMock, createOptionsMock(options), pollVoteHandlerMock)
+	})
+
+	return httptest.NewServer(router)
+}
+
+func getExport(t *testing.T, server *httptest.Server, query string) (*http.Response, []byte) {
+	request, _ := http.NewRequest("GET", server.URL+"/polls/"+kallax.NewULID().String()+"/export?"+query, nil)
+	request.Header.Set("sessionId", kallax.NewULID().String())
+
+	response, err := http.DefaultClient.Do(request)
+	assert.AssertNil(t, err)
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	assert.AssertNil(t, err)
+	return response, body
+}
+
+func TestExportPollAsJSON(t *testing.T) {
+	owner := kallax.NewULID()
+	server := newExportServer(owner, owner)
+	defer server.Close()
+
+	response, body := getExport(t, server, "format=json&timeline=true")
+
+	assert.AssertEqual(t, http.StatusOK, response.StatusCode)
+	assert.AssertEqual(t, "application/json", response.Header.Get("Content-Type"))
+	assert.AssertTrue(t, strings.HasPrefix(response.Header.Get("Content-Disposition"), `attachment; filename="poll-`))
+
+	var export struct {
+		Poll     PollExportView     `json:"poll"`
+		Options  []OptionResultView `json:"options"`
+		Timeline []time.Time        `json:"timeline"`
+	}
+	assert.AssertNil(t, json.Unmarshal(body, &export))
+	assert.AssertEqual(t, "Lunch", export.Poll.Name)
+	assert.AssertEqual(t, int64(4), export.Poll.Voters)
+	assert.AssertEqual(t, int64(4), export.Poll.Selections)
+	assert.AssertEqual(t, []OptionResultView{
+		{ID: exportedOptions[0].ID.String(), Position: 1, Content: "Pizza", Votes: 3, Percent: 75},
+		{ID: exportedOptions[1].ID.String(), Position: 2, Content: "Sushi", Votes: 1, Percent: 25},
+	}, export.Options)
+	assert.AssertEqual(t, 4, len(export.Timeline))
+	assert.AssertEqual(t, exportedAt.Add(3*time.Minute), export.Timeline[3])
+}
+
+func TestExportPollAsJSONWithoutTimeline(t *testing.T) {
+	owner := kallax.NewULID()
+	server := newExportServer(owner, owner)
+	defer server.Close()
+
+	_, body := getExport(t, server, "")
+
+	var export map[string]interface{}
+	assert.AssertNil(t, json.Unmarshal(body, &export))
+	_, hasTimeline := export["timeline"]
+	assert.AssertFalse(t, hasTimeline)
+}
+
+func TestExportPollAsCSV(t *testing.T) {
+	owner := kallax.NewULID()
+	server := newExportServer(owner, owner)
+	defer server.Close()
+
+	response, body := getExport(t, server, "format=csv&timeline=true")
+
+	assert.AssertEqual(t, "text/csv; charset=utf-8", response.Header.Get("Content-Type"))
+	reader := csv.NewReader(bytes.NewReader(body))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, 10, len(records))
+	assert.AssertEqual(t, "poll", records[1][0])
+	assert.AssertEqual(t, "Lunch", records[1][2])
+	assert.AssertEqual(t, []string{"option", exportedOptions[0].ID.String(), "1", "Pizza", "3", "75"}, records[3])
+	assert.AssertEqual(t, []string{"option", exportedOptions[1].ID.String(), "2", "Sushi", "1", "25"}, records[4])
+	assert.AssertEqual(t, []string{"record", "votedAt"}, records[5])
+	assert.AssertEqual(t, []string{"vote", "2018-12-21T12:00:00Z"}, records[6])
+}
+
+func readZipEntry(t *testing.T, archive *zip.Reader, name string) string {
+	for _, file := range archive.File {
+		if file.Name != name {
+			continue
+		}
+
+		reader, err := file.Open()
+		assert.AssertNil(t, err)
+		defer reader.Close()
+
+		content, err := io.ReadAll(reader)
+		assert.AssertNil(t, err)
+		return string(content)
+	}
+
+	t.Fatalf("Missing %s in the workbook", name)
+	return ""
+}
+
+func TestExportPollAsXLSX(t *testing.T) {
+	owner := kallax.NewULID()
+	server := newExportServer(owner, owner)
+	defer server.Close()
+
+	response, body := getExport(t, server, "format=xlsx&timeline=true")
+
+	assert.AssertEqual(t, http.StatusOK, response.StatusCode)
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	assert.AssertNil(t, err)
+
+	workbook := readZipEntry(t, archive, "xl/workbook.xml")
+	assert.AssertTrue(t, strings.Contains(workbook, `<sheet name="Timeline" sheetId="3" r:id="rId3"/>`))
+	assert.AssertTrue(t, strings.Contains(readZipEntry(t, archive, "[Content_Types].xml"), "/xl/worksheets/sheet3.xml"))
+
+	options := readZipEntry(t, archive, "xl/worksheets/sheet2.xml")
+	assert.AssertTrue(t, strings.Contains(options, `<row r="2"><c r="A2" t="inlineStr"><is><t>`+
+		exportedOptions[0].ID.String()+`</t></is></c><c r="B2"><v>1</v></c>`+
+		`<c r="C2" t="inlineStr"><is><t>Pizza</t></is></c><c r="D2"><v>3</v></c><c r="E2"><v>75</v></c></row>`))
+
+	timeline := readZipEntry(t, archive, "xl/worksheets/sheet3.xml")
+	assert.AssertEqual(t, 5, strings.Count(timeline, "<row "))
+	assert.AssertTrue(t, strings.HasSuffix(timeline, "</sheetData></worksheet>"))
+}
+
+func createExportHelperMock(box *ProcessErrorBox, format string) *HTTPHelperMock {
+	helperMock := createPollChangeHelperMock()
+	helperMock.GetQueryFunc = func(name string) string {
+		if name == "format" {
+			return format
+		}
+		return ""
+	}
+	helperMock.FailFunc = func(err error) {
+		box.ErrorOcurred = err
+	}
+
+	return helperMock
+}
+
+func TestShouldNotExportPollFromOtherUser(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createExportHelperMock(box, ExportCSV)
+	poll := &Poll{Owner: kallax.NewULID(), Status: PollClosed}
+
+	ExportPoll(helperMock, createPollInStatusHandlerMock(poll), &PollOptionHandlerMock{}, &PollVoteHandlerMock{})
+
+	assert.AssertEqual(t, "Can't export a poll from other user.", box.ErrorOcurred.Error())
+	assert.AssertEqual(t, CodeForbidden, AsCodedError(box.ErrorOcurred).Code())
+	assert.AssertEqual(t, 0, len(helperMock.RawCalls()))
+}
+
+func TestShouldNotExportPollInUnknownFormat(t *testing.T) {
+	box := &ProcessErrorBox{}
+	helperMock := createExportHelperMock(box, "pdf")
+	pollHandlerMock := createPollInStatusHandlerMock(&Poll{Owner: loggedUserID()})
+
+	ExportPoll(helperMock, pollHandlerMock, &PollOptionHandlerMock{}, &PollVoteHandlerMock{})
+
+	assert.AssertEqual(t, "Unknown export format pdf. Use csv, json or xlsx.", box.ErrorOcurred.Error())
+	assert.AssertEqual(t, CodeValidation, AsCodedError(box.ErrorOcurred).Code())
+	assert.AssertEqual(t, 0, len(pollHandlerMock.FindPollByIDCalls()))
+}
+
+func TestShouldNotExportPollWithoutSession(t *testing.T) {
+	server := newExportServer(kallax.NewULID(), kallax.NewULID())
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "/polls/" + kallax.NewULID().String() + "/export?sessionId=" + kallax.NewULID().String())
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, http.StatusUnauthorized, response.StatusCode)
+}
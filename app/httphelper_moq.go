@@ -5,17 +5,22 @@ package app
 
 import (
 	"gopkg.in/src-d/go-kallax.v1"
+	"net/http"
 	"sync"
 )
 
 var (
-	lockHTTPHelperMockForbid              sync.RWMutex
-	lockHTTPHelperMockGetRequestSessionID sync.RWMutex
-	lockHTTPHelperMockGetVar              sync.RWMutex
-	lockHTTPHelperMockIsRegisteredUser    sync.RWMutex
-	lockHTTPHelperMockLoggedUserID        sync.RWMutex
-	lockHTTPHelperMockProcess             sync.RWMutex
-	lockHTTPHelperMockValidateSession     sync.RWMutex
+	lockHTTPHelperMockFail                  sync.RWMutex
+	lockHTTPHelperMockForbid                sync.RWMutex
+	lockHTTPHelperMockGetQuery              sync.RWMutex
+	lockHTTPHelperMockGetRequestSessionID   sync.RWMutex
+	lockHTTPHelperMockGetVar                sync.RWMutex
+	lockHTTPHelperMockIsRegisteredUser      sync.RWMutex
+	lockHTTPHelperMockLoggedUserID          sync.RWMutex
+	lockHTTPHelperMockProcess               sync.RWMutex
+	lockHTTPHelperMockRaw                   sync.RWMutex
+	lockHTTPHelperMockValidateSession       sync.RWMutex
+	lockHTTPHelperMockValidateStreamSession sync.RWMutex
 )
 
 // HTTPHelperMock is a mock implementation of HTTPHelper.
@@ -24,9 +29,15 @@ var (
 //
 //         // make and configure a mocked HTTPHelper
 //         mockedHTTPHelper := &HTTPHelperMock{
+//             FailFunc: func(in1 error)  {
+// 	               panic("mock out the Fail method")
+//             },
 //             ForbidFunc: func(in1 error)  {
 // 	               panic("mock out the Forbid method")
 //             },
+//             GetQueryFunc: func(name string) string {
+// 	               panic("mock out the GetQuery method")
+//             },
 //             GetRequestSessionIDFunc: func() (string, error) {
 // 	               panic("mock out the GetRequestSessionID method")
 //             },
@@ -42,9 +53,15 @@ var (
 //             ProcessFunc: func(in1 interface{}, in2 ...ProcessingBlock)  {
 // 	               panic("mock out the Process method")
 //             },
+//             RawFunc: func() (http.ResponseWriter, *http.Request) {
+// 	               panic("mock out the Raw method")
+//             },
 //             ValidateSessionFunc: func() error {
 // 	               panic("mock out the ValidateSession method")
 //             },
+//             ValidateStreamSessionFunc: func() error {
+// 	               panic("mock out the ValidateStreamSession method")
+//             },
 //         }
 //
 //         // use mockedHTTPHelper in code that requires HTTPHelper
@@ -52,9 +69,15 @@ var (
 //
 //     }
 type HTTPHelperMock struct {
+	// FailFunc mocks the Fail method.
+	FailFunc func(in1 error)
+
 	// ForbidFunc mocks the Forbid method.
 	ForbidFunc func(in1 error)
 
+	// GetQueryFunc mocks the GetQuery method.
+	GetQueryFunc func(name string) string
+
 	// GetRequestSessionIDFunc mocks the GetRequestSessionID method.
 	GetRequestSessionIDFunc func() (string, error)
 
@@ -70,16 +93,32 @@ type HTTPHelperMock struct {
 	// ProcessFunc mocks the Process method.
 	ProcessFunc func(in1 interface{}, in2 ...ProcessingBlock)
 
+	// RawFunc mocks the Raw method.
+	RawFunc func() (http.ResponseWriter, *http.Request)
+
 	// ValidateSessionFunc mocks the ValidateSession method.
 	ValidateSessionFunc func() error
 
+	// ValidateStreamSessionFunc mocks the ValidateStreamSession method.
+	ValidateStreamSessionFunc func() error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// Fail holds details about calls to the Fail method.
+		Fail []struct {
+			// In1 is the in1 argument value.
+			In1 error
+		}
 		// Forbid holds details about calls to the Forbid method.
 		Forbid []struct {
 			// In1 is the in1 argument value.
 			In1 error
 		}
+		// GetQuery holds details about calls to the GetQuery method.
+		GetQuery []struct {
+			// Name is the name argument value.
+			Name string
+		}
 		// GetRequestSessionID holds details about calls to the GetRequestSessionID method.
 		GetRequestSessionID []struct {
 		}
@@ -101,12 +140,49 @@ type HTTPHelperMock struct {
 			// In2 is the in2 argument value.
 			In2 []ProcessingBlock
 		}
+		// Raw holds details about calls to the Raw method.
+		Raw []struct {
+		}
 		// ValidateSession holds details about calls to the ValidateSession method.
 		ValidateSession []struct {
 		}
+		// ValidateStreamSession holds details about calls to the ValidateStreamSession method.
+		ValidateStreamSession []struct {
+		}
 	}
 }
 
+// Fail calls FailFunc.
+func (mock *HTTPHelperMock) Fail(in1 error) {
+	if mock.FailFunc == nil {
+		panic("HTTPHelperMock.FailFunc: method is nil but HTTPHelper.Fail was just called")
+	}
+	callInfo := struct {
+		In1 error
+	}{
+		In1: in1,
+	}
+	lockHTTPHelperMockFail.Lock()
+	mock.calls.Fail = append(mock.calls.Fail, callInfo)
+	lockHTTPHelperMockFail.Unlock()
+	mock.FailFunc(in1)
+}
+
+// FailCalls gets all the calls that were made to Fail.
+// Check the length with:
+//     len(mockedHTTPHelper.FailCalls())
+func (mock *HTTPHelperMock) FailCalls() []struct {
+	In1 error
+} {
+	var calls []struct {
+		In1 error
+	}
+	lockHTTPHelperMockFail.RLock()
+	calls = mock.calls.Fail
+	lockHTTPHelperMockFail.RUnlock()
+	return calls
+}
+
 // Forbid calls ForbidFunc.
 func (mock *HTTPHelperMock) Forbid(in1 error) {
 	if mock.ForbidFunc == nil {
@@ -138,6 +214,37 @@ func (mock *HTTPHelperMock) ForbidCalls() []struct {
 	return calls
 }
 
+// GetQuery calls GetQueryFunc.
+func (mock *HTTPHelperMock) GetQuery(name string) string {
+	if mock.GetQueryFunc == nil {
+		panic("HTTPHelperMock.GetQueryFunc: method is nil but HTTPHelper.GetQuery was just called")
+	}
+	callInfo := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	lockHTTPHelperMockGetQuery.Lock()
+	mock.calls.GetQuery = append(mock.calls.GetQuery, callInfo)
+	lockHTTPHelperMockGetQuery.Unlock()
+	return mock.GetQueryFunc(name)
+}
+
+// GetQueryCalls gets all the calls that were made to GetQuery.
+// Check the length with:
+//     len(mockedHTTPHelper.GetQueryCalls())
+func (mock *HTTPHelperMock) GetQueryCalls() []struct {
+	Name string
+} {
+	var calls []struct {
+		Name string
+	}
+	lockHTTPHelperMockGetQuery.RLock()
+	calls = mock.calls.GetQuery
+	lockHTTPHelperMockGetQuery.RUnlock()
+	return calls
+}
+
 // GetRequestSessionID calls GetRequestSessionIDFunc.
 func (mock *HTTPHelperMock) GetRequestSessionID() (string, error) {
 	if mock.GetRequestSessionIDFunc == nil {
@@ -282,6 +389,32 @@ func (mock *HTTPHelperMock) ProcessCalls() []struct {
 	return calls
 }
 
+// Raw calls RawFunc.
+func (mock *HTTPHelperMock) Raw() (http.ResponseWriter, *http.Request) {
+	if mock.RawFunc == nil {
+		panic("HTTPHelperMock.RawFunc: method is nil but HTTPHelper.Raw was just called")
+	}
+	callInfo := struct {
+	}{}
+	lockHTTPHelperMockRaw.Lock()
+	mock.calls.Raw = append(mock.calls.Raw, callInfo)
+	lockHTTPHelperMockRaw.Unlock()
+	return mock.RawFunc()
+}
+
+// RawCalls gets all the calls that were made to Raw.
+// Check the length with:
+//     len(mockedHTTPHelper.RawCalls())
+func (mock *HTTPHelperMock) RawCalls() []struct {
+} {
+	var calls []struct {
+	}
+	lockHTTPHelperMockRaw.RLock()
+	calls = mock.calls.Raw
+	lockHTTPHelperMockRaw.RUnlock()
+	return calls
+}
+
 // ValidateSession calls ValidateSessionFunc.
 func (mock *HTTPHelperMock) ValidateSession() error {
 	if mock.ValidateSessionFunc == nil {
@@ -307,3 +440,29 @@ func (mock *HTTPHelperMock) ValidateSessionCalls() []struct {
 	lockHTTPHelperMockValidateSession.RUnlock()
 	return calls
 }
+
+// ValidateStreamSession calls ValidateStreamSessionFunc.
+func (mock *HTTPHelperMock) ValidateStreamSession() error {
+	if mock.ValidateStreamSessionFunc == nil {
+		panic("HTTPHelperMock.ValidateStreamSessionFunc: method is nil but HTTPHelper.ValidateStreamSession was just called")
+	}
+	callInfo := struct {
+	}{}
+	lockHTTPHelperMockValidateStreamSession.Lock()
+	mock.calls.ValidateStreamSession = append(mock.calls.ValidateStreamSession, callInfo)
+	lockHTTPHelperMockValidateStreamSession.Unlock()
+	return mock.ValidateStreamSessionFunc()
+}
+
+// ValidateStreamSessionCalls gets all the calls that were made to ValidateStreamSession.
+// Check the length with:
+//     len(mockedHTTPHelper.ValidateStreamSessionCalls())
+func (mock *HTTPHelperMock) ValidateStreamSessionCalls() []struct {
+} {
+	var calls []struct {
+	}
+	lockHTTPHelperMockValidateStreamSession.RLock()
+	calls = mock.calls.ValidateStreamSession
+	lockHTTPHelperMockValidateStreamSession.RUnlock()
+	return calls
+}
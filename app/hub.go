@@ -0,0 +1,131 @@
+package app
+
+import (
+	"sync"
+
+	"gopkg.in/src-d/go-kallax.v1"
+)
+
+//TallyPublisher tells the subscribers of a poll that its tally changed. Take a version
+//before reading the tally and publish it along, so tallies read earlier but published
+//later than another are not taken for the newest.
+//go:generate moq -out tallypublisher_moq.go . TallyPublisher
+type TallyPublisher interface {
+	NextVersion() int64
+	Publish(pollID kallax.ULID, version int64, tally interface{})
+}
+
+//TallySubscription receives the tallies published for one poll. Updates is closed when
+//the subscription is cancelled.
+type TallySubscription struct {
+	PollID  kallax.ULID
+	Updates <-chan interface{}
+	updates chan interface{}
+	version int64
+}
+
+//TallyHub fans the tallies of each poll out to its subscribers, in process.
+//Publishing never waits for a subscriber: when one falls behind and its buffer is
+//full, the oldest tally waiting for it is dropped, since the newer one supersedes it.
+//Tallies of a version older than one the subscriber already got are dropped as well.
+type TallyHub struct {
+	lock        sync.Mutex
+	buffer      int
+	version     int64
+	subscribers map[kallax.ULID]map[*TallySubscription]bool
+}
+
+//NewTallyHub creates a hub that holds up to buffer tallies for each subscriber.
+func NewTallyHub(buffer int) *TallyHub {
+	if buffer < 1 {
+		buffer = 1
+	}
+
+	return &TallyHub{
+		buffer:      buffer,
+		subscribers: make(map[kallax.ULID]map[*TallySubscription]bool),
+	}
+}
+
+//NextVersion gives a version newer than every version given before.
+func (h *TallyHub) NextVersion() int64 {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.version++
+	return h.version
+}
+
+//Subscribe starts receiving the tallies of the poll that are read from now on. Tallies
+//read before are left out, as the subscriber reads the current tally after subscribing.
+func (h *TallyHub) Subscribe(pollID kallax.ULID) *TallySubscription {
+	updates := make(chan interface{}, h.buffer)
+	sub := &TallySubscription{PollID: pollID, Updates: updates, updates: updates}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.version++
+	sub.version = h.version
+
+	if h.subscribers[pollID] == nil {
+		h.subscribers[pollID] = make(map[*TallySubscription]bool)
+	}
+	h.subscribers[pollID][sub] = true
+
+	return sub
+}
+
+//Unsubscribe stops the subscription and closes its Updates. Unsubscribing twice is harmless.
+func (h *TallyHub) Unsubscribe(sub *TallySubscription) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	subs := h.subscribers[sub.PollID]
+	if !subs[sub] {
+		return
+	}
+
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(h.subscribers, sub.PollID)
+	}
+	close(sub.updates)
+}
+
+//Subscribers tells how many subscriptions the poll has.
+func (h *TallyHub) Subscribers(pollID kallax.ULID) int {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return len(h.subscribers[pollID])
+}
+
+//Publish hands the tally to every subscriber of the poll that hasn't got a newer one.
+func (h *TallyHub) Publish(pollID kallax.ULID, version int64, tally interface{}) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for sub := range h.subscribers[pollID] {
+		if version <= sub.version {
+			continue
+		}
+
+		sub.version = version
+		for !offer(sub.updates, tally) {
+			select {
+			case <-sub.updates:
+			default:
+			}
+		}
+	}
+}
+
+func offer(updates chan interface{}, tally interface{}) bool {
+	select {
+	case updates <- tally:
+		return true
+	default:
+		return false
+	}
+}
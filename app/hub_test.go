@@ -0,0 +1,77 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/chai2010/assert"
+
+	"gopkg.in/src-d/go-kallax.v1"
+)
+
+func TestTallyHubPublishesToEverySubscriberOfThePoll(t *testing.T) {
+	hub := NewTallyHub(1)
+	pollID := kallax.NewULID()
+	first := hub.Subscribe(pollID)
+	second := hub.Subscribe(pollID)
+	other := hub.Subscribe(kallax.NewULID())
+
+	hub.Publish(pollID, hub.NextVersion(), "tally")
+
+	assert.AssertEqual(t, 2, hub.Subscribers(pollID))
+	assert.AssertEqual(t, "tally", <-first.Updates)
+	assert.AssertEqual(t, "tally", <-second.Updates)
+	assert.AssertEqual(t, 0, len(other.Updates))
+}
+
+func TestTallyHubDropsTheOldestTallyOfSlowSubscribers(t *testing.T) {
+	hub := NewTallyHub(2)
+	pollID := kallax.NewULID()
+	sub := hub.Subscribe(pollID)
+
+	hub.Publish(pollID, hub.NextVersion(), 1)
+	hub.Publish(pollID, hub.NextVersion(), 2)
+	hub.Publish(pollID, hub.NextVersion(), 3)
+
+	assert.AssertEqual(t, 2, <-sub.Updates)
+	assert.AssertEqual(t, 3, <-sub.Updates)
+	assert.AssertEqual(t, 0, len(sub.Updates))
+}
+
+func TestTallyHubUnsubscribe(t *testing.T) {
+	hub := NewTallyHub(1)
+	pollID := kallax.NewULID()
+	sub := hub.Subscribe(pollID)
+
+	hub.Unsubscribe(sub)
+	hub.Unsubscribe(sub)
+	hub.Publish(pollID, hub.NextVersion(), "tally")
+
+	_, open := <-sub.Updates
+	assert.AssertFalse(t, open)
+	assert.AssertEqual(t, 0, hub.Subscribers(pollID))
+}
+
+func TestTallyHubDropsTalliesOlderThanTheLastPublished(t *testing.T) {
+	hub := NewTallyHub(2)
+	pollID := kallax.NewULID()
+	sub := hub.Subscribe(pollID)
+	older := hub.NextVersion()
+	newer := hub.NextVersion()
+
+	hub.Publish(pollID, newer, "newer")
+	hub.Publish(pollID, older, "older")
+
+	assert.AssertEqual(t, "newer", <-sub.Updates)
+	assert.AssertEqual(t, 0, len(sub.Updates))
+}
+
+func TestTallyHubDropsTalliesReadBeforeSubscribing(t *testing.T) {
+	hub := NewTallyHub(1)
+	pollID := kallax.NewULID()
+	read := hub.NextVersion()
+	sub := hub.Subscribe(pollID)
+
+	hub.Publish(pollID, read, "tally")
+
+	assert.AssertEqual(t, 0, len(sub.Updates))
+}
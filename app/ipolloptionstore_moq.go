@@ -8,11 +8,13 @@ import (
 )
 
 var (
-	lockIPollOptionStoreMockCount   sync.RWMutex
-	lockIPollOptionStoreMockDelete  sync.RWMutex
-	lockIPollOptionStoreMockFindAll sync.RWMutex
-	lockIPollOptionStoreMockFindOne sync.RWMutex
-	lockIPollOptionStoreMockSave    sync.RWMutex
+	lockIPollOptionStoreMockCount       sync.RWMutex
+	lockIPollOptionStoreMockDelete      sync.RWMutex
+	lockIPollOptionStoreMockFindAll     sync.RWMutex
+	lockIPollOptionStoreMockFindOne     sync.RWMutex
+	lockIPollOptionStoreMockSave        sync.RWMutex
+	lockIPollOptionStoreMockSaveOutbox  sync.RWMutex
+	lockIPollOptionStoreMockTransaction sync.RWMutex
 )
 
 // IPollOptionStoreMock is a mock implementation of IPollOptionStore.
@@ -36,6 +38,12 @@ var (
 //             SaveFunc: func(record *PollOption) (bool, error) {
 // 	               panic("mock out the Save method")
 //             },
+//             SaveOutboxFunc: func(record *PollOutbox) error {
+// 	               panic("mock out the SaveOutbox method")
+//             },
+//             TransactionFunc: func(callback func(IPollOptionStore) error) error {
+// 	               panic("mock out the Transaction method")
+//             },
 //         }
 //
 //         // use mockedIPollOptionStore in code that requires IPollOptionStore
@@ -58,6 +66,12 @@ type IPollOptionStoreMock struct {
 	// SaveFunc mocks the Save method.
 	SaveFunc func(record *PollOption) (bool, error)
 
+	// SaveOutboxFunc mocks the SaveOutbox method.
+	SaveOutboxFunc func(record *PollOutbox) error
+
+	// TransactionFunc mocks the Transaction method.
+	TransactionFunc func(callback func(IPollOptionStore) error) error
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// Count holds details about calls to the Count method.
@@ -85,6 +99,16 @@ type IPollOptionStoreMock struct {
 			// Record is the record argument value.
 			Record *PollOption
 		}
+		// SaveOutbox holds details about calls to the SaveOutbox method.
+		SaveOutbox []struct {
+			// Record is the record argument value.
+			Record *PollOutbox
+		}
+		// Transaction holds details about calls to the Transaction method.
+		Transaction []struct {
+			// Callback is the callback argument value.
+			Callback func(IPollOptionStore) error
+		}
 	}
 }
 
@@ -242,3 +266,65 @@ func (mock *IPollOptionStoreMock) SaveCalls() []struct {
 	lockIPollOptionStoreMockSave.RUnlock()
 	return calls
 }
+
+// SaveOutbox calls SaveOutboxFunc.
+func (mock *IPollOptionStoreMock) SaveOutbox(record *PollOutbox) error {
+	if mock.SaveOutboxFunc == nil {
+		panic("IPollOptionStoreMock.SaveOutboxFunc: method is nil but IPollOptionStore.SaveOutbox was just called")
+	}
+	callInfo := struct {
+		Record *PollOutbox
+	}{
+		Record: record,
+	}
+	lockIPollOptionStoreMockSaveOutbox.Lock()
+	mock.calls.SaveOutbox = append(mock.calls.SaveOutbox, callInfo)
+	lockIPollOptionStoreMockSaveOutbox.Unlock()
+	return mock.SaveOutboxFunc(record)
+}
+
+// SaveOutboxCalls gets all the calls that were made to SaveOutbox.
+// Check the length with:
+//     len(mockedIPollOptionStore.SaveOutboxCalls())
+func (mock *IPollOptionStoreMock) SaveOutboxCalls() []struct {
+	Record *PollOutbox
+} {
+	var calls []struct {
+		Record *PollOutbox
+	}
+	lockIPollOptionStoreMockSaveOutbox.RLock()
+	calls = mock.calls.SaveOutbox
+	lockIPollOptionStoreMockSaveOutbox.RUnlock()
+	return calls
+}
+
+// Transaction calls TransactionFunc.
+func (mock *IPollOptionStoreMock) Transaction(callback func(IPollOptionStore) error) error {
+	if mock.TransactionFunc == nil {
+		panic("IPollOptionStoreMock.TransactionFunc: method is nil but IPollOptionStore.Transaction was just called")
+	}
+	callInfo := struct {
+		Callback func(IPollOptionStore) error
+	}{
+		Callback: callback,
+	}
+	lockIPollOptionStoreMockTransaction.Lock()
+	mock.calls.Transaction = append(mock.calls.Transaction, callInfo)
+	lockIPollOptionStoreMockTransaction.Unlock()
+	return mock.TransactionFunc(callback)
+}
+
+// TransactionCalls gets all the calls that were made to Transaction.
+// Check the length with:
+//     len(mockedIPollOptionStore.TransactionCalls())
+func (mock *IPollOptionStoreMock) TransactionCalls() []struct {
+	Callback func(IPollOptionStore) error
+} {
+	var calls []struct {
+		Callback func(IPollOptionStore) error
+	}
+	lockIPollOptionStoreMockTransaction.RLock()
+	calls = mock.calls.Transaction
+	lockIPollOptionStoreMockTransaction.RUnlock()
+	return calls
+}
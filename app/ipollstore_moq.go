@@ -8,8 +8,11 @@ import (
 )
 
 var (
-	lockIPollStoreMockFindOne sync.RWMutex
-	lockIPollStoreMockSave    sync.RWMutex
+	lockIPollStoreMockFindAll     sync.RWMutex
+	lockIPollStoreMockFindOne     sync.RWMutex
+	lockIPollStoreMockSave        sync.RWMutex
+	lockIPollStoreMockSaveOutbox  sync.RWMutex
+	lockIPollStoreMockTransaction sync.RWMutex
 )
 
 // IPollStoreMock is a mock implementation of IPollStore.
@@ -18,12 +21,21 @@ var (
 //
 //         // make and configure a mocked IPollStore
 //         mockedIPollStore := &IPollStoreMock{
+//             FindAllFunc: func(q *PollQuery) ([]*Poll, error) {
+// 	               panic("mock out the FindAll method")
+//             },
 //             FindOneFunc: func(q *PollQuery) (*Poll, error) {
 // 	               panic("mock out the FindOne method")
 //             },
 //             SaveFunc: func(record *Poll) (bool, error) {
 // 	               panic("mock out the Save method")
 //             },
+//             SaveOutboxFunc: func(record *PollOutbox) error {
+// 	               panic("mock out the SaveOutbox method")
+//             },
+//             TransactionFunc: func(callback func(IPollStore) error) error {
+// 	               panic("mock out the Transaction method")
+//             },
 //         }
 //
 //         // use mockedIPollStore in code that requires IPollStore
@@ -31,14 +43,28 @@ var (
 //
 //     }
 type IPollStoreMock struct {
+	// FindAllFunc mocks the FindAll method.
+	FindAllFunc func(q *PollQuery) ([]*Poll, error)
+
 	// FindOneFunc mocks the FindOne method.
 	FindOneFunc func(q *PollQuery) (*Poll, error)
 
 	// SaveFunc mocks the Save method.
 	SaveFunc func(record *Poll) (bool, error)
 
+	// SaveOutboxFunc mocks the SaveOutbox method.
+	SaveOutboxFunc func(record *PollOutbox) error
+
+	// TransactionFunc mocks the Transaction method.
+	TransactionFunc func(callback func(IPollStore) error) error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// FindAll holds details about calls to the FindAll method.
+		FindAll []struct {
+			// Q is the q argument value.
+			Q *PollQuery
+		}
 		// FindOne holds details about calls to the FindOne method.
 		FindOne []struct {
 			// Q is the q argument value.
@@ -49,7 +75,48 @@ type IPollStoreMock struct {
 			// Record is the record argument value.
 			Record *Poll
 		}
+		// SaveOutbox holds details about calls to the SaveOutbox method.
+		SaveOutbox []struct {
+			// Record is the record argument value.
+			Record *PollOutbox
+		}
+		// Transaction holds details about calls to the Transaction method.
+		Transaction []struct {
+			// Callback is the callback argument value.
+			Callback func(IPollStore) error
+		}
+	}
+}
+
+// FindAll calls FindAllFunc.
+func (mock *IPollStoreMock) FindAll(q *PollQuery) ([]*Poll, error) {
+	if mock.FindAllFunc == nil {
+		panic("IPollStoreMock.FindAllFunc: method is nil but IPollStore.FindAll was just called")
+	}
+	callInfo := struct {
+		Q *PollQuery
+	}{
+		Q: q,
+	}
+	lockIPollStoreMockFindAll.Lock()
+	mock.calls.FindAll = append(mock.calls.FindAll, callInfo)
+	lockIPollStoreMockFindAll.Unlock()
+	return mock.FindAllFunc(q)
+}
+
+// FindAllCalls gets all the calls that were made to FindAll.
+// Check the length with:
+//     len(mockedIPollStore.FindAllCalls())
+func (mock *IPollStoreMock) FindAllCalls() []struct {
+	Q *PollQuery
+} {
+	var calls []struct {
+		Q *PollQuery
 	}
+	lockIPollStoreMockFindAll.RLock()
+	calls = mock.calls.FindAll
+	lockIPollStoreMockFindAll.RUnlock()
+	return calls
 }
 
 // FindOne calls FindOneFunc.
@@ -113,3 +180,65 @@ func (mock *IPollStoreMock) SaveCalls() []struct {
 	lockIPollStoreMockSave.RUnlock()
 	return calls
 }
+
+// SaveOutbox calls SaveOutboxFunc.
+func (mock *IPollStoreMock) SaveOutbox(record *PollOutbox) error {
+	if mock.SaveOutboxFunc == nil {
+		panic("IPollStoreMock.SaveOutboxFunc: method is nil but IPollStore.SaveOutbox was just called")
+	}
+	callInfo := struct {
+		Record *PollOutbox
+	}{
+		Record: record,
+	}
+	lockIPollStoreMockSaveOutbox.Lock()
+	mock.calls.SaveOutbox = append(mock.calls.SaveOutbox, callInfo)
+	lockIPollStoreMockSaveOutbox.Unlock()
+	return mock.SaveOutboxFunc(record)
+}
+
+// SaveOutboxCalls gets all the calls that were made to SaveOutbox.
+// Check the length with:
+//     len(mockedIPollStore.SaveOutboxCalls())
+func (mock *IPollStoreMock) SaveOutboxCalls() []struct {
+	Record *PollOutbox
+} {
+	var calls []struct {
+		Record *PollOutbox
+	}
+	lockIPollStoreMockSaveOutbox.RLock()
+	calls = mock.calls.SaveOutbox
+	lockIPollStoreMockSaveOutbox.RUnlock()
+	return calls
+}
+
+// Transaction calls TransactionFunc.
+func (mock *IPollStoreMock) Transaction(callback func(IPollStore) error) error {
+	if mock.TransactionFunc == nil {
+		panic("IPollStoreMock.TransactionFunc: method is nil but IPollStore.Transaction was just called")
+	}
+	callInfo := struct {
+		Callback func(IPollStore) error
+	}{
+		Callback: callback,
+	}
+	lockIPollStoreMockTransaction.Lock()
+	mock.calls.Transaction = append(mock.calls.Transaction, callInfo)
+	lockIPollStoreMockTransaction.Unlock()
+	return mock.TransactionFunc(callback)
+}
+
+// TransactionCalls gets all the calls that were made to Transaction.
+// Check the length with:
+//     len(mockedIPollStore.TransactionCalls())
+func (mock *IPollStoreMock) TransactionCalls() []struct {
+	Callback func(IPollStore) error
+} {
+	var calls []struct {
+		Callback func(IPollStore) error
+	}
+	lockIPollStoreMockTransaction.RLock()
+	calls = mock.calls.Transaction
+	lockIPollStoreMockTransaction.RUnlock()
+	return calls
+}
@@ -4,12 +4,29 @@
 package app
 
 import (
+	"gopkg.in/src-d/go-kallax.v1"
 	"sync"
+	"time"
 )
 
 var (
-	lockIPollVoteStoreMockCount sync.RWMutex
-	lockIPollVoteStoreMockSave  sync.RWMutex
+	lockIPollVoteStoreMockAddToTally              sync.RWMutex
+	lockIPollVoteStoreMockCount                   sync.RWMutex
+	lockIPollVoteStoreMockCountSelections         sync.RWMutex
+	lockIPollVoteStoreMockCountSelectionsByOption sync.RWMutex
+	lockIPollVoteStoreMockDelete                  sync.RWMutex
+	lockIPollVoteStoreMockDeleteSelections        sync.RWMutex
+	lockIPollVoteStoreMockEachVoteTime            sync.RWMutex
+	lockIPollVoteStoreMockFindOne                 sync.RWMutex
+	lockIPollVoteStoreMockFindSelections          sync.RWMutex
+	lockIPollVoteStoreMockFindTally               sync.RWMutex
+	lockIPollVoteStoreMockLockTally               sync.RWMutex
+	lockIPollVoteStoreMockReplaceTally            sync.RWMutex
+	lockIPollVoteStoreMockSave                    sync.RWMutex
+	lockIPollVoteStoreMockSaveAudit               sync.RWMutex
+	lockIPollVoteStoreMockSaveOutbox              sync.RWMutex
+	lockIPollVoteStoreMockSaveSelection           sync.RWMutex
+	lockIPollVoteStoreMockTransaction             sync.RWMutex
 )
 
 // IPollVoteStoreMock is a mock implementation of IPollVoteStore.
@@ -18,12 +35,57 @@ var (
 //
 //         // make and configure a mocked IPollVoteStore
 //         mockedIPollVoteStore := &IPollVoteStoreMock{
+//             AddToTallyFunc: func(pollID kallax.ULID, optionID kallax.ULID, delta int64) error {
+// 	               panic("mock out the AddToTally method")
+//             },
 //             CountFunc: func(q *PollVoteQuery) (int64, error) {
 // 	               panic("mock out the Count method")
 //             },
+//             CountSelectionsFunc: func(q *PollVoteSelectionQuery) (int64, error) {
+// 	               panic("mock out the CountSelections method")
+//             },
+//             CountSelectionsByOptionFunc: func(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+// 	               panic("mock out the CountSelectionsByOption method")
+//             },
+//             DeleteFunc: func(record *PollVote) error {
+// 	               panic("mock out the Delete method")
+//             },
+//             DeleteSelectionsFunc: func(voteID kallax.ULID) error {
+// 	               panic("mock out the DeleteSelections method")
+//             },
+//             EachVoteTimeFunc: func(pollID kallax.ULID, fn func(time.Time) error) error {
+// 	               panic("mock out the EachVoteTime method")
+//             },
+//             FindOneFunc: func(q *PollVoteQuery) (*PollVote, error) {
+// 	               panic("mock out the FindOne method")
+//             },
+//             FindSelectionsFunc: func(q *PollVoteSelectionQuery) ([]*PollVoteSelection, error) {
+// 	               panic("mock out the FindSelections method")
+//             },
+//             FindTallyFunc: func(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+// 	               panic("mock out the FindTally method")
+//             },
+//             LockTallyFunc: func() error {
+// 	               panic("mock out the LockTally method")
+//             },
+//             ReplaceTallyFunc: func(pollID kallax.ULID, counts map[kallax.ULID]int64) error {
+// 	               panic("mock out the ReplaceTally method")
+//             },
 //             SaveFunc: func(record *PollVote) (bool, error) {
 // 	               panic("mock out the Save method")
 //             },
+//             SaveAuditFunc: func(record *PollVoteAudit) error {
+// 	               panic("mock out the SaveAudit method")
+//             },
+//             SaveOutboxFunc: func(record *PollOutbox) error {
+// 	               panic("mock out the SaveOutbox method")
+//             },
+//             SaveSelectionFunc: func(record *PollVoteSelection) error {
+// 	               panic("mock out the SaveSelection method")
+//             },
+//             TransactionFunc: func(callback func(IPollVoteStore) error) error {
+// 	               panic("mock out the Transaction method")
+//             },
 //         }
 //
 //         // use mockedIPollVoteStore in code that requires IPollVoteStore
@@ -31,25 +93,190 @@ var (
 //
 //     }
 type IPollVoteStoreMock struct {
+	// AddToTallyFunc mocks the AddToTally method.
+	AddToTallyFunc func(pollID kallax.ULID, optionID kallax.ULID, delta int64) error
+
 	// CountFunc mocks the Count method.
 	CountFunc func(q *PollVoteQuery) (int64, error)
 
+	// CountSelectionsFunc mocks the CountSelections method.
+	CountSelectionsFunc func(q *PollVoteSelectionQuery) (int64, error)
+
+	// CountSelectionsByOptionFunc mocks the CountSelectionsByOption method.
+	CountSelectionsByOptionFunc func(pollID kallax.ULID) (map[kallax.ULID]int64, error)
+
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(record *PollVote) error
+
+	// DeleteSelectionsFunc mocks the DeleteSelections method.
+	DeleteSelectionsFunc func(voteID kallax.ULID) error
+
+	// EachVoteTimeFunc mocks the EachVoteTime method.
+	EachVoteTimeFunc func(pollID kallax.ULID, fn func(time.Time) error) error
+
+	// FindOneFunc mocks the FindOne method.
+	FindOneFunc func(q *PollVoteQuery) (*PollVote, error)
+
+	// FindSelectionsFunc mocks the FindSelections method.
+	FindSelectionsFunc func(q *PollVoteSelectionQuery) ([]*PollVoteSelection, error)
+
+	// FindTallyFunc mocks the FindTally method.
+	FindTallyFunc func(pollID kallax.ULID) (map[kallax.ULID]int64, error)
+
+	// LockTallyFunc mocks the LockTally method.
+	LockTallyFunc func() error
+
+	// ReplaceTallyFunc mocks the ReplaceTally method.
+	ReplaceTallyFunc func(pollID kallax.ULID, counts map[kallax.ULID]int64) error
+
 	// SaveFunc mocks the Save method.
 	SaveFunc func(record *PollVote) (bool, error)
 
+	// SaveAuditFunc mocks the SaveAudit method.
+	SaveAuditFunc func(record *PollVoteAudit) error
+
+	// SaveOutboxFunc mocks the SaveOutbox method.
+	SaveOutboxFunc func(record *PollOutbox) error
+
+	// SaveSelectionFunc mocks the SaveSelection method.
+	SaveSelectionFunc func(record *PollVoteSelection) error
+
+	// TransactionFunc mocks the Transaction method.
+	TransactionFunc func(callback func(IPollVoteStore) error) error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// AddToTally holds details about calls to the AddToTally method.
+		AddToTally []struct {
+			// PollID is the pollID argument value.
+			PollID kallax.ULID
+			// OptionID is the optionID argument value.
+			OptionID kallax.ULID
+			// Delta is the delta argument value.
+			Delta int64
+		}
 		// Count holds details about calls to the Count method.
 		Count []struct {
 			// Q is the q argument value.
 			Q *PollVoteQuery
 		}
+		// CountSelections holds details about calls to the CountSelections method.
+		CountSelections []struct {
+			// Q is the q argument value.
+			Q *PollVoteSelectionQuery
+		}
+		// CountSelectionsByOption holds details about calls to the CountSelectionsByOption method.
+		CountSelectionsByOption []struct {
+			// PollID is the pollID argument value.
+			PollID kallax.ULID
+		}
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Record is the record argument value.
+			Record *PollVote
+		}
+		// DeleteSelections holds details about calls to the DeleteSelections method.
+		DeleteSelections []struct {
+			// VoteID is the voteID argument value.
+			VoteID kallax.ULID
+		}
+		// EachVoteTime holds details about calls to the EachVoteTime method.
+		EachVoteTime []struct {
+			// PollID is the pollID argument value.
+			PollID kallax.ULID
+			// Fn is the fn argument value.
+			Fn func(time.Time) error
+		}
+		// FindOne holds details about calls to the FindOne method.
+		FindOne []struct {
+			// Q is the q argument value.
+			Q *PollVoteQuery
+		}
+		// FindSelections holds details about calls to the FindSelections method.
+		FindSelections []struct {
+			// Q is the q argument value.
+			Q *PollVoteSelectionQuery
+		}
+		// FindTally holds details about calls to the FindTally method.
+		FindTally []struct {
+			// PollID is the pollID argument value.
+			PollID kallax.ULID
+		}
+		// LockTally holds details about calls to the LockTally method.
+		LockTally []struct {
+		}
+		// ReplaceTally holds details about calls to the ReplaceTally method.
+		ReplaceTally []struct {
+			// PollID is the pollID argument value.
+			PollID kallax.ULID
+			// Counts is the counts argument value.
+			Counts map[kallax.ULID]int64
+		}
 		// Save holds details about calls to the Save method.
 		Save []struct {
 			// Record is the record argument value.
 			Record *PollVote
 		}
+		// SaveAudit holds details about calls to the SaveAudit method.
+		SaveAudit []struct {
+			// Record is the record argument value.
+			Record *PollVoteAudit
+		}
+		// SaveOutbox holds details about calls to the SaveOutbox method.
+		SaveOutbox []struct {
+			// Record is the record argument value.
+			Record *PollOutbox
+		}
+		// SaveSelection holds details about calls to the SaveSelection method.
+		SaveSelection []struct {
+			// Record is the record argument value.
+			Record *PollVoteSelection
+		}
+		// Transaction holds details about calls to the Transaction method.
+		Transaction []struct {
+			// Callback is the callback argument value.
+			Callback func(IPollVoteStore) error
+		}
+	}
+}
+
+// AddToTally calls AddToTallyFunc.
+func (mock *IPollVoteStoreMock) AddToTally(pollID kallax.ULID, optionID kallax.ULID, delta int64) error {
+	if mock.AddToTallyFunc == nil {
+		panic("IPollVoteStoreMock.AddToTallyFunc: method is nil but IPollVoteStore.AddToTally was just called")
+	}
+	callInfo := struct {
+		PollID   kallax.ULID
+		OptionID kallax.ULID
+		Delta    int64
+	}{
+		PollID:   pollID,
+		OptionID: optionID,
+		Delta:    delta,
+	}
+	lockIPollVoteStoreMockAddToTally.Lock()
+	mock.calls.AddToTally = append(mock.calls.AddToTally, callInfo)
+	lockIPollVoteStoreMockAddToTally.Unlock()
+	return mock.AddToTallyFunc(pollID, optionID, delta)
+}
+
+// AddToTallyCalls gets all the calls that were made to AddToTally.
+// Check the length with:
+//     len(mockedIPollVoteStore.AddToTallyCalls())
+func (mock *IPollVoteStoreMock) AddToTallyCalls() []struct {
+	PollID   kallax.ULID
+	OptionID kallax.ULID
+	Delta    int64
+} {
+	var calls []struct {
+		PollID   kallax.ULID
+		OptionID kallax.ULID
+		Delta    int64
 	}
+	lockIPollVoteStoreMockAddToTally.RLock()
+	calls = mock.calls.AddToTally
+	lockIPollVoteStoreMockAddToTally.RUnlock()
+	return calls
 }
 
 // Count calls CountFunc.
@@ -83,6 +310,319 @@ func (mock *IPollVoteStoreMock) CountCalls() []struct {
 	return calls
 }
 
+// CountSelections calls CountSelectionsFunc.
+func (mock *IPollVoteStoreMock) CountSelections(q *PollVoteSelectionQuery) (int64, error) {
+	if mock.CountSelectionsFunc == nil {
+		panic("IPollVoteStoreMock.CountSelectionsFunc: method is nil but IPollVoteStore.CountSelections was just called")
+	}
+	callInfo := struct {
+		Q *PollVoteSelectionQuery
+	}{
+		Q: q,
+	}
+	lockIPollVoteStoreMockCountSelections.Lock()
+	mock.calls.CountSelections = append(mock.calls.CountSelections, callInfo)
+	lockIPollVoteStoreMockCountSelections.Unlock()
+	return mock.CountSelectionsFunc(q)
+}
+
+// CountSelectionsCalls gets all the calls that were made to CountSelections.
+// Check the length with:
+//     len(mockedIPollVoteStore.CountSelectionsCalls())
+func (mock *IPollVoteStoreMock) CountSelectionsCalls() []struct {
+	Q *PollVoteSelectionQuery
+} {
+	var calls []struct {
+		Q *PollVoteSelectionQuery
+	}
+	lockIPollVoteStoreMockCountSelections.RLock()
+	calls = mock.calls.CountSelections
+	lockIPollVoteStoreMockCountSelections.RUnlock()
+	return calls
+}
+
+// CountSelectionsByOption calls CountSelectionsByOptionFunc.
+func (mock *IPollVoteStoreMock) CountSelectionsByOption(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+	if mock.CountSelectionsByOptionFunc == nil {
+		panic("IPollVoteStoreMock.CountSelectionsByOptionFunc: method is nil but IPollVoteStore.CountSelectionsByOption was just called")
+	}
+	callInfo := struct {
+		PollID kallax.ULID
+	}{
+		PollID: pollID,
+	}
+	lockIPollVoteStoreMockCountSelectionsByOption.Lock()
+	mock.calls.CountSelectionsByOption = append(mock.calls.CountSelectionsByOption, callInfo)
+	lockIPollVoteStoreMockCountSelectionsByOption.Unlock()
+	return mock.CountSelectionsByOptionFunc(pollID)
+}
+
+// CountSelectionsByOptionCalls gets all the calls that were made to CountSelectionsByOption.
+// Check the length with:
+//     len(mockedIPollVoteStore.CountSelectionsByOptionCalls())
+func (mock *IPollVoteStoreMock) CountSelectionsByOptionCalls() []struct {
+	PollID kallax.ULID
+} {
+	var calls []struct {
+		PollID kallax.ULID
+	}
+	lockIPollVoteStoreMockCountSelectionsByOption.RLock()
+	calls = mock.calls.CountSelectionsByOption
+	lockIPollVoteStoreMockCountSelectionsByOption.RUnlock()
+	return calls
+}
+
+// Delete calls DeleteFunc.
+func (mock *IPollVoteStoreMock) Delete(record *PollVote) error {
+	if mock.DeleteFunc == nil {
+		panic("IPollVoteStoreMock.DeleteFunc: method is nil but IPollVoteStore.Delete was just called")
+	}
+	callInfo := struct {
+		Record *PollVote
+	}{
+		Record: record,
+	}
+	lockIPollVoteStoreMockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	lockIPollVoteStoreMockDelete.Unlock()
+	return mock.DeleteFunc(record)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//     len(mockedIPollVoteStore.DeleteCalls())
+func (mock *IPollVoteStoreMock) DeleteCalls() []struct {
+	Record *PollVote
+} {
+	var calls []struct {
+		Record *PollVote
+	}
+	lockIPollVoteStoreMockDelete.RLock()
+	calls = mock.calls.Delete
+	lockIPollVoteStoreMockDelete.RUnlock()
+	return calls
+}
+
+// DeleteSelections calls DeleteSelectionsFunc.
+func (mock *IPollVoteStoreMock) DeleteSelections(voteID kallax.ULID) error {
+	if mock.DeleteSelectionsFunc == nil {
+		panic("IPollVoteStoreMock.DeleteSelectionsFunc: method is nil but IPollVoteStore.DeleteSelections was just called")
+	}
+	callInfo := struct {
+		VoteID kallax.ULID
+	}{
+		VoteID: voteID,
+	}
+	lockIPollVoteStoreMockDeleteSelections.Lock()
+	mock.calls.DeleteSelections = append(mock.calls.DeleteSelections, callInfo)
+	lockIPollVoteStoreMockDeleteSelections.Unlock()
+	return mock.DeleteSelectionsFunc(voteID)
+}
+
+// DeleteSelectionsCalls gets all the calls that were made to DeleteSelections.
+// Check the length with:
+//     len(mockedIPollVoteStore.DeleteSelectionsCalls())
+func (mock *IPollVoteStoreMock) DeleteSelectionsCalls() []struct {
+	VoteID kallax.ULID
+} {
+	var calls []struct {
+		VoteID kallax.ULID
+	}
+	lockIPollVoteStoreMockDeleteSelections.RLock()
+	calls = mock.calls.DeleteSelections
+	lockIPollVoteStoreMockDeleteSelections.RUnlock()
+	return calls
+}
+
+// EachVoteTime calls EachVoteTimeFunc.
+func (mock *IPollVoteStoreMock) EachVoteTime(pollID kallax.ULID, fn func(time.Time) error) error {
+	if mock.EachVoteTimeFunc == nil {
+		panic("IPollVoteStoreMock.EachVoteTimeFunc: method is nil but IPollVoteStore.EachVoteTime was just called")
+	}
+	callInfo := struct {
+		PollID kallax.ULID
+		Fn     func(time.Time) error
+	}{
+		PollID: pollID,
+		Fn:     fn,
+	}
+	lockIPollVoteStoreMockEachVoteTime.Lock()
+	mock.calls.EachVoteTime = append(mock.calls.EachVoteTime, callInfo)
+	lockIPollVoteStoreMockEachVoteTime.Unlock()
+	return mock.EachVoteTimeFunc(pollID, fn)
+}
+
+// EachVoteTimeCalls gets all the calls that were made to EachVoteTime.
+// Check the length with:
+//     len(mockedIPollVoteStore.EachVoteTimeCalls())
+func (mock *IPollVoteStoreMock) EachVoteTimeCalls() []struct {
+	PollID kallax.ULID
+	Fn     func(time.Time) error
+} {
+	var calls []struct {
+		PollID kallax.ULID
+		Fn     func(time.Time) error
+	}
+	lockIPollVoteStoreMockEachVoteTime.RLock()
+	calls = mock.calls.EachVoteTime
+	lockIPollVoteStoreMockEachVoteTime.RUnlock()
+	return calls
+}
+
+// FindOne calls FindOneFunc.
+func (mock *IPollVoteStoreMock) FindOne(q *PollVoteQuery) (*PollVote, error) {
+	if mock.FindOneFunc == nil {
+		panic("IPollVoteStoreMock.FindOneFunc: method is nil but IPollVoteStore.FindOne was just called")
+	}
+	callInfo := struct {
+		Q *PollVoteQuery
+	}{
+		Q: q,
+	}
+	lockIPollVoteStoreMockFindOne.Lock()
+	mock.calls.FindOne = append(mock.calls.FindOne, callInfo)
+	lockIPollVoteStoreMockFindOne.Unlock()
+	return mock.FindOneFunc(q)
+}
+
+// FindOneCalls gets all the calls that were made to FindOne.
+// Check the length with:
+//     len(mockedIPollVoteStore.FindOneCalls())
+func (mock *IPollVoteStoreMock) FindOneCalls() []struct {
+	Q *PollVoteQuery
+} {
+	var calls []struct {
+		Q *PollVoteQuery
+	}
+	lockIPollVoteStoreMockFindOne.RLock()
+	calls = mock.calls.FindOne
+	lockIPollVoteStoreMockFindOne.RUnlock()
+	return calls
+}
+
+// FindSelections calls FindSelectionsFunc.
+func (mock *IPollVoteStoreMock) FindSelections(q *PollVoteSelectionQuery) ([]*PollVoteSelection, error) {
+	if mock.FindSelectionsFunc == nil {
+		panic("IPollVoteStoreMock.FindSelectionsFunc: method is nil but IPollVoteStore.FindSelections was just called")
+	}
+	callInfo := struct {
+		Q *PollVoteSelectionQuery
+	}{
+		Q: q,
+	}
+	lockIPollVoteStoreMockFindSelections.Lock()
+	mock.calls.FindSelections = append(mock.calls.FindSelections, callInfo)
+	lockIPollVoteStoreMockFindSelections.Unlock()
+	return mock.FindSelectionsFunc(q)
+}
+
+// FindSelectionsCalls gets all the calls that were made to FindSelections.
+// Check the length with:
+//     len(mockedIPollVoteStore.FindSelectionsCalls())
+func (mock *IPollVoteStoreMock) FindSelectionsCalls() []struct {
+	Q *PollVoteSelectionQuery
+} {
+	var calls []struct {
+		Q *PollVoteSelectionQuery
+	}
+	lockIPollVoteStoreMockFindSelections.RLock()
+	calls = mock.calls.FindSelections
+	lockIPollVoteStoreMockFindSelections.RUnlock()
+	return calls
+}
+
+// FindTally calls FindTallyFunc.
+func (mock *IPollVoteStoreMock) FindTally(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+	if mock.FindTallyFunc == nil {
+		panic("IPollVoteStoreMock.FindTallyFunc: method is nil but IPollVoteStore.FindTally was just called")
+	}
+	callInfo := struct {
+		PollID kallax.ULID
+	}{
+		PollID: pollID,
+	}
+	lockIPollVoteStoreMockFindTally.Lock()
+	mock.calls.FindTally = append(mock.calls.FindTally, callInfo)
+	lockIPollVoteStoreMockFindTally.Unlock()
+	return mock.FindTallyFunc(pollID)
+}
+
+// FindTallyCalls gets all the calls that were made to FindTally.
+// Check the length with:
+//     len(mockedIPollVoteStore.FindTallyCalls())
+func (mock *IPollVoteStoreMock) FindTallyCalls() []struct {
+	PollID kallax.ULID
+} {
+	var calls []struct {
+		PollID kallax.ULID
+	}
+	lockIPollVoteStoreMockFindTally.RLock()
+	calls = mock.calls.FindTally
+	lockIPollVoteStoreMockFindTally.RUnlock()
+	return calls
+}
+
+// LockTally calls LockTallyFunc.
+func (mock *IPollVoteStoreMock) LockTally() error {
+	if mock.LockTallyFunc == nil {
+		panic("IPollVoteStoreMock.LockTallyFunc: method is nil but IPollVoteStore.LockTally was just called")
+	}
+	callInfo := struct {
+	}{}
+	lockIPollVoteStoreMockLockTally.Lock()
+	mock.calls.LockTally = append(mock.calls.LockTally, callInfo)
+	lockIPollVoteStoreMockLockTally.Unlock()
+	return mock.LockTallyFunc()
+}
+
+// LockTallyCalls gets all the calls that were made to LockTally.
+// Check the length with:
+//     len(mockedIPollVoteStore.LockTallyCalls())
+func (mock *IPollVoteStoreMock) LockTallyCalls() []struct {
+} {
+	var calls []struct {
+	}
+	lockIPollVoteStoreMockLockTally.RLock()
+	calls = mock.calls.LockTally
+	lockIPollVoteStoreMockLockTally.RUnlock()
+	return calls
+}
+
+// ReplaceTally calls ReplaceTallyFunc.
+func (mock *IPollVoteStoreMock) ReplaceTally(pollID kallax.ULID, counts map[kallax.ULID]int64) error {
+	if mock.ReplaceTallyFunc == nil {
+		panic("IPollVoteStoreMock.ReplaceTallyFunc: method is nil but IPollVoteStore.ReplaceTally was just called")
+	}
+	callInfo := struct {
+		PollID kallax.ULID
+		Counts map[kallax.ULID]int64
+	}{
+		PollID: pollID,
+		Counts: counts,
+	}
+	lockIPollVoteStoreMockReplaceTally.Lock()
+	mock.calls.ReplaceTally = append(mock.calls.ReplaceTally, callInfo)
+	lockIPollVoteStoreMockReplaceTally.Unlock()
+	return mock.ReplaceTallyFunc(pollID, counts)
+}
+
+// ReplaceTallyCalls gets all the calls that were made to ReplaceTally.
+// Check the length with:
+//     len(mockedIPollVoteStore.ReplaceTallyCalls())
+func (mock *IPollVoteStoreMock) ReplaceTallyCalls() []struct {
+	PollID kallax.ULID
+	Counts map[kallax.ULID]int64
+} {
+	var calls []struct {
+		PollID kallax.ULID
+		Counts map[kallax.ULID]int64
+	}
+	lockIPollVoteStoreMockReplaceTally.RLock()
+	calls = mock.calls.ReplaceTally
+	lockIPollVoteStoreMockReplaceTally.RUnlock()
+	return calls
+}
+
 // Save calls SaveFunc.
 func (mock *IPollVoteStoreMock) Save(record *PollVote) (bool, error) {
 	if mock.SaveFunc == nil {
@@ -113,3 +653,127 @@ func (mock *IPollVoteStoreMock) SaveCalls() []struct {
 	lockIPollVoteStoreMockSave.RUnlock()
 	return calls
 }
+
+// SaveAudit calls SaveAuditFunc.
+func (mock *IPollVoteStoreMock) SaveAudit(record *PollVoteAudit) error {
+	if mock.SaveAuditFunc == nil {
+		panic("IPollVoteStoreMock.SaveAuditFunc: method is nil but IPollVoteStore.SaveAudit was just called")
+	}
+	callInfo := struct {
+		Record *PollVoteAudit
+	}{
+		Record: record,
+	}
+	lockIPollVoteStoreMockSaveAudit.Lock()
+	mock.calls.SaveAudit = append(mock.calls.SaveAudit, callInfo)
+	lockIPollVoteStoreMockSaveAudit.Unlock()
+	return mock.SaveAuditFunc(record)
+}
+
+// SaveAuditCalls gets all the calls that were made to SaveAudit.
+// Check the length with:
+//     len(mockedIPollVoteStore.SaveAuditCalls())
+func (mock *IPollVoteStoreMock) SaveAuditCalls() []struct {
+	Record *PollVoteAudit
+} {
+	var calls []struct {
+		Record *PollVoteAudit
+	}
+	lockIPollVoteStoreMockSaveAudit.RLock()
+	calls = mock.calls.SaveAudit
+	lockIPollVoteStoreMockSaveAudit.RUnlock()
+	return calls
+}
+
+// SaveOutbox calls SaveOutboxFunc.
+func (mock *IPollVoteStoreMock) SaveOutbox(record *PollOutbox) error {
+	if mock.SaveOutboxFunc == nil {
+		panic("IPollVoteStoreMock.SaveOutboxFunc: method is nil but IPollVoteStore.SaveOutbox was just called")
+	}
+	callInfo := struct {
+		Record *PollOutbox
+	}{
+		Record: record,
+	}
+	lockIPollVoteStoreMockSaveOutbox.Lock()
+	mock.calls.SaveOutbox = append(mock.calls.SaveOutbox, callInfo)
+	lockIPollVoteStoreMockSaveOutbox.Unlock()
+	return mock.SaveOutboxFunc(record)
+}
+
+// SaveOutboxCalls gets all the calls that were made to SaveOutbox.
+// Check the length with:
+//     len(mockedIPollVoteStore.SaveOutboxCalls())
+func (mock *IPollVoteStoreMock) SaveOutboxCalls() []struct {
+	Record *PollOutbox
+} {
+	var calls []struct {
+		Record *PollOutbox
+	}
+	lockIPollVoteStoreMockSaveOutbox.RLock()
+	calls = mock.calls.SaveOutbox
+	lockIPollVoteStoreMockSaveOutbox.RUnlock()
+	return calls
+}
+
+// SaveSelection calls SaveSelectionFunc.
+func (mock *IPollVoteStoreMock) SaveSelection(record *PollVoteSelection) error {
+	if mock.SaveSelectionFunc == nil {
+		panic("IPollVoteStoreMock.SaveSelectionFunc: method is nil but IPollVoteStore.SaveSelection was just called")
+	}
+	callInfo := struct {
+		Record *PollVoteSelection
+	}{
+		Record: record,
+	}
+	lockIPollVoteStoreMockSaveSelection.Lock()
+	mock.calls.SaveSelection = append(mock.calls.SaveSelection, callInfo)
+	lockIPollVoteStoreMockSaveSelection.Unlock()
+	return mock.SaveSelectionFunc(record)
+}
+
+// SaveSelectionCalls gets all the calls that were made to SaveSelection.
+// Check the length with:
+//     len(mockedIPollVoteStore.SaveSelectionCalls())
+func (mock *IPollVoteStoreMock) SaveSelectionCalls() []struct {
+	Record *PollVoteSelection
+} {
+	var calls []struct {
+		Record *PollVoteSelection
+	}
+	lockIPollVoteStoreMockSaveSelection.RLock()
+	calls = mock.calls.SaveSelection
+	lockIPollVoteStoreMockSaveSelection.RUnlock()
+	return calls
+}
+
+// Transaction calls TransactionFunc.
+func (mock *IPollVoteStoreMock) Transaction(callback func(IPollVoteStore) error) error {
+	if mock.TransactionFunc == nil {
+		panic("IPollVoteStoreMock.TransactionFunc: method is nil but IPollVoteStore.Transaction was just called")
+	}
+	callInfo := struct {
+		Callback func(IPollVoteStore) error
+	}{
+		Callback: callback,
+	}
+	lockIPollVoteStoreMockTransaction.Lock()
+	mock.calls.Transaction = append(mock.calls.Transaction, callInfo)
+	lockIPollVoteStoreMockTransaction.Unlock()
+	return mock.TransactionFunc(callback)
+}
+
+// TransactionCalls gets all the calls that were made to Transaction.
+// Check the length with:
+//     len(mockedIPollVoteStore.TransactionCalls())
+func (mock *IPollVoteStoreMock) TransactionCalls() []struct {
+	Callback func(IPollVoteStore) error
+} {
+	var calls []struct {
+		Callback func(IPollVoteStore) error
+	}
+	lockIPollVoteStoreMockTransaction.RLock()
+	calls = mock.calls.Transaction
+	lockIPollVoteStoreMockTransaction.RUnlock()
+	return calls
+}
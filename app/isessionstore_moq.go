@@ -8,7 +8,9 @@ import (
 )
 
 var (
+	lockISessionStoreMockDelete  sync.RWMutex
 	lockISessionStoreMockFindOne sync.RWMutex
+	lockISessionStoreMockRawExec sync.RWMutex
 	lockISessionStoreMockSave    sync.RWMutex
 )
 
@@ -18,9 +20,15 @@ var (
 //
 //         // make and configure a mocked ISessionStore
 //         mockedISessionStore := &ISessionStoreMock{
+//             DeleteFunc: func(record *Session) error {
+// 	               panic("mock out the Delete method")
+//             },
 //             FindOneFunc: func(q *SessionQuery) (*Session, error) {
 // 	               panic("mock out the FindOne method")
 //             },
+//             RawExecFunc: func(sql string, params ...interface{}) (int64, error) {
+// 	               panic("mock out the RawExec method")
+//             },
 //             SaveFunc: func(record *Session) (bool, error) {
 // 	               panic("mock out the Save method")
 //             },
@@ -31,19 +39,37 @@ var (
 //
 //     }
 type ISessionStoreMock struct {
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(record *Session) error
+
 	// FindOneFunc mocks the FindOne method.
 	FindOneFunc func(q *SessionQuery) (*Session, error)
 
+	// RawExecFunc mocks the RawExec method.
+	RawExecFunc func(sql string, params ...interface{}) (int64, error)
+
 	// SaveFunc mocks the Save method.
 	SaveFunc func(record *Session) (bool, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Record is the record argument value.
+			Record *Session
+		}
 		// FindOne holds details about calls to the FindOne method.
 		FindOne []struct {
 			// Q is the q argument value.
 			Q *SessionQuery
 		}
+		// RawExec holds details about calls to the RawExec method.
+		RawExec []struct {
+			// SQL is the sql argument value.
+			SQL string
+			// Params is the params argument value.
+			Params []interface{}
+		}
 		// Save holds details about calls to the Save method.
 		Save []struct {
 			// Record is the record argument value.
@@ -52,6 +78,37 @@ type ISessionStoreMock struct {
 	}
 }
 
+// Delete calls DeleteFunc.
+func (mock *ISessionStoreMock) Delete(record *Session) error {
+	if mock.DeleteFunc == nil {
+		panic("ISessionStoreMock.DeleteFunc: method is nil but ISessionStore.Delete was just called")
+	}
+	callInfo := struct {
+		Record *Session
+	}{
+		Record: record,
+	}
+	lockISessionStoreMockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	lockISessionStoreMockDelete.Unlock()
+	return mock.DeleteFunc(record)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//     len(mockedISessionStore.DeleteCalls())
+func (mock *ISessionStoreMock) DeleteCalls() []struct {
+	Record *Session
+} {
+	var calls []struct {
+		Record *Session
+	}
+	lockISessionStoreMockDelete.RLock()
+	calls = mock.calls.Delete
+	lockISessionStoreMockDelete.RUnlock()
+	return calls
+}
+
 // FindOne calls FindOneFunc.
 func (mock *ISessionStoreMock) FindOne(q *SessionQuery) (*Session, error) {
 	if mock.FindOneFunc == nil {
@@ -83,6 +140,41 @@ func (mock *ISessionStoreMock) FindOneCalls() []struct {
 	return calls
 }
 
+// RawExec calls RawExecFunc.
+func (mock *ISessionStoreMock) RawExec(sql string, params ...interface{}) (int64, error) {
+	if mock.RawExecFunc == nil {
+		panic("ISessionStoreMock.RawExecFunc: method is nil but ISessionStore.RawExec was just called")
+	}
+	callInfo := struct {
+		SQL    string
+		Params []interface{}
+	}{
+		SQL:    sql,
+		Params: params,
+	}
+	lockISessionStoreMockRawExec.Lock()
+	mock.calls.RawExec = append(mock.calls.RawExec, callInfo)
+	lockISessionStoreMockRawExec.Unlock()
+	return mock.RawExecFunc(sql, params...)
+}
+
+// RawExecCalls gets all the calls that were made to RawExec.
+// Check the length with:
+//     len(mockedISessionStore.RawExecCalls())
+func (mock *ISessionStoreMock) RawExecCalls() []struct {
+	SQL    string
+	Params []interface{}
+} {
+	var calls []struct {
+		SQL    string
+		Params []interface{}
+	}
+	lockISessionStoreMockRawExec.RLock()
+	calls = mock.calls.RawExec
+	lockISessionStoreMockRawExec.RUnlock()
+	return calls
+}
+
 // Save calls SaveFunc.
 func (mock *ISessionStoreMock) Save(record *Session) (bool, error) {
 	if mock.SaveFunc == nil {
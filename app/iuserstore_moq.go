@@ -8,8 +8,10 @@ import (
 )
 
 var (
-	lockIUserStoreMockFindOne sync.RWMutex
-	lockIUserStoreMockSave    sync.RWMutex
+	lockIUserStoreMockFindOne     sync.RWMutex
+	lockIUserStoreMockSave        sync.RWMutex
+	lockIUserStoreMockSaveOutbox  sync.RWMutex
+	lockIUserStoreMockTransaction sync.RWMutex
 )
 
 // IUserStoreMock is a mock implementation of IUserStore.
@@ -24,6 +26,12 @@ var (
 //             SaveFunc: func(record *User) (bool, error) {
 // 	               panic("mock out the Save method")
 //             },
+//             SaveOutboxFunc: func(record *PollOutbox) error {
+// 	               panic("mock out the SaveOutbox method")
+//             },
+//             TransactionFunc: func(callback func(IUserStore) error) error {
+// 	               panic("mock out the Transaction method")
+//             },
 //         }
 //
 //         // use mockedIUserStore in code that requires IUserStore
@@ -37,6 +45,12 @@ type IUserStoreMock struct {
 	// SaveFunc mocks the Save method.
 	SaveFunc func(record *User) (bool, error)
 
+	// SaveOutboxFunc mocks the SaveOutbox method.
+	SaveOutboxFunc func(record *PollOutbox) error
+
+	// TransactionFunc mocks the Transaction method.
+	TransactionFunc func(callback func(IUserStore) error) error
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// FindOne holds details about calls to the FindOne method.
@@ -49,6 +63,16 @@ type IUserStoreMock struct {
 			// Record is the record argument value.
 			Record *User
 		}
+		// SaveOutbox holds details about calls to the SaveOutbox method.
+		SaveOutbox []struct {
+			// Record is the record argument value.
+			Record *PollOutbox
+		}
+		// Transaction holds details about calls to the Transaction method.
+		Transaction []struct {
+			// Callback is the callback argument value.
+			Callback func(IUserStore) error
+		}
 	}
 }
 
@@ -113,3 +137,65 @@ func (mock *IUserStoreMock) SaveCalls() []struct {
 	lockIUserStoreMockSave.RUnlock()
 	return calls
 }
+
+// SaveOutbox calls SaveOutboxFunc.
+func (mock *IUserStoreMock) SaveOutbox(record *PollOutbox) error {
+	if mock.SaveOutboxFunc == nil {
+		panic("IUserStoreMock.SaveOutboxFunc: method is nil but IUserStore.SaveOutbox was just called")
+	}
+	callInfo := struct {
+		Record *PollOutbox
+	}{
+		Record: record,
+	}
+	lockIUserStoreMockSaveOutbox.Lock()
+	mock.calls.SaveOutbox = append(mock.calls.SaveOutbox, callInfo)
+	lockIUserStoreMockSaveOutbox.Unlock()
+	return mock.SaveOutboxFunc(record)
+}
+
+// SaveOutboxCalls gets all the calls that were made to SaveOutbox.
+// Check the length with:
+//     len(mockedIUserStore.SaveOutboxCalls())
+func (mock *IUserStoreMock) SaveOutboxCalls() []struct {
+	Record *PollOutbox
+} {
+	var calls []struct {
+		Record *PollOutbox
+	}
+	lockIUserStoreMockSaveOutbox.RLock()
+	calls = mock.calls.SaveOutbox
+	lockIUserStoreMockSaveOutbox.RUnlock()
+	return calls
+}
+
+// Transaction calls TransactionFunc.
+func (mock *IUserStoreMock) Transaction(callback func(IUserStore) error) error {
+	if mock.TransactionFunc == nil {
+		panic("IUserStoreMock.TransactionFunc: method is nil but IUserStore.Transaction was just called")
+	}
+	callInfo := struct {
+		Callback func(IUserStore) error
+	}{
+		Callback: callback,
+	}
+	lockIUserStoreMockTransaction.Lock()
+	mock.calls.Transaction = append(mock.calls.Transaction, callInfo)
+	lockIUserStoreMockTransaction.Unlock()
+	return mock.TransactionFunc(callback)
+}
+
+// TransactionCalls gets all the calls that were made to Transaction.
+// Check the length with:
+//     len(mockedIUserStore.TransactionCalls())
+func (mock *IUserStoreMock) TransactionCalls() []struct {
+	Callback func(IUserStore) error
+} {
+	var calls []struct {
+		Callback func(IUserStore) error
+	}
+	lockIUserStoreMockTransaction.RLock()
+	calls = mock.calls.Transaction
+	lockIUserStoreMockTransaction.RUnlock()
+	return calls
+}
@@ -41,8 +41,20 @@ func (r *Poll) ColumnAddress(col string) (interface{}, error) {
 		return &r.Name, nil
 	case "owner":
 		return &r.Owner, nil
-	case "published":
-		return &r.Published, nil
+	case "status":
+		return &r.Status, nil
+	case "opens_at":
+		return types.Nullable(&r.OpensAt), nil
+	case "closes_at":
+		return types.Nullable(&r.ClosesAt), nil
+	case "min_choices":
+		return &r.MinChoices, nil
+	case "max_choices":
+		return &r.MaxChoices, nil
+	case "voting_method":
+		return &r.VotingMethod, nil
+	case "max_score":
+		return &r.MaxScore, nil
 
 	default:
 		return nil, fmt.Errorf("kallax: invalid column in Poll: %s", col)
@@ -62,8 +74,26 @@ func (r *Poll) Value(col string) (interface{}, error) {
 		return r.Name, nil
 	case "owner":
 		return r.Owner, nil
-	case "published":
-		return r.Published, nil
+	case "status":
+		return r.Status, nil
+	case "opens_at":
+		if r.OpensAt == nil {
+			return nil, nil
+		}
+		return r.OpensAt, nil
+	case "closes_at":
+		if r.ClosesAt == nil {
+			return nil, nil
+		}
+		return r.ClosesAt, nil
+	case "min_choices":
+		return r.MinChoices, nil
+	case "max_choices":
+		return r.MaxChoices, nil
+	case "voting_method":
+		return r.VotingMethod, nil
+	case "max_score":
+		return r.MaxScore, nil
 
 	default:
 		return nil, fmt.Errorf("kallax: invalid column in Poll: %s", col)
@@ -168,6 +198,12 @@ func (s *PollStore) Insert(record *Poll) error {
 
 	record.CreatedAt = record.CreatedAt.Truncate(time.Microsecond)
 	record.UpdatedAt = record.UpdatedAt.Truncate(time.Microsecond)
+	if record.OpensAt != nil {
+		record.OpensAt = func(t time.Time) *time.Time { return &t }(record.OpensAt.Truncate(time.Microsecond))
+	}
+	if record.ClosesAt != nil {
+		record.ClosesAt = func(t time.Time) *time.Time { return &t }(record.ClosesAt.Truncate(time.Microsecond))
+	}
 
 	if err := record.BeforeSave(); err != nil {
 		return err
@@ -203,6 +239,12 @@ func (s *PollStore) Insert(record *Poll) error {
 func (s *PollStore) Update(record *Poll, cols ...kallax.SchemaField) (updated int64, err error) {
 	record.CreatedAt = record.CreatedAt.Truncate(time.Microsecond)
 	record.UpdatedAt = record.UpdatedAt.Truncate(time.Microsecond)
+	if record.OpensAt != nil {
+		record.OpensAt = func(t time.Time) *time.Time { return &t }(record.OpensAt.Truncate(time.Microsecond))
+	}
+	if record.ClosesAt != nil {
+		record.ClosesAt = func(t time.Time) *time.Time { return &t }(record.ClosesAt.Truncate(time.Microsecond))
+	}
 
 	record.SetSaving(true)
 	defer record.SetSaving(false)
@@ -555,10 +597,46 @@ func (q *PollQuery) FindByOwner(v kallax.ULID) *PollQuery {
 	return q.Where(kallax.Eq(Schema.Poll.Owner, v))
 }
 
-// FindByPublished adds a new filter to the query that will require that
-// the Published property is equal to the passed value.
-func (q *PollQuery) FindByPublished(v bool) *PollQuery {
-	return q.Where(kallax.Eq(Schema.Poll.Published, v))
+// FindByStatus adds a new filter to the query that will require that
+// the Status property is equal to the passed value.
+func (q *PollQuery) FindByStatus(v string) *PollQuery {
+	return q.Where(kallax.Eq(Schema.Poll.Status, v))
+}
+
+// FindByOpensAt adds a new filter to the query that will require that
+// the OpensAt property is equal to the passed value.
+func (q *PollQuery) FindByOpensAt(cond kallax.ScalarCond, v time.Time) *PollQuery {
+	return q.Where(cond(Schema.Poll.OpensAt, v))
+}
+
+// FindByClosesAt adds a new filter to the query that will require that
+// the ClosesAt property is equal to the passed value.
+func (q *PollQuery) FindByClosesAt(cond kallax.ScalarCond, v time.Time) *PollQuery {
+	return q.Where(cond(Schema.Poll.ClosesAt, v))
+}
+
+// FindByMinChoices adds a new filter to the query that will require that
+// the MinChoices property is equal to the passed value.
+func (q *PollQuery) FindByMinChoices(cond kallax.ScalarCond, v int) *PollQuery {
+	return q.Where(cond(Schema.Poll.MinChoices, v))
+}
+
+// FindByMaxChoices adds a new filter to the query that will require that
+// the MaxChoices property is equal to the passed value.
+func (q *PollQuery) FindByMaxChoices(cond kallax.ScalarCond, v int) *PollQuery {
+	return q.Where(cond(Schema.Poll.MaxChoices, v))
+}
+
+// FindByVotingMethod adds a new filter to the query that will require that
+// the VotingMethod property is equal to the passed value.
+func (q *PollQuery) FindByVotingMethod(v string) *PollQuery {
+	return q.Where(kallax.Eq(Schema.Poll.VotingMethod, v))
+}
+
+// FindByMaxScore adds a new filter to the query that will require that
+// the MaxScore property is equal to the passed value.
+func (q *PollQuery) FindByMaxScore(cond kallax.ScalarCond, v int) *PollQuery {
+	return q.Where(cond(Schema.Poll.MaxScore, v))
 }
 
 // PollResultSet is the set of results returned by a query to the
@@ -688,6 +766,8 @@ func (r *PollOption) ColumnAddress(col string) (interface{}, error) {
 		return types.Nullable(kallax.VirtualColumn("poll_id", r, new(kallax.ULID))), nil
 	case "content":
 		return &r.Content, nil
+	case "position":
+		return &r.Position, nil
 
 	default:
 		return nil, fmt.Errorf("kallax: invalid column in PollOption: %s", col)
@@ -707,6 +787,8 @@ func (r *PollOption) Value(col string) (interface{}, error) {
 		return v, nil
 	case "content":
 		return r.Content, nil
+	case "position":
+		return r.Position, nil
 
 	default:
 		return nil, fmt.Errorf("kallax: invalid column in PollOption: %s", col)
@@ -1072,6 +1154,12 @@ func (q *PollOptionQuery) FindByContent(v string) *PollOptionQuery {
 	return q.Where(kallax.Eq(Schema.PollOption.Content, v))
 }
 
+// FindByPosition adds a new filter to the query that will require that
+// the Position property is equal to the passed value.
+func (q *PollOptionQuery) FindByPosition(cond kallax.ScalarCond, v int) *PollOptionQuery {
+	return q.Where(cond(Schema.PollOption.Position, v))
+}
+
 // PollOptionResultSet is the set of results returned by a query to the
 // database.
 type PollOptionResultSet struct {
@@ -1180,18 +1268,18 @@ func (rs *PollOptionResultSet) Close() error {
 	return rs.ResultSet.Close()
 }
 
-// NewPollVote returns a new instance of PollVote.
-func NewPollVote() (record *PollVote) {
-	return new(PollVote)
+// NewPollOutbox returns a new instance of PollOutbox.
+func NewPollOutbox() (record *PollOutbox) {
+	return new(PollOutbox)
 }
 
 // GetID returns the primary key of the model.
-func (r *PollVote) GetID() kallax.Identifier {
+func (r *PollOutbox) GetID() kallax.Identifier {
 	return (*kallax.ULID)(&r.ID)
 }
 
 // ColumnAddress returns the pointer to the value of the given column.
-func (r *PollVote) ColumnAddress(col string) (interface{}, error) {
+func (r *PollOutbox) ColumnAddress(col string) (interface{}, error) {
 	switch col {
 	case "id":
 		return (*kallax.ULID)(&r.ID), nil
@@ -1199,20 +1287,30 @@ func (r *PollVote) ColumnAddress(col string) (interface{}, error) {
 		return &r.Timestamps.CreatedAt, nil
 	case "updated_at":
 		return &r.Timestamps.UpdatedAt, nil
-	case "poll_id":
-		return &r.PollID, nil
-	case "user_id":
-		return &r.UserID, nil
-	case "chosen_option":
-		return &r.ChosenOption, nil
+	case "event":
+		return &r.Event, nil
+	case "payload":
+		return &r.Payload, nil
+	case "published":
+		return &r.Published, nil
+	case "published_at":
+		return types.Nullable(&r.PublishedAt), nil
+	case "attempts":
+		return &r.Attempts, nil
+	case "next_attempt_at":
+		return &r.NextAttemptAt, nil
+	case "last_error":
+		return &r.LastError, nil
+	case "dead":
+		return &r.Dead, nil
 
 	default:
-		return nil, fmt.Errorf("kallax: invalid column in PollVote: %s", col)
+		return nil, fmt.Errorf("kallax: invalid column in PollOutbox: %s", col)
 	}
 }
 
 // Value returns the value of the given column.
-func (r *PollVote) Value(col string) (interface{}, error) {
+func (r *PollOutbox) Value(col string) (interface{}, error) {
 	switch col {
 	case "id":
 		return r.ID, nil
@@ -1220,82 +1318,99 @@ func (r *PollVote) Value(col string) (interface{}, error) {
 		return r.Timestamps.CreatedAt, nil
 	case "updated_at":
 		return r.Timestamps.UpdatedAt, nil
-	case "poll_id":
-		return r.PollID, nil
-	case "user_id":
-		return r.UserID, nil
-	case "chosen_option":
-		return r.ChosenOption, nil
+	case "event":
+		return r.Event, nil
+	case "payload":
+		return r.Payload, nil
+	case "published":
+		return r.Published, nil
+	case "published_at":
+		if r.PublishedAt == nil {
+			return nil, nil
+		}
+		return r.PublishedAt, nil
+	case "attempts":
+		return r.Attempts, nil
+	case "next_attempt_at":
+		return r.NextAttemptAt, nil
+	case "last_error":
+		return r.LastError, nil
+	case "dead":
+		return r.Dead, nil
 
 	default:
-		return nil, fmt.Errorf("kallax: invalid column in PollVote: %s", col)
+		return nil, fmt.Errorf("kallax: invalid column in PollOutbox: %s", col)
 	}
 }
 
 // NewRelationshipRecord returns a new record for the relatiobship in the given
 // field.
-func (r *PollVote) NewRelationshipRecord(field string) (kallax.Record, error) {
-	return nil, fmt.Errorf("kallax: model PollVote has no relationships")
+func (r *PollOutbox) NewRelationshipRecord(field string) (kallax.Record, error) {
+	return nil, fmt.Errorf("kallax: model PollOutbox has no relationships")
 }
 
 // SetRelationship sets the given relationship in the given field.
-func (r *PollVote) SetRelationship(field string, rel interface{}) error {
-	return fmt.Errorf("kallax: model PollVote has no relationships")
+func (r *PollOutbox) SetRelationship(field string, rel interface{}) error {
+	return fmt.Errorf("kallax: model PollOutbox has no relationships")
 }
 
-// PollVoteStore is the entity to access the records of the type PollVote
+// PollOutboxStore is the entity to access the records of the type PollOutbox
 // in the database.
-type PollVoteStore struct {
+type PollOutboxStore struct {
 	*kallax.Store
 }
 
-// NewPollVoteStore creates a new instance of PollVoteStore
+// NewPollOutboxStore creates a new instance of PollOutboxStore
 // using a SQL database.
-func NewPollVoteStore(db *sql.DB) *PollVoteStore {
-	return &PollVoteStore{kallax.NewStore(db)}
+func NewPollOutboxStore(db *sql.DB) *PollOutboxStore {
+	return &PollOutboxStore{kallax.NewStore(db)}
 }
 
 // GenericStore returns the generic store of this store.
-func (s *PollVoteStore) GenericStore() *kallax.Store {
+func (s *PollOutboxStore) GenericStore() *kallax.Store {
 	return s.Store
 }
 
 // SetGenericStore changes the generic store of this store.
-func (s *PollVoteStore) SetGenericStore(store *kallax.Store) {
+func (s *PollOutboxStore) SetGenericStore(store *kallax.Store) {
 	s.Store = store
 }
 
 // Debug returns a new store that will print all SQL statements to stdout using
 // the log.Printf function.
-func (s *PollVoteStore) Debug() *PollVoteStore {
-	return &PollVoteStore{s.Store.Debug()}
+func (s *PollOutboxStore) Debug() *PollOutboxStore {
+	return &PollOutboxStore{s.Store.Debug()}
 }
 
 // DebugWith returns a new store that will print all SQL statements using the
 // given logger function.
-func (s *PollVoteStore) DebugWith(logger kallax.LoggerFunc) *PollVoteStore {
-	return &PollVoteStore{s.Store.DebugWith(logger)}
+func (s *PollOutboxStore) DebugWith(logger kallax.LoggerFunc) *PollOutboxStore {
+	return &PollOutboxStore{s.Store.DebugWith(logger)}
 }
 
 // DisableCacher turns off prepared statements, which can be useful in some scenarios.
-func (s *PollVoteStore) DisableCacher() *PollVoteStore {
-	return &PollVoteStore{s.Store.DisableCacher()}
+func (s *PollOutboxStore) DisableCacher() *PollOutboxStore {
+	return &PollOutboxStore{s.Store.DisableCacher()}
 }
 
-// Insert inserts a PollVote in the database. A non-persisted object is
+// Insert inserts a PollOutbox in the database. A non-persisted object is
 // required for this operation.
-func (s *PollVoteStore) Insert(record *PollVote) error {
+func (s *PollOutboxStore) Insert(record *PollOutbox) error {
 	record.SetSaving(true)
 	defer record.SetSaving(false)
 
 	record.CreatedAt = record.CreatedAt.Truncate(time.Microsecond)
 	record.UpdatedAt = record.UpdatedAt.Truncate(time.Microsecond)
+	if record.PublishedAt != nil {
+		record.PublishedAt = func(t time.Time) *time.Time { return &t }(record.PublishedAt.Truncate(time.Microsecond))
+	}
+	record.NextAttemptAt = record.NextAttemptAt.Truncate(time.Microsecond)
 
 	if err := record.BeforeSave(); err != nil {
 		return err
 	}
 
-	return s.Store.Insert(Schema.PollVote.BaseSchema, record)
+	return s.Store.Insert(Schema.PollOutbox.BaseSchema, record)
 }
 
 // Update updates the given record on the database. If the columns are given,
@@ -1304,9 +1419,13 @@ func (s *PollVoteStore) Insert(record *PollVote) error {
 // in memory but not on the database.
 // Only writable records can be updated. Writable objects are those that have
 // been just inserted or retrieved using a query with no custom select fields.
-func (s *PollVoteStore) Update(record *PollVote, cols ...kallax.SchemaField) (updated int64, err error) {
+func (s *PollOutboxStore) Update(record *PollOutbox, cols ...kallax.SchemaField) (updated int64, err error) {
 	record.CreatedAt = record.CreatedAt.Truncate(time.Microsecond)
 	record.UpdatedAt = record.UpdatedAt.Truncate(time.Microsecond)
+	if record.PublishedAt != nil {
+		record.PublishedAt = func(t time.Time) *time.Time { return &t }(record.PublishedAt.Truncate(time.Microsecond))
+	}
+	record.NextAttemptAt = record.NextAttemptAt.Truncate(time.Microsecond)
 
 	record.SetSaving(true)
 	defer record.SetSaving(false)
@@ -1315,12 +1434,12 @@ func (s *PollVoteStore) Update(record *PollVote, cols ...kallax.SchemaField) (up
 		return 0, err
 	}
 
-	return s.Store.Update(Schema.PollVote.BaseSchema, record, cols...)
+	return s.Store.Update(Schema.PollOutbox.BaseSchema, record, cols...)
 }
 
 // Save inserts the object if the record is not persisted, otherwise it updates
 // it. Same rules of Update and Insert apply depending on the case.
-func (s *PollVoteStore) Save(record *PollVote) (updated bool, err error) {
+func (s *PollOutboxStore) Save(record *PollOutbox) (updated bool, err error) {
 	if !record.IsPersisted() {
 		return false, s.Insert(record)
 	}
@@ -1334,41 +1453,41 @@ func (s *PollVoteStore) Save(record *PollVote) (updated bool, err error) {
 }
 
 // Delete removes the given record from the database.
-func (s *PollVoteStore) Delete(record *PollVote) error {
-	return s.Store.Delete(Schema.PollVote.BaseSchema, record)
+func (s *PollOutboxStore) Delete(record *PollOutbox) error {
+	return s.Store.Delete(Schema.PollOutbox.BaseSchema, record)
 }
 
 // Find returns the set of results for the given query.
-func (s *PollVoteStore) Find(q *PollVoteQuery) (*PollVoteResultSet, error) {
+func (s *PollOutboxStore) Find(q *PollOutboxQuery) (*PollOutboxResultSet, error) {
 	rs, err := s.Store.Find(q)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewPollVoteResultSet(rs), nil
+	return NewPollOutboxResultSet(rs), nil
 }
 
 // MustFind returns the set of results for the given query, but panics if there
 // is any error.
-func (s *PollVoteStore) MustFind(q *PollVoteQuery) *PollVoteResultSet {
-	return NewPollVoteResultSet(s.Store.MustFind(q))
+func (s *PollOutboxStore) MustFind(q *PollOutboxQuery) *PollOutboxResultSet {
+	return NewPollOutboxResultSet(s.Store.MustFind(q))
 }
 
 // Count returns the number of rows that would be retrieved with the given
 // query.
-func (s *PollVoteStore) Count(q *PollVoteQuery) (int64, error) {
+func (s *PollOutboxStore) Count(q *PollOutboxQuery) (int64, error) {
 	return s.Store.Count(q)
 }
 
 // MustCount returns the number of rows that would be retrieved with the given
 // query, but panics if there is an error.
-func (s *PollVoteStore) MustCount(q *PollVoteQuery) int64 {
+func (s *PollOutboxStore) MustCount(q *PollOutboxQuery) int64 {
 	return s.Store.MustCount(q)
 }
 
 // FindOne returns the first row returned by the given query.
 // `ErrNotFound` is returned if there are no results.
-func (s *PollVoteStore) FindOne(q *PollVoteQuery) (*PollVote, error) {
+func (s *PollOutboxStore) FindOne(q *PollOutboxQuery) (*PollOutbox, error) {
 	q.Limit(1)
 	q.Offset(0)
 	rs, err := s.Find(q)
@@ -1393,7 +1512,7 @@ func (s *PollVoteStore) FindOne(q *PollVoteQuery) (*PollVote, error) {
 }
 
 // FindAll returns a list of all the rows returned by the given query.
-func (s *PollVoteStore) FindAll(q *PollVoteQuery) ([]*PollVote, error) {
+func (s *PollOutboxStore) FindAll(q *PollOutboxQuery) ([]*PollOutbox, error) {
 	rs, err := s.Find(q)
 	if err != nil {
 		return nil, err
@@ -1404,7 +1523,7 @@ func (s *PollVoteStore) FindAll(q *PollVoteQuery) ([]*PollVote, error) {
 
 // MustFindOne returns the first row retrieved by the given query. It panics
 // if there is an error or if there are no rows.
-func (s *PollVoteStore) MustFindOne(q *PollVoteQuery) *PollVote {
+func (s *PollOutboxStore) MustFindOne(q *PollOutboxQuery) *PollOutbox {
 	record, err := s.FindOne(q)
 	if err != nil {
 		panic(err)
@@ -1412,41 +1531,41 @@ func (s *PollVoteStore) MustFindOne(q *PollVoteQuery) *PollVote {
 	return record
 }
 
-// Reload refreshes the PollVote with the data in the database and
+// Reload refreshes the PollOutbox with the data in the database and
 // makes it writable.
-func (s *PollVoteStore) Reload(record *PollVote) error {
-	return s.Store.Reload(Schema.PollVote.BaseSchema, record)
+func (s *PollOutboxStore) Reload(record *PollOutbox) error {
+	return s.Store.Reload(Schema.PollOutbox.BaseSchema, record)
 }
 
 // Transaction executes the given callback in a transaction and rollbacks if
 // an error is returned.
 // The transaction is only open in the store passed as a parameter to the
 // callback.
-func (s *PollVoteStore) Transaction(callback func(*PollVoteStore) error) error {
+func (s *PollOutboxStore) Transaction(callback func(*PollOutboxStore) error) error {
 	if callback == nil {
 		return kallax.ErrInvalidTxCallback
 	}
 
 	return s.Store.Transaction(func(store *kallax.Store) error {
-		return callback(&PollVoteStore{store})
+		return callback(&PollOutboxStore{store})
 	})
 }
 
-// PollVoteQuery is the object used to create queries for the PollVote
+// PollOutboxQuery is the object used to create queries for the PollOutbox
 // entity.
-type PollVoteQuery struct {
+type PollOutboxQuery struct {
 	*kallax.BaseQuery
 }
 
-// NewPollVoteQuery returns a new instance of PollVoteQuery.
-func NewPollVoteQuery() *PollVoteQuery {
-	return &PollVoteQuery{
-		BaseQuery: kallax.NewBaseQuery(Schema.PollVote.BaseSchema),
+// NewPollOutboxQuery returns a new instance of PollOutboxQuery.
+func NewPollOutboxQuery() *PollOutboxQuery {
+	return &PollOutboxQuery{
+		BaseQuery: kallax.NewBaseQuery(Schema.PollOutbox.BaseSchema),
 	}
 }
 
 // Select adds columns to select in the query.
-func (q *PollVoteQuery) Select(columns ...kallax.SchemaField) *PollVoteQuery {
+func (q *PollOutboxQuery) Select(columns ...kallax.SchemaField) *PollOutboxQuery {
 	if len(columns) == 0 {
 		return q
 	}
@@ -1455,47 +1574,47 @@ func (q *PollVoteQuery) Select(columns ...kallax.SchemaField) *PollVoteQuery {
 }
 
 // SelectNot excludes columns from being selected in the query.
-func (q *PollVoteQuery) SelectNot(columns ...kallax.SchemaField) *PollVoteQuery {
+func (q *PollOutboxQuery) SelectNot(columns ...kallax.SchemaField) *PollOutboxQuery {
 	q.BaseQuery.SelectNot(columns...)
 	return q
 }
 
 // Copy returns a new identical copy of the query. Remember queries are mutable
 // so make a copy any time you need to reuse them.
-func (q *PollVoteQuery) Copy() *PollVoteQuery {
-	return &PollVoteQuery{
+func (q *PollOutboxQuery) Copy() *PollOutboxQuery {
+	return &PollOutboxQuery{
 		BaseQuery: q.BaseQuery.Copy(),
 	}
 }
 
 // Order adds order clauses to the query for the given columns.
-func (q *PollVoteQuery) Order(cols ...kallax.ColumnOrder) *PollVoteQuery {
+func (q *PollOutboxQuery) Order(cols ...kallax.ColumnOrder) *PollOutboxQuery {
 	q.BaseQuery.Order(cols...)
 	return q
 }
 
 // BatchSize sets the number of items to fetch per batch when there are 1:N
 // relationships selected in the query.
-func (q *PollVoteQuery) BatchSize(size uint64) *PollVoteQuery {
+func (q *PollOutboxQuery) BatchSize(size uint64) *PollOutboxQuery {
 	q.BaseQuery.BatchSize(size)
 	return q
 }
 
 // Limit sets the max number of items to retrieve.
-func (q *PollVoteQuery) Limit(n uint64) *PollVoteQuery {
+func (q *PollOutboxQuery) Limit(n uint64) *PollOutboxQuery {
 	q.BaseQuery.Limit(n)
 	return q
 }
 
 // Offset sets the number of items to skip from the result set of items.
-func (q *PollVoteQuery) Offset(n uint64) *PollVoteQuery {
+func (q *PollOutboxQuery) Offset(n uint64) *PollOutboxQuery {
 	q.BaseQuery.Offset(n)
 	return q
 }
 
 // Where adds a condition to the query. All conditions added are concatenated
 // using a logical AND.
-func (q *PollVoteQuery) Where(cond kallax.Condition) *PollVoteQuery {
+func (q *PollOutboxQuery) Where(cond kallax.Condition) *PollOutboxQuery {
 	q.BaseQuery.Where(cond)
 	return q
 }
@@ -1503,7 +1622,7 @@ func (q *PollVoteQuery) Where(cond kallax.Condition) *PollVoteQuery {
 // FindByID adds a new filter to the query that will require that
 // the ID property is equal to one of the passed values; if no passed values,
 // it will do nothing.
-func (q *PollVoteQuery) FindByID(v ...kallax.ULID) *PollVoteQuery {
+func (q *PollOutboxQuery) FindByID(v ...kallax.ULID) *PollOutboxQuery {
 	if len(v) == 0 {
 		return q
 	}
@@ -1511,57 +1630,87 @@ func (q *PollVoteQuery) FindByID(v ...kallax.ULID) *PollVoteQuery {
 	for i, val := range v {
 		values[i] = val
 	}
-	return q.Where(kallax.In(Schema.PollVote.ID, values...))
+	return q.Where(kallax.In(Schema.PollOutbox.ID, values...))
 }
 
 // FindByCreatedAt adds a new filter to the query that will require that
 // the CreatedAt property is equal to the passed value.
-func (q *PollVoteQuery) FindByCreatedAt(cond kallax.ScalarCond, v time.Time) *PollVoteQuery {
-	return q.Where(cond(Schema.PollVote.CreatedAt, v))
+func (q *PollOutboxQuery) FindByCreatedAt(cond kallax.ScalarCond, v time.Time) *PollOutboxQuery {
+	return q.Where(cond(Schema.PollOutbox.CreatedAt, v))
 }
 
 // FindByUpdatedAt adds a new filter to the query that will require that
 // the UpdatedAt property is equal to the passed value.
-func (q *PollVoteQuery) FindByUpdatedAt(cond kallax.ScalarCond, v time.Time) *PollVoteQuery {
-	return q.Where(cond(Schema.PollVote.UpdatedAt, v))
+func (q *PollOutboxQuery) FindByUpdatedAt(cond kallax.ScalarCond, v time.Time) *PollOutboxQuery {
+	return q.Where(cond(Schema.PollOutbox.UpdatedAt, v))
 }
 
-// FindByPollID adds a new filter to the query that will require that
-// the PollID property is equal to the passed value.
-func (q *PollVoteQuery) FindByPollID(v kallax.ULID) *PollVoteQuery {
-	return q.Where(kallax.Eq(Schema.PollVote.PollID, v))
+// FindByEvent adds a new filter to the query that will require that
+// the Event property is equal to the passed value.
+func (q *PollOutboxQuery) FindByEvent(v string) *PollOutboxQuery {
+	return q.Where(kallax.Eq(Schema.PollOutbox.Event, v))
 }
 
-// FindByUserID adds a new filter to the query that will require that
-// the UserID property is equal to the passed value.
-func (q *PollVoteQuery) FindByUserID(v kallax.ULID) *PollVoteQuery {
-	return q.Where(kallax.Eq(Schema.PollVote.UserID, v))
+// FindByPayload adds a new filter to the query that will require that
+// the Payload property is equal to the passed value.
+func (q *PollOutboxQuery) FindByPayload(v string) *PollOutboxQuery {
+	return q.Where(kallax.Eq(Schema.PollOutbox.Payload, v))
 }
 
-// FindByChosenOption adds a new filter to the query that will require that
-// the ChosenOption property is equal to the passed value.
-func (q *PollVoteQuery) FindByChosenOption(v string) *PollVoteQuery {
-	return q.Where(kallax.Eq(Schema.PollVote.ChosenOption, v))
+// FindByPublished adds a new filter to the query that will require that
+// the Published property is equal to the passed value.
+func (q *PollOutboxQuery) FindByPublished(v bool) *PollOutboxQuery {
+	return q.Where(kallax.Eq(Schema.PollOutbox.Published, v))
 }
 
-// PollVoteResultSet is the set of results returned by a query to the
+// FindByPublishedAt adds a new filter to the query that will require that
+// the PublishedAt property is equal to the passed value.
+func (q *PollOutboxQuery) FindByPublishedAt(cond kallax.ScalarCond, v time.Time) *PollOutboxQuery {
+	return q.Where(cond(Schema.PollOutbox.PublishedAt, v))
+}
+
+// FindByAttempts adds a new filter to the query that will require that
+// the Attempts property is equal to the passed value.
+func (q *PollOutboxQuery) FindByAttempts(cond kallax.ScalarCond, v int) *PollOutboxQuery {
+	return q.Where(cond(Schema.PollOutbox.Attempts, v))
+}
+
+// FindByNextAttemptAt adds a new filter to the query that will require that
+// the NextAttemptAt property is equal to the passed value.
+func (q *PollOutboxQuery) FindByNextAttemptAt(cond kallax.ScalarCond, v time.Time) *PollOutboxQuery {
+	return q.Where(cond(Schema.PollOutbox.NextAttemptAt, v))
+}
+
+// FindByLastError adds a new filter to the query that will require that
+// the LastError property is equal to the passed value.
+func (q *PollOutboxQuery) FindByLastError(v string) *PollOutboxQuery {
+	return q.Where(kallax.Eq(Schema.PollOutbox.LastError, v))
+}
+
+// FindByDead adds a new filter to the query that will require that
+// the Dead property is equal to the passed value.
+func (q *PollOutboxQuery) FindByDead(v bool) *PollOutboxQuery {
+	return q.Where(kallax.Eq(Schema.PollOutbox.Dead, v))
+}
+
+// PollOutboxResultSet is the set of results returned by a query to the
 // database.
-type PollVoteResultSet struct {
+type PollOutboxResultSet struct {
 	ResultSet kallax.ResultSet
-	last      *PollVote
+	last      *PollOutbox
 	lastErr   error
 }
 
-// NewPollVoteResultSet creates a new result set for rows of the type
-// PollVote.
-func NewPollVoteResultSet(rs kallax.ResultSet) *PollVoteResultSet {
-	return &PollVoteResultSet{ResultSet: rs}
+// NewPollOutboxResultSet creates a new result set for rows of the type
+// PollOutbox.
+func NewPollOutboxResultSet(rs kallax.ResultSet) *PollOutboxResultSet {
+	return &PollOutboxResultSet{ResultSet: rs}
 }
 
 // Next fetches the next item in the result set and returns true if there is
 // a next item.
 // The result set is closed automatically when there are no more items.
-func (rs *PollVoteResultSet) Next() bool {
+func (rs *PollOutboxResultSet) Next() bool {
 	if !rs.ResultSet.Next() {
 		rs.lastErr = rs.ResultSet.Close()
 		rs.last = nil
@@ -1569,14 +1718,14 @@ func (rs *PollVoteResultSet) Next() bool {
 	}
 
 	var record kallax.Record
-	record, rs.lastErr = rs.ResultSet.Get(Schema.PollVote.BaseSchema)
+	record, rs.lastErr = rs.ResultSet.Get(Schema.PollOutbox.BaseSchema)
 	if rs.lastErr != nil {
 		rs.last = nil
 	} else {
 		var ok bool
-		rs.last, ok = record.(*PollVote)
+		rs.last, ok = record.(*PollOutbox)
 		if !ok {
-			rs.lastErr = fmt.Errorf("kallax: unable to convert record to *PollVote")
+			rs.lastErr = fmt.Errorf("kallax: unable to convert record to *PollOutbox")
 			rs.last = nil
 		}
 	}
@@ -1585,7 +1734,7 @@ func (rs *PollVoteResultSet) Next() bool {
 }
 
 // Get retrieves the last fetched item from the result set and the last error.
-func (rs *PollVoteResultSet) Get() (*PollVote, error) {
+func (rs *PollOutboxResultSet) Get() (*PollOutbox, error) {
 	return rs.last, rs.lastErr
 }
 
@@ -1593,7 +1742,7 @@ func (rs *PollVoteResultSet) Get() (*PollVote, error) {
 // the given callback. It is possible to stop the iteration by returning
 // `kallax.ErrStop` in the callback.
 // Result set is always closed at the end.
-func (rs *PollVoteResultSet) ForEach(fn func(*PollVote) error) error {
+func (rs *PollOutboxResultSet) ForEach(fn func(*PollOutbox) error) error {
 	for rs.Next() {
 		record, err := rs.Get()
 		if err != nil {
@@ -1612,8 +1761,8 @@ func (rs *PollVoteResultSet) ForEach(fn func(*PollVote) error) error {
 }
 
 // All returns all records on the result set and closes the result set.
-func (rs *PollVoteResultSet) All() ([]*PollVote, error) {
-	var result []*PollVote
+func (rs *PollOutboxResultSet) All() ([]*PollOutbox, error) {
+	var result []*PollOutbox
 	for rs.Next() {
 		record, err := rs.Get()
 		if err != nil {
@@ -1625,7 +1774,7 @@ func (rs *PollVoteResultSet) All() ([]*PollVote, error) {
 }
 
 // One returns the first record on the result set and closes the result set.
-func (rs *PollVoteResultSet) One() (*PollVote, error) {
+func (rs *PollOutboxResultSet) One() (*PollOutbox, error) {
 	if !rs.Next() {
 		return nil, kallax.ErrNotFound
 	}
@@ -1643,27 +1792,27 @@ func (rs *PollVoteResultSet) One() (*PollVote, error) {
 }
 
 // Err returns the last error occurred.
-func (rs *PollVoteResultSet) Err() error {
+func (rs *PollOutboxResultSet) Err() error {
 	return rs.lastErr
 }
 
 // Close closes the result set.
-func (rs *PollVoteResultSet) Close() error {
+func (rs *PollOutboxResultSet) Close() error {
 	return rs.ResultSet.Close()
 }
 
-// NewSession returns a new instance of Session.
-func NewSession() (record *Session) {
-	return new(Session)
+// NewPollVote returns a new instance of PollVote.
+func NewPollVote() (record *PollVote) {
+	return new(PollVote)
 }
 
 // GetID returns the primary key of the model.
-func (r *Session) GetID() kallax.Identifier {
+func (r *PollVote) GetID() kallax.Identifier {
 	return (*kallax.ULID)(&r.ID)
 }
 
 // ColumnAddress returns the pointer to the value of the given column.
-func (r *Session) ColumnAddress(col string) (interface{}, error) {
+func (r *PollVote) ColumnAddress(col string) (interface{}, error) {
 	switch col {
 	case "id":
 		return (*kallax.ULID)(&r.ID), nil
@@ -1671,18 +1820,22 @@ func (r *Session) ColumnAddress(col string) (interface{}, error) {
 		return &r.Timestamps.CreatedAt, nil
 	case "updated_at":
 		return &r.Timestamps.UpdatedAt, nil
+	case "poll_id":
+		return &r.PollID, nil
 	case "user_id":
 		return &r.UserID, nil
-	case "registered_user":
-		return &r.RegisteredUser, nil
+	case "chosen_option":
+		return &r.ChosenOption, nil
+	case "poll_option_id":
+		return types.Nullable(&r.OptionID), nil
 
 	default:
-		return nil, fmt.Errorf("kallax: invalid column in Session: %s", col)
+		return nil, fmt.Errorf("kallax: invalid column in PollVote: %s", col)
 	}
 }
 
 // Value returns the value of the given column.
-func (r *Session) Value(col string) (interface{}, error) {
+func (r *PollVote) Value(col string) (interface{}, error) {
 	switch col {
 	case "id":
 		return r.ID, nil
@@ -1690,69 +1843,76 @@ func (r *Session) Value(col string) (interface{}, error) {
 		return r.Timestamps.CreatedAt, nil
 	case "updated_at":
 		return r.Timestamps.UpdatedAt, nil
+	case "poll_id":
+		return r.PollID, nil
 	case "user_id":
 		return r.UserID, nil
-	case "registered_user":
-		return r.RegisteredUser, nil
+	case "chosen_option":
+		return r.ChosenOption, nil
+	case "poll_option_id":
+		if r.OptionID == nil {
+			return nil, nil
+		}
+		return r.OptionID, nil
 
 	default:
-		return nil, fmt.Errorf("kallax: invalid column in Session: %s", col)
+		return nil, fmt.Errorf("kallax: invalid column in PollVote: %s", col)
 	}
 }
 
 // NewRelationshipRecord returns a new record for the relatiobship in the given
 // field.
-func (r *Session) NewRelationshipRecord(field string) (kallax.Record, error) {
-	return nil, fmt.Errorf("kallax: model Session has no relationships")
+func (r *PollVote) NewRelationshipRecord(field string) (kallax.Record, error) {
+	return nil, fmt.Errorf("kallax: model PollVote has no relationships")
 }
 
 // SetRelationship sets the given relationship in the given field.
-func (r *Session) SetRelationship(field string, rel interface{}) error {
-	return fmt.Errorf("kallax: model Session has no relationships")
+func (r *PollVote) SetRelationship(field string, rel interface{}) error {
+	return fmt.Errorf("kallax: model PollVote has no relationships")
 }
 
-// SessionStore is the entity to access the records of the type Session
+// PollVoteStore is the entity to access the records of the type PollVote
 // in the database.
-type SessionStore struct {
+type PollVoteStore struct {
 	*kallax.Store
 }
 
-// NewSessionStore creates a new instance of SessionStore
+// NewPollVoteStore creates a new instance of PollVoteStore
 // using a SQL database.
-func NewSessionStore(db *sql.DB) *SessionStore {
-	return &SessionStore{kallax.NewStore(db)}
+func NewPollVoteStore(db *sql.DB) *PollVoteStore {
+	return &PollVoteStore{kallax.NewStore(db)}
 }
 
 // GenericStore returns the generic store of this store.
-func (s *SessionStore) GenericStore() *kallax.Store {
+func (s *PollVoteStore) GenericStore() *kallax.Store {
 	return s.Store
 }
 
 // SetGenericStore changes the generic store of this store.
-func (s *SessionStore) SetGenericStore(store *kallax.Store) {
+func (s *PollVoteStore) SetGenericStore(store *kallax.Store) {
 	s.Store = store
 }
 
 // Debug returns a new store that will print all SQL statements to stdout using
 // the log.Printf function.
-func (s *SessionStore) Debug() *SessionStore {
-	return &SessionStore{s.Store.Debug()}
+func (s *PollVoteStore) Debug() *PollVoteStore {
+	return &PollVoteStore{s.Store.Debug()}
 }
 
 // DebugWith returns a new store that will print all SQL statements using the
 // given logger function.
-func (s *SessionStore) DebugWith(logger kallax.LoggerFunc) *SessionStore {
-	return &SessionStore{s.Store.DebugWith(logger)}
+func (s *PollVoteStore) DebugWith(logger kallax.LoggerFunc) *PollVoteStore {
+	return &PollVoteStore{s.Store.DebugWith(logger)}
 }
 
 // DisableCacher turns off prepared statements, which can be useful in some scenarios.
-func (s *SessionStore) DisableCacher() *SessionStore {
-	return &SessionStore{s.Store.DisableCacher()}
+func (s *PollVoteStore) DisableCacher() *PollVoteStore {
+	return &PollVoteStore{s.Store.DisableCacher()}
 }
 
-// Insert inserts a Session in the database. A non-persisted object is
+// Insert inserts a PollVote in the database. A non-persisted object is
 // required for this operation.
-func (s *SessionStore) Insert(record *Session) error {
+func (s *PollVoteStore) Insert(record *PollVote) error {
 	record.SetSaving(true)
 	defer record.SetSaving(false)
 
@@ -1763,7 +1923,7 @@ func (s *SessionStore) Insert(record *Session) error {
 		return err
 	}
 
-	return s.Store.Insert(Schema.Session.BaseSchema, record)
+	return s.Store.Insert(Schema.PollVote.BaseSchema, record)
 }
 
 // Update updates the given record on the database. If the columns are given,
@@ -1772,7 +1932,7 @@ func (s *SessionStore) Insert(record *Session) error {
 // in memory but not on the database.
 // Only writable records can be updated. Writable objects are those that have
 // been just inserted or retrieved using a query with no custom select fields.
-func (s *SessionStore) Update(record *Session, cols ...kallax.SchemaField) (updated int64, err error) {
+func (s *PollVoteStore) Update(record *PollVote, cols ...kallax.SchemaField) (updated int64, err error) {
 	record.CreatedAt = record.CreatedAt.Truncate(time.Microsecond)
 	record.UpdatedAt = record.UpdatedAt.Truncate(time.Microsecond)
 
@@ -1783,12 +1943,12 @@ func (s *SessionStore) Update(record *Session, cols ...kallax.SchemaField) (upda
 		return 0, err
 	}
 
-	return s.Store.Update(Schema.Session.BaseSchema, record, cols...)
+	return s.Store.Update(Schema.PollVote.BaseSchema, record, cols...)
 }
 
 // Save inserts the object if the record is not persisted, otherwise it updates
 // it. Same rules of Update and Insert apply depending on the case.
-func (s *SessionStore) Save(record *Session) (updated bool, err error) {
+func (s *PollVoteStore) Save(record *PollVote) (updated bool, err error) {
 	if !record.IsPersisted() {
 		return false, s.Insert(record)
 	}
@@ -1802,41 +1962,41 @@ func (s *SessionStore) Save(record *Session) (updated bool, err error) {
 }
 
 // Delete removes the given record from the database.
-func (s *SessionStore) Delete(record *Session) error {
-	return s.Store.Delete(Schema.Session.BaseSchema, record)
+func (s *PollVoteStore) Delete(record *PollVote) error {
+	return s.Store.Delete(Schema.PollVote.BaseSchema, record)
 }
 
 // Find returns the set of results for the given query.
-func (s *SessionStore) Find(q *SessionQuery) (*SessionResultSet, error) {
+func (s *PollVoteStore) Find(q *PollVoteQuery) (*PollVoteResultSet, error) {
 	rs, err := s.Store.Find(q)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewSessionResultSet(rs), nil
+	return NewPollVoteResultSet(rs), nil
 }
 
 // MustFind returns the set of results for the given query, but panics if there
 // is any error.
-func (s *SessionStore) MustFind(q *SessionQuery) *SessionResultSet {
-	return NewSessionResultSet(s.Store.MustFind(q))
+func (s *PollVoteStore) MustFind(q *PollVoteQuery) *PollVoteResultSet {
+	return NewPollVoteResultSet(s.Store.MustFind(q))
 }
 
 // Count returns the number of rows that would be retrieved with the given
 // query.
-func (s *SessionStore) Count(q *SessionQuery) (int64, error) {
+func (s *PollVoteStore) Count(q *PollVoteQuery) (int64, error) {
 	return s.Store.Count(q)
 }
 
 // MustCount returns the number of rows that would be retrieved with the given
 // query, but panics if there is an error.
-func (s *SessionStore) MustCount(q *SessionQuery) int64 {
+func (s *PollVoteStore) MustCount(q *PollVoteQuery) int64 {
 	return s.Store.MustCount(q)
 }
 
 // FindOne returns the first row returned by the given query.
 // `ErrNotFound` is returned if there are no results.
-func (s *SessionStore) FindOne(q *SessionQuery) (*Session, error) {
+func (s *PollVoteStore) FindOne(q *PollVoteQuery) (*PollVote, error) {
 	q.Limit(1)
 	q.Offset(0)
 	rs, err := s.Find(q)
@@ -1861,7 +2021,7 @@ func (s *SessionStore) FindOne(q *SessionQuery) (*Session, error) {
 }
 
 // FindAll returns a list of all the rows returned by the given query.
-func (s *SessionStore) FindAll(q *SessionQuery) ([]*Session, error) {
+func (s *PollVoteStore) FindAll(q *PollVoteQuery) ([]*PollVote, error) {
 	rs, err := s.Find(q)
 	if err != nil {
 		return nil, err
@@ -1872,7 +2032,7 @@ func (s *SessionStore) FindAll(q *SessionQuery) ([]*Session, error) {
 
 // MustFindOne returns the first row retrieved by the given query. It panics
 // if there is an error or if there are no rows.
-func (s *SessionStore) MustFindOne(q *SessionQuery) *Session {
+func (s *PollVoteStore) MustFindOne(q *PollVoteQuery) *PollVote {
 	record, err := s.FindOne(q)
 	if err != nil {
 		panic(err)
@@ -1880,10 +2040,2510 @@ func (s *SessionStore) MustFindOne(q *SessionQuery) *Session {
 	return record
 }
 
-// Reload refreshes the Session with the data in the database and
+// Reload refreshes the PollVote with the data in the database and
 // makes it writable.
-func (s *SessionStore) Reload(record *Session) error {
-	return s.Store.Reload(Schema.Session.BaseSchema, record)
+func (s *PollVoteStore) Reload(record *PollVote) error {
+	return s.Store.Reload(Schema.PollVote.BaseSchema, record)
+}
+
+// Transaction executes the given callback in a transaction and rollbacks if
+// an error is returned.
+// The transaction is only open in the store passed as a parameter to the
+// callback.
+func (s *PollVoteStore) Transaction(callback func(*PollVoteStore) error) error {
+	if callback == nil {
+		return kallax.ErrInvalidTxCallback
+	}
+
+	return s.Store.Transaction(func(store *kallax.Store) error {
+		return callback(&PollVoteStore{store})
+	})
+}
+
+// PollVoteQuery is the object used to create queries for the PollVote
+// entity.
+type PollVoteQuery struct {
+	*kallax.BaseQuery
+}
+
+// NewPollVoteQuery returns a new instance of PollVoteQuery.
+func NewPollVoteQuery() *PollVoteQuery {
+	return &PollVoteQuery{
+		BaseQuery: kallax.NewBaseQuery(Schema.PollVote.BaseSchema),
+	}
+}
+
+// Select adds columns to select in the query.
+func (q *PollVoteQuery) Select(columns ...kallax.SchemaField) *PollVoteQuery {
+	if len(columns) == 0 {
+		return q
+	}
+	q.BaseQuery.Select(columns...)
+	return q
+}
+
+// SelectNot excludes columns from being selected in the query.
+func (q *PollVoteQuery) SelectNot(columns ...kallax.SchemaField) *PollVoteQuery {
+	q.BaseQuery.SelectNot(columns...)
+	return q
+}
+
+// Copy returns a new identical copy of the query. Remember queries are mutable
+// so make a copy any time you need to reuse them.
+func (q *PollVoteQuery) Copy() *PollVoteQuery {
+	return &PollVoteQuery{
+		BaseQuery: q.BaseQuery.Copy(),
+	}
+}
+
+// Order adds order clauses to the query for the given columns.
+func (q *PollVoteQuery) Order(cols ...kallax.ColumnOrder) *PollVoteQuery {
+	q.BaseQuery.Order(cols...)
+	return q
+}
+
+// BatchSize sets the number of items to fetch per batch when there are 1:N
+// relationships selected in the query.
+func (q *PollVoteQuery) BatchSize(size uint64) *PollVoteQuery {
+	q.BaseQuery.BatchSize(size)
+	return q
+}
+
+// Limit sets the max number of items to retrieve.
+func (q *PollVoteQuery) Limit(n uint64) *PollVoteQuery {
+	q.BaseQuery.Limit(n)
+	return q
+}
+
+// Offset sets the number of items to skip from the result set of items.
+func (q *PollVoteQuery) Offset(n uint64) *PollVoteQuery {
+	q.BaseQuery.Offset(n)
+	return q
+}
+
+// Where adds a condition to the query. All conditions added are concatenated
+// using a logical AND.
+func (q *PollVoteQuery) Where(cond kallax.Condition) *PollVoteQuery {
+	q.BaseQuery.Where(cond)
+	return q
+}
+
+// FindByID adds a new filter to the query that will require that
+// the ID property is equal to one of the passed values; if no passed values,
+// it will do nothing.
+func (q *PollVoteQuery) FindByID(v ...kallax.ULID) *PollVoteQuery {
+	if len(v) == 0 {
+		return q
+	}
+	values := make([]interface{}, len(v))
+	for i, val := range v {
+		values[i] = val
+	}
+	return q.Where(kallax.In(Schema.PollVote.ID, values...))
+}
+
+// FindByCreatedAt adds a new filter to the query that will require that
+// the CreatedAt property is equal to the passed value.
+func (q *PollVoteQuery) FindByCreatedAt(cond kallax.ScalarCond, v time.Time) *PollVoteQuery {
+	return q.Where(cond(Schema.PollVote.CreatedAt, v))
+}
+
+// FindByUpdatedAt adds a new filter to the query that will require that
+// the UpdatedAt property is equal to the passed value.
+func (q *PollVoteQuery) FindByUpdatedAt(cond kallax.ScalarCond, v time.Time) *PollVoteQuery {
+	return q.Where(cond(Schema.PollVote.UpdatedAt, v))
+}
+
+// FindByPollID adds a new filter to the query that will require that
+// the PollID property is equal to the passed value.
+func (q *PollVoteQuery) FindByPollID(v kallax.ULID) *PollVoteQuery {
+	return q.Where(kallax.Eq(Schema.PollVote.PollID, v))
+}
+
+// FindByUserID adds a new filter to the query that will require that
+// the UserID property is equal to the passed value.
+func (q *PollVoteQuery) FindByUserID(v kallax.ULID) *PollVoteQuery {
+	return q.Where(kallax.Eq(Schema.PollVote.UserID, v))
+}
+
+// FindByChosenOption adds a new filter to the query that will require that
+// the ChosenOption property is equal to the passed value.
+func (q *PollVoteQuery) FindByChosenOption(v string) *PollVoteQuery {
+	return q.Where(kallax.Eq(Schema.PollVote.ChosenOption, v))
+}
+
+// FindByOptionID adds a new filter to the query that will require that
+// the OptionID property is equal to the passed value.
+func (q *PollVoteQuery) FindByOptionID(v kallax.ULID) *PollVoteQuery {
+	return q.Where(kallax.Eq(Schema.PollVote.OptionID, v))
+}
+
+// PollVoteResultSet is the set of results returned by a query to the
+// database.
+type PollVoteResultSet struct {
+	ResultSet kallax.ResultSet
+	last      *PollVote
+	lastErr   error
+}
+
+// NewPollVoteResultSet creates a new result set for rows of the type
+// PollVote.
+func NewPollVoteResultSet(rs kallax.ResultSet) *PollVoteResultSet {
+	return &PollVoteResultSet{ResultSet: rs}
+}
+
+// Next fetches the next item in the result set and returns true if there is
+// a next item.
+// The result set is closed automatically when there are no more items.
+func (rs *PollVoteResultSet) Next() bool {
+	if !rs.ResultSet.Next() {
+		rs.lastErr = rs.ResultSet.Close()
+		rs.last = nil
+		return false
+	}
+
+	var record kallax.Record
+	record, rs.lastErr = rs.ResultSet.Get(Schema.PollVote.BaseSchema)
+	if rs.lastErr != nil {
+		rs.last = nil
+	} else {
+		var ok bool
+		rs.last, ok = record.(*PollVote)
+		if !ok {
+			rs.lastErr = fmt.Errorf("kallax: unable to convert record to *PollVote")
+			rs.last = nil
+		}
+	}
+
+	return true
+}
+
+// Get retrieves the last fetched item from the result set and the last error.
+func (rs *PollVoteResultSet) Get() (*PollVote, error) {
+	return rs.last, rs.lastErr
+}
+
+// ForEach iterates over the complete result set passing every record found to
+// the given callback. It is possible to stop the iteration by returning
+// `kallax.ErrStop` in the callback.
+// Result set is always closed at the end.
+func (rs *PollVoteResultSet) ForEach(fn func(*PollVote) error) error {
+	for rs.Next() {
+		record, err := rs.Get()
+		if err != nil {
+			return err
+		}
+
+		if err := fn(record); err != nil {
+			if err == kallax.ErrStop {
+				return rs.Close()
+			}
+
+			return err
+		}
+	}
+	return nil
+}
+
+// All returns all records on the result set and closes the result set.
+func (rs *PollVoteResultSet) All() ([]*PollVote, error) {
+	var result []*PollVote
+	for rs.Next() {
+		record, err := rs.Get()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, record)
+	}
+	return result, nil
+}
+
+// One returns the first record on the result set and closes the result set.
+func (rs *PollVoteResultSet) One() (*PollVote, error) {
+	if !rs.Next() {
+		return nil, kallax.ErrNotFound
+	}
+
+	record, err := rs.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rs.Close(); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// Err returns the last error occurred.
+func (rs *PollVoteResultSet) Err() error {
+	return rs.lastErr
+}
+
+// Close closes the result set.
+func (rs *PollVoteResultSet) Close() error {
+	return rs.ResultSet.Close()
+}
+
+// NewPollVoteAudit returns a new instance of PollVoteAudit.
+func NewPollVoteAudit() (record *PollVoteAudit) {
+	return new(PollVoteAudit)
+}
+
+// GetID returns the primary key of the model.
+func (r *PollVoteAudit) GetID() kallax.Identifier {
+	return (*kallax.ULID)(&r.ID)
+}
+
+// ColumnAddress returns the pointer to the value of the given column.
+func (r *PollVoteAudit) ColumnAddress(col string) (interface{}, error) {
+	switch col {
+	case "id":
+		return (*kallax.ULID)(&r.ID), nil
+	case "created_at":
+		return &r.Timestamps.CreatedAt, nil
+	case "updated_at":
+		return &r.Timestamps.UpdatedAt, nil
+	case "vote_id":
+		return &r.VoteID, nil
+	case "poll_id":
+		return &r.PollID, nil
+	case "user_id":
+		return &r.UserID, nil
+	case "previous_option":
+		return &r.PreviousOption, nil
+	case "action":
+		return &r.Action, nil
+
+	default:
+		return nil, fmt.Errorf("kallax: invalid column in PollVoteAudit: %s", col)
+	}
+}
+
+// Value returns the value of the given column.
+func (r *PollVoteAudit) Value(col string) (interface{}, error) {
+	switch col {
+	case "id":
+		return r.ID, nil
+	case "created_at":
+		return r.Timestamps.CreatedAt, nil
+	case "updated_at":
+		return r.Timestamps.UpdatedAt, nil
+	case "vote_id":
+		return r.VoteID, nil
+	case "poll_id":
+		return r.PollID, nil
+	case "user_id":
+		return r.UserID, nil
+	case "previous_option":
+		return r.PreviousOption, nil
+	case "action":
+		return r.Action, nil
+
+	default:
+		return nil, fmt.Errorf("kallax: invalid column in PollVoteAudit: %s", col)
+	}
+}
+
+// NewRelationshipRecord returns a new record for the relatiobship in the given
+// field.
+func (r *PollVoteAudit) NewRelationshipRecord(field string) (kallax.Record, error) {
+	return nil, fmt.Errorf("kallax: model PollVoteAudit has no relationships")
+}
+
+// SetRelationship sets the given relationship in the given field.
+func (r *PollVoteAudit) SetRelationship(field string, rel interface{}) error {
+	return fmt.Errorf("kallax: model PollVoteAudit has no relationships")
+}
+
+// PollVoteAuditStore is the entity to access the records of the type PollVoteAudit
+// in the database.
+type PollVoteAuditStore struct {
+	*kallax.Store
+}
+
+// NewPollVoteAuditStore creates a new instance of PollVoteAuditStore
+// using a SQL database.
+func NewPollVoteAuditStore(db *sql.DB) *PollVoteAuditStore {
+	return &PollVoteAuditStore{kallax.NewStore(db)}
+}
+
+// GenericStore returns the generic store of this store.
+func (s *PollVoteAuditStore) GenericStore() *kallax.Store {
+	return s.Store
+}
+
+// SetGenericStore changes the generic store of this store.
+func (s *PollVoteAuditStore) SetGenericStore(store *kallax.Store) {
+	s.Store = store
+}
+
+// Debug returns a new store that will print all SQL statements to stdout using
+// the log.Printf function.
+func (s *PollVoteAuditStore) Debug() *PollVoteAuditStore {
+	return &PollVoteAuditStore{s.Store.Debug()}
+}
+
+// DebugWith returns a new store that will print all SQL statements using the
+// given logger function.
+func (s *PollVoteAuditStore) DebugWith(logger kallax.LoggerFunc) *PollVoteAuditStore {
+	return &PollVoteAuditStore{s.Store.DebugWith(logger)}
+}
+
+// DisableCacher turns off prepared statements, which can be useful in some scenarios.
+func (s *PollVoteAuditStore) DisableCacher() *PollVoteAuditStore {
+	return &PollVoteAuditStore{s.Store.DisableCacher()}
+}
+
+// Insert inserts a PollVoteAudit in the database. A non-persisted object is
+// required for this operation.
+func (s *PollVoteAuditStore) Insert(record *PollVoteAudit) error {
+	record.SetSaving(true)
+	defer record.SetSaving(false)
+
+	record.CreatedAt = record.CreatedAt.Truncate(time.Microsecond)
+	record.UpdatedAt = record.UpdatedAt.Truncate(time.Microsecond)
+
+	if err := record.BeforeSave(); err != nil {
+		return err
+	}
+
+	return s.Store.Insert(Schema.PollVoteAudit.BaseSchema, record)
+}
+
+// Update updates the given record on the database. If the columns are given,
+// only these columns will be updated. Otherwise all of them will be.
+// Be very careful with this, as you will have a potentially different object
+// in memory but not on the database.
+// Only writable records can be updated. Writable objects are those that have
+// been just inserted or retrieved using a query with no custom select fields.
+func (s *PollVoteAuditStore) Update(record *PollVoteAudit, cols ...kallax.SchemaField) (updated int64, err error) {
+	record.CreatedAt = record.CreatedAt.Truncate(time.Microsecond)
+	record.UpdatedAt = record.UpdatedAt.Truncate(time.Microsecond)
+
+	record.SetSaving(true)
+	defer record.SetSaving(false)
+
+	if err := record.BeforeSave(); err != nil {
+		return 0, err
+	}
+
+	return s.Store.Update(Schema.PollVoteAudit.BaseSchema, record, cols...)
+}
+
+// Save inserts the object if the record is not persisted, otherwise it updates
+// it. Same rules of Update and Insert apply depending on the case.
+func (s *PollVoteAuditStore) Save(record *PollVoteAudit) (updated bool, err error) {
+	if !record.IsPersisted() {
+		return false, s.Insert(record)
+	}
+
+	rowsUpdated, err := s.Update(record)
+	if err != nil {
+		return false, err
+	}
+
+	return rowsUpdated > 0, nil
+}
+
+// Delete removes the given record from the database.
+func (s *PollVoteAuditStore) Delete(record *PollVoteAudit) error {
+	return s.Store.Delete(Schema.PollVoteAudit.BaseSchema, record)
+}
+
+// Find returns the set of results for the given query.
+func (s *PollVoteAuditStore) Find(q *PollVoteAuditQuery) (*PollVoteAuditResultSet, error) {
+	rs, err := s.Store.Find(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPollVoteAuditResultSet(rs), nil
+}
+
+// MustFind returns the set of results for the given query, but panics if there
+// is any error.
+func (s *PollVoteAuditStore) MustFind(q *PollVoteAuditQuery) *PollVoteAuditResultSet {
+	return NewPollVoteAuditResultSet(s.Store.MustFind(q))
+}
+
+// Count returns the number of rows that would be retrieved with the given
+// query.
+func (s *PollVoteAuditStore) Count(q *PollVoteAuditQuery) (int64, error) {
+	return s.Store.Count(q)
+}
+
+// MustCount returns the number of rows that would be retrieved with the given
+// query, but panics if there is an error.
+func (s *PollVoteAuditStore) MustCount(q *PollVoteAuditQuery) int64 {
+	return s.Store.MustCount(q)
+}
+
+// FindOne returns the first row returned by the given query.
+// `ErrNotFound` is returned if there are no results.
+func (s *PollVoteAuditStore) FindOne(q *PollVoteAuditQuery) (*PollVoteAudit, error) {
+	q.Limit(1)
+	q.Offset(0)
+	rs, err := s.Find(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if !rs.Next() {
+		return nil, kallax.ErrNotFound
+	}
+
+	record, err := rs.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rs.Close(); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// FindAll returns a list of all the rows returned by the given query.
+func (s *PollVoteAuditStore) FindAll(q *PollVoteAuditQuery) ([]*PollVoteAudit, error) {
+	rs, err := s.Find(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return rs.All()
+}
+
+// MustFindOne returns the first row retrieved by the given query. It panics
+// if there is an error or if there are no rows.
+func (s *PollVoteAuditStore) MustFindOne(q *PollVoteAuditQuery) *PollVoteAudit {
+	record, err := s.FindOne(q)
+	if err != nil {
+		panic(err)
+	}
+	return record
+}
+
+// Reload refreshes the PollVoteAudit with the data in the database and
+// makes it writable.
+func (s *PollVoteAuditStore) Reload(record *PollVoteAudit) error {
+	return s.Store.Reload(Schema.PollVoteAudit.BaseSchema, record)
+}
+
+// Transaction executes the given callback in a transaction and rollbacks if
+// an error is returned.
+// The transaction is only open in the store passed as a parameter to the
+// callback.
+func (s *PollVoteAuditStore) Transaction(callback func(*PollVoteAuditStore) error) error {
+	if callback == nil {
+		return kallax.ErrInvalidTxCallback
+	}
+
+	return s.Store.Transaction(func(store *kallax.Store) error {
+		return callback(&PollVoteAuditStore{store})
+	})
+}
+
+// PollVoteAuditQuery is the object used to create queries for the PollVoteAudit
+// entity.
+type PollVoteAuditQuery struct {
+	*kallax.BaseQuery
+}
+
+// NewPollVoteAuditQuery returns a new instance of PollVoteAuditQuery.
+func NewPollVoteAuditQuery() *PollVoteAuditQuery {
+	return &PollVoteAuditQuery{
+		BaseQuery: kallax.NewBaseQuery(Schema.PollVoteAudit.BaseSchema),
+	}
+}
+
+// Select adds columns to select in the query.
+func (q *PollVoteAuditQuery) Select(columns ...kallax.SchemaField) *PollVoteAuditQuery {
+	if len(columns) == 0 {
+		return q
+	}
+	q.BaseQuery.Select(columns...)
+	return q
+}
+
+// SelectNot excludes columns from being selected in the query.
+func (q *PollVoteAuditQuery) SelectNot(columns ...kallax.SchemaField) *PollVoteAuditQuery {
+	q.BaseQuery.SelectNot(columns...)
+	return q
+}
+
+// Copy returns a new identical copy of the query. Remember queries are mutable
+// so make a copy any time you need to reuse them.
+func (q *PollVoteAuditQuery) Copy() *PollVoteAuditQuery {
+	return &PollVoteAuditQuery{
+		BaseQuery: q.BaseQuery.Copy(),
+	}
+}
+
+// Order adds order clauses to the query for the given columns.
+func (q *PollVoteAuditQuery) Order(cols ...kallax.ColumnOrder) *PollVoteAuditQuery {
+	q.BaseQuery.Order(cols...)
+	return q
+}
+
+// BatchSize sets the number of items to fetch per batch when there are 1:N
+// relationships selected in the query.
+func (q *PollVoteAuditQuery) BatchSize(size uint64) *PollVoteAuditQuery {
+	q.BaseQuery.BatchSize(size)
+	return q
+}
+
+// Limit sets the max number of items to retrieve.
+func (q *PollVoteAuditQuery) Limit(n uint64) *PollVoteAuditQuery {
+	q.BaseQuery.Limit(n)
+	return q
+}
+
+// Offset sets the number of items to skip from the result set of items.
+func (q *PollVoteAuditQuery) Offset(n uint64) *PollVoteAuditQuery {
+	q.BaseQuery.Offset(n)
+	return q
+}
+
+// Where adds a condition to the query. All conditions added are concatenated
+// using a logical AND.
+func (q *PollVoteAuditQuery) Where(cond kallax.Condition) *PollVoteAuditQuery {
+	q.BaseQuery.Where(cond)
+	return q
+}
+
+// FindByID adds a new filter to the query that will require that
+// the ID property is equal to one of the passed values; if no passed values,
+// it will do nothing.
+func (q *PollVoteAuditQuery) FindByID(v ...kallax.ULID) *PollVoteAuditQuery {
+	if len(v) == 0 {
+		return q
+	}
+	values := make([]interface{}, len(v))
+	for i, val := range v {
+		values[i] = val
+	}
+	return q.Where(kallax.In(Schema.PollVoteAudit.ID, values...))
+}
+
+// FindByCreatedAt adds a new filter to the query that will require that
+// the CreatedAt property is equal to the passed value.
+func (q *PollVoteAuditQuery) FindByCreatedAt(cond kallax.ScalarCond, v time.Time) *PollVoteAuditQuery {
+	return q.Where(cond(Schema.PollVoteAudit.CreatedAt, v))
+}
+
+// FindByUpdatedAt adds a new filter to the query that will require that
+// the UpdatedAt property is equal to the passed value.
+func (q *PollVoteAuditQuery) FindByUpdatedAt(cond kallax.ScalarCond, v time.Time) *PollVoteAuditQuery {
+	return q.Where(cond(Schema.PollVoteAudit.UpdatedAt, v))
+}
+
+// FindByVoteID adds a new filter to the query that will require that
+// the VoteID property is equal to the passed value.
+func (q *PollVoteAuditQuery) FindByVoteID(v kallax.ULID) *PollVoteAuditQuery {
+	return q.Where(kallax.Eq(Schema.PollVoteAudit.VoteID, v))
+}
+
+// FindByPollID adds a new filter to the query that will require that
+// the PollID property is equal to the passed value.
+func (q *PollVoteAuditQuery) FindByPollID(v kallax.ULID) *PollVoteAuditQuery {
+	return q.Where(kallax.Eq(Schema.PollVoteAudit.PollID, v))
+}
+
+// FindByUserID adds a new filter to the query that will require that
+// the UserID property is equal to the passed value.
+func (q *PollVoteAuditQuery) FindByUserID(v kallax.ULID) *PollVoteAuditQuery {
+	return q.Where(kallax.Eq(Schema.PollVoteAudit.UserID, v))
+}
+
+// FindByPreviousOption adds a new filter to the query that will require that
+// the PreviousOption property is equal to the passed value.
+func (q *PollVoteAuditQuery) FindByPreviousOption(v string) *PollVoteAuditQuery {
+	return q.Where(kallax.Eq(Schema.PollVoteAudit.PreviousOption, v))
+}
+
+// FindByAction adds a new filter to the query that will require that
+// the Action property is equal to the passed value.
+func (q *PollVoteAuditQuery) FindByAction(v string) *PollVoteAuditQuery {
+	return q.Where(kallax.Eq(Schema.PollVoteAudit.Action, v))
+}
+
+// PollVoteAuditResultSet is the set of results returned by a query to the
+// database.
+type PollVoteAuditResultSet struct {
+	ResultSet kallax.ResultSet
+	last      *PollVoteAudit
+	lastErr   error
+}
+
+// NewPollVoteAuditResultSet creates a new result set for rows of the type
+// PollVoteAudit.
+func NewPollVoteAuditResultSet(rs kallax.ResultSet) *PollVoteAuditResultSet {
+	return &PollVoteAuditResultSet{ResultSet: rs}
+}
+
+// Next fetches the next item in the result set and returns true if there is
+// a next item.
+// The result set is closed automatically when there are no more items.
+func (rs *PollVoteAuditResultSet) Next() bool {
+	if !rs.ResultSet.Next() {
+		rs.lastErr = rs.ResultSet.Close()
+		rs.last = nil
+		return false
+	}
+
+	var record kallax.Record
+	record, rs.lastErr = rs.ResultSet.Get(Schema.PollVoteAudit.BaseSchema)
+	if rs.lastErr != nil {
+		rs.last = nil
+	} else {
+		var ok bool
+		rs.last, ok = record.(*PollVoteAudit)
+		if !ok {
+			rs.lastErr = fmt.Errorf("kallax: unable to convert record to *PollVoteAudit")
+			rs.last = nil
+		}
+	}
+
+	return true
+}
+
+// Get retrieves the last fetched item from the result set and the last error.
+func (rs *PollVoteAuditResultSet) Get() (*PollVoteAudit, error) {
+	return rs.last, rs.lastErr
+}
+
+// ForEach iterates over the complete result set passing every record found to
+// the given callback. It is possible to stop the iteration by returning
+// `kallax.ErrStop` in the callback.
+// Result set is always closed at the end.
+func (rs *PollVoteAuditResultSet) ForEach(fn func(*PollVoteAudit) error) error {
+	for rs.Next() {
+		record, err := rs.Get()
+		if err != nil {
+			return err
+		}
+
+		if err := fn(record); err != nil {
+			if err == kallax.ErrStop {
+				return rs.Close()
+			}
+
+			return err
+		}
+	}
+	return nil
+}
+
+// All returns all records on the result set and closes the result set.
+func (rs *PollVoteAuditResultSet) All() ([]*PollVoteAudit, error) {
+	var result []*PollVoteAudit
+	for rs.Next() {
+		record, err := rs.Get()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, record)
+	}
+	return result, nil
+}
+
+// One returns the first record on the result set and closes the result set.
+func (rs *PollVoteAuditResultSet) One() (*PollVoteAudit, error) {
+	if !rs.Next() {
+		return nil, kallax.ErrNotFound
+	}
+
+	record, err := rs.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rs.Close(); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// Err returns the last error occurred.
+func (rs *PollVoteAuditResultSet) Err() error {
+	return rs.lastErr
+}
+
+// Close closes the result set.
+func (rs *PollVoteAuditResultSet) Close() error {
+	return rs.ResultSet.Close()
+}
+
+// NewPollVoteSelection returns a new instance of PollVoteSelection.
+func NewPollVoteSelection() (record *PollVoteSelection) {
+	return new(PollVoteSelection)
+}
+
+// GetID returns the primary key of the model.
+func (r *PollVoteSelection) GetID() kallax.Identifier {
+	return (*kallax.ULID)(&r.ID)
+}
+
+// ColumnAddress returns the pointer to the value of the given column.
+func (r *PollVoteSelection) ColumnAddress(col string) (interface{}, error) {
+	switch col {
+	case "id":
+		return (*kallax.ULID)(&r.ID), nil
+	case "created_at":
+		return &r.Timestamps.CreatedAt, nil
+	case "updated_at":
+		return &r.Timestamps.UpdatedAt, nil
+	case "vote_id":
+		return &r.VoteID, nil
+	case "poll_id":
+		return &r.PollID, nil
+	case "poll_option_id":
+		return &r.OptionID, nil
+	case "rank":
+		return &r.Rank, nil
+	case "score":
+		return &r.Score, nil
+
+	default:
+		return nil, fmt.Errorf("kallax: invalid column in PollVoteSelection: %s", col)
+	}
+}
+
+// Value returns the value of the given column.
+func (r *PollVoteSelection) Value(col string) (interface{}, error) {
+	switch col {
+	case "id":
+		return r.ID, nil
+	case "created_at":
+		return r.Timestamps.CreatedAt, nil
+	case "updated_at":
+		return r.Timestamps.UpdatedAt, nil
+	case "vote_id":
+		return r.VoteID, nil
+	case "poll_id":
+		return r.PollID, nil
+	case "poll_option_id":
+		return r.OptionID, nil
+	case "rank":
+		return r.Rank, nil
+	case "score":
+		return r.Score, nil
+
+	default:
+		return nil, fmt.Errorf("kallax: invalid column in PollVoteSelection: %s", col)
+	}
+}
+
+// NewRelationshipRecord returns a new record for the relatiobship in the given
+// field.
+func (r *PollVoteSelection) NewRelationshipRecord(field string) (kallax.Record, error) {
+	return nil, fmt.Errorf("kallax: model PollVoteSelection has no relationships")
+}
+
+// SetRelationship sets the given relationship in the given field.
+func (r *PollVoteSelection) SetRelationship(field string, rel interface{}) error {
+	return fmt.Errorf("kallax: model PollVoteSelection has no relationships")
+}
+
+// PollVoteSelectionStore is the entity to access the records of the type PollVoteSelection
+// in the database.
+type PollVoteSelectionStore struct {
+	*kallax.Store
+}
+
+// NewPollVoteSelectionStore creates a new instance of PollVoteSelectionStore
+// using a SQL database.
+func NewPollVoteSelectionStore(db *sql.DB) *PollVoteSelectionStore {
+	return &PollVoteSelectionStore{kallax.NewStore(db)}
+}
+
+// GenericStore returns the generic store of this store.
+func (s *PollVoteSelectionStore) GenericStore() *kallax.Store {
+	return s.Store
+}
+
+// SetGenericStore changes the generic store of this store.
+func (s *PollVoteSelectionStore) SetGenericStore(store *kallax.Store) {
+	s.Store = store
+}
+
+// Debug returns a new store that will print all SQL statements to stdout using
+// the log.Printf function.
+func (s *PollVoteSelectionStore) Debug() *PollVoteSelectionStore {
+	return &PollVoteSelectionStore{s.Store.Debug()}
+}
+
+// DebugWith returns a new store that will print all SQL statements using the
+// given logger function.
+func (s *PollVoteSelectionStore) DebugWith(logger kallax.LoggerFunc) *PollVoteSelectionStore {
+	return &PollVoteSelectionStore{s.Store.DebugWith(logger)}
+}
+
+// DisableCacher turns off prepared statements, which can be useful in some scenarios.
+func (s *PollVoteSelectionStore) DisableCacher() *PollVoteSelectionStore {
+	return &PollVoteSelectionStore{s.Store.DisableCacher()}
+}
+
+// Insert inserts a PollVoteSelection in the database. A non-persisted object is
+// required for this operation.
+func (s *PollVoteSelectionStore) Insert(record *PollVoteSelection) error {
+	record.SetSaving(true)
+	defer record.SetSaving(false)
+
+	record.CreatedAt = record.CreatedAt.Truncate(time.Microsecond)
+	record.UpdatedAt = record.UpdatedAt.Truncate(time.Microsecond)
+
+	if err := record.BeforeSave(); err != nil {
+		return err
+	}
+
+	return s.Store.Insert(Schema.PollVoteSelection.BaseSchema, record)
+}
+
+// Update updates the given record on the database. If the columns are given,
+// only these columns will be updated. Otherwise all of them will be.
+// Be very careful with this, as you will have a potentially different object
+// in memory but not on the database.
+// Only writable records can be updated. Writable objects are those that have
+// been just inserted or retrieved using a query with no custom select fields.
+func (s *PollVoteSelectionStore) Update(record *PollVoteSelection, cols ...kallax.SchemaField) (updated int64, err error) {
+	record.CreatedAt = record.CreatedAt.Truncate(time.Microsecond)
+	record.UpdatedAt = record.UpdatedAt.Truncate(time.Microsecond)
+
+	record.SetSaving(true)
+	defer record.SetSaving(false)
+
+	if err := record.BeforeSave(); err != nil {
+		return 0, err
+	}
+
+	return s.Store.Update(Schema.PollVoteSelection.BaseSchema, record, cols...)
+}
+
+// Save inserts the object if the record is not persisted, otherwise it updates
+// it. Same rules of Update and Insert apply depending on the case.
+func (s *PollVoteSelectionStore) Save(record *PollVoteSelection) (updated bool, err error) {
+	if !record.IsPersisted() {
+		return false, s.Insert(record)
+	}
+
+	rowsUpdated, err := s.Update(record)
+	if err != nil {
+		return false, err
+	}
+
+	return rowsUpdated > 0, nil
+}
+
+// Delete removes the given record from the database.
+func (s *PollVoteSelectionStore) Delete(record *PollVoteSelection) error {
+	return s.Store.Delete(Schema.PollVoteSelection.BaseSchema, record)
+}
+
+// Find returns the set of results for the given query.
+func (s *PollVoteSelectionStore) Find(q *PollVoteSelectionQuery) (*PollVoteSelectionResultSet, error) {
+	rs, err := s.Store.Find(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPollVoteSelectionResultSet(rs), nil
+}
+
+// MustFind returns the set of results for the given query, but panics if there
+// is any error.
+func (s *PollVoteSelectionStore) MustFind(q *PollVoteSelectionQuery) *PollVoteSelectionResultSet {
+	return NewPollVoteSelectionResultSet(s.Store.MustFind(q))
+}
+
+// Count returns the number of rows that would be retrieved with the given
+// query.
+func (s *PollVoteSelectionStore) Count(q *PollVoteSelectionQuery) (int64, error) {
+	return s.Store.Count(q)
+}
+
+// MustCount returns the number of rows that would be retrieved with the given
+// query, but panics if there is an error.
+func (s *PollVoteSelectionStore) MustCount(q *PollVoteSelectionQuery) int64 {
+	return s.Store.MustCount(q)
+}
+
+// FindOne returns the first row returned by the given query.
+// `ErrNotFound` is returned if there are no results.
+func (s *PollVoteSelectionStore) FindOne(q *PollVoteSelectionQuery) (*PollVoteSelection, error) {
+	q.Limit(1)
+	q.Offset(0)
+	rs, err := s.Find(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if !rs.Next() {
+		return nil, kallax.ErrNotFound
+	}
+
+	record, err := rs.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rs.Close(); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// FindAll returns a list of all the rows returned by the given query.
+func (s *PollVoteSelectionStore) FindAll(q *PollVoteSelectionQuery) ([]*PollVoteSelection, error) {
+	rs, err := s.Find(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return rs.All()
+}
+
+// MustFindOne returns the first row retrieved by the given query. It panics
+// if there is an error or if there are no rows.
+func (s *PollVoteSelectionStore) MustFindOne(q *PollVoteSelectionQuery) *PollVoteSelection {
+	record, err := s.FindOne(q)
+	if err != nil {
+		panic(err)
+	}
+	return record
+}
+
+// Reload refreshes the PollVoteSelection with the data in the database and
+// makes it writable.
+func (s *PollVoteSelectionStore) Reload(record *PollVoteSelection) error {
+	return s.Store.Reload(Schema.PollVoteSelection.BaseSchema, record)
+}
+
+// Transaction executes the given callback in a transaction and rollbacks if
+// an error is returned.
+// The transaction is only open in the store passed as a parameter to the
+// callback.
+func (s *PollVoteSelectionStore) Transaction(callback func(*PollVoteSelectionStore) error) error {
+	if callback == nil {
+		return kallax.ErrInvalidTxCallback
+	}
+
+	return s.Store.Transaction(func(store *kallax.Store) error {
+		return callback(&PollVoteSelectionStore{store})
+	})
+}
+
+// PollVoteSelectionQuery is the object used to create queries for the PollVoteSelection
+// entity.
+type PollVoteSelectionQuery struct {
+	*kallax.BaseQuery
+}
+
+// NewPollVoteSelectionQuery returns a new instance of PollVoteSelectionQuery.
+func NewPollVoteSelectionQuery() *PollVoteSelectionQuery {
+	return &PollVoteSelectionQuery{
+		BaseQuery: kallax.NewBaseQuery(Schema.PollVoteSelection.BaseSchema),
+	}
+}
+
+// Select adds columns to select in the query.
+func (q *PollVoteSelectionQuery) Select(columns ...kallax.SchemaField) *PollVoteSelectionQuery {
+	if len(columns) == 0 {
+		return q
+	}
+	q.BaseQuery.Select(columns...)
+	return q
+}
+
+// SelectNot excludes columns from being selected in the query.
+func (q *PollVoteSelectionQuery) SelectNot(columns ...kallax.SchemaField) *PollVoteSelectionQuery {
+	q.BaseQuery.SelectNot(columns...)
+	return q
+}
+
+// Copy returns a new identical copy of the query. Remember queries are mutable
+// so make a copy any time you need to reuse them.
+func (q *PollVoteSelectionQuery) Copy() *PollVoteSelectionQuery {
+	return &PollVoteSelectionQuery{
+		BaseQuery: q.BaseQuery.Copy(),
+	}
+}
+
+// Order adds order clauses to the query for the given columns.
+func (q *PollVoteSelectionQuery) Order(cols ...kallax.ColumnOrder) *PollVoteSelectionQuery {
+	q.BaseQuery.Order(cols...)
+	return q
+}
+
+// BatchSize sets the number of items to fetch per batch when there are 1:N
+// relationships selected in the query.
+func (q *PollVoteSelectionQuery) BatchSize(size uint64) *PollVoteSelectionQuery {
+	q.BaseQuery.BatchSize(size)
+	return q
+}
+
+// Limit sets the max number of items to retrieve.
+func (q *PollVoteSelectionQuery) Limit(n uint64) *PollVoteSelectionQuery {
+	q.BaseQuery.Limit(n)
+	return q
+}
+
+// Offset sets the number of items to skip from the result set of items.
+func (q *PollVoteSelectionQuery) Offset(n uint64) *PollVoteSelectionQuery {
+	q.BaseQuery.Offset(n)
+	return q
+}
+
+// Where adds a condition to the query. All conditions added are concatenated
+// using a logical AND.
+func (q *PollVoteSelectionQuery) Where(cond kallax.Condition) *PollVoteSelectionQuery {
+	q.BaseQuery.Where(cond)
+	return q
+}
+
+// FindByID adds a new filter to the query that will require that
+// the ID property is equal to one of the passed values; if no passed values,
+// it will do nothing.
+func (q *PollVoteSelectionQuery) FindByID(v ...kallax.ULID) *PollVoteSelectionQuery {
+	if len(v) == 0 {
+		return q
+	}
+	values := make([]interface{}, len(v))
+	for i, val := range v {
+		values[i] = val
+	}
+	return q.Where(kallax.In(Schema.PollVoteSelection.ID, values...))
+}
+
+// FindByCreatedAt adds a new filter to the query that will require that
+// the CreatedAt property is equal to the passed value.
+func (q *PollVoteSelectionQuery) FindByCreatedAt(cond kallax.ScalarCond, v time.Time) *PollVoteSelectionQuery {
+	return q.Where(cond(Schema.PollVoteSelection.CreatedAt, v))
+}
+
+// FindByUpdatedAt adds a new filter to the query that will require that
+// the UpdatedAt property is equal to the passed value.
+func (q *PollVoteSelectionQuery) FindByUpdatedAt(cond kallax.ScalarCond, v time.Time) *PollVoteSelectionQuery {
+	return q.Where(cond(Schema.PollVoteSelection.UpdatedAt, v))
+}
+
+// FindByVoteID adds a new filter to the query that will require that
+// the VoteID property is equal to the passed value.
+func (q *PollVoteSelectionQuery) FindByVoteID(v kallax.ULID) *PollVoteSelectionQuery {
+	return q.Where(kallax.Eq(Schema.PollVoteSelection.VoteID, v))
+}
+
+// FindByPollID adds a new filter to the query that will require that
+// the PollID property is equal to the passed value.
+func (q *PollVoteSelectionQuery) FindByPollID(v kallax.ULID) *PollVoteSelectionQuery {
+	return q.Where(kallax.Eq(Schema.PollVoteSelection.PollID, v))
+}
+
+// FindByOptionID adds a new filter to the query that will require that
+// the OptionID property is equal to the passed value.
+func (q *PollVoteSelectionQuery) FindByOptionID(v kallax.ULID) *PollVoteSelectionQuery {
+	return q.Where(kallax.Eq(Schema.PollVoteSelection.OptionID, v))
+}
+
+// FindByRank adds a new filter to the query that will require that
+// the Rank property is equal to the passed value.
+func (q *PollVoteSelectionQuery) FindByRank(cond kallax.ScalarCond, v int) *PollVoteSelectionQuery {
+	return q.Where(cond(Schema.PollVoteSelection.Rank, v))
+}
+
+// FindByScore adds a new filter to the query that will require that
+// the Score property is equal to the passed value.
+func (q *PollVoteSelectionQuery) FindByScore(cond kallax.ScalarCond, v int) *PollVoteSelectionQuery {
+	return q.Where(cond(Schema.PollVoteSelection.Score, v))
+}
+
+// PollVoteSelectionResultSet is the set of results returned by a query to the
+// database.
+type PollVoteSelectionResultSet struct {
+	ResultSet kallax.ResultSet
+	last      *PollVoteSelection
+	lastErr   error
+}
+
+// NewPollVoteSelectionResultSet creates a new result set for rows of the type
+// PollVoteSelection.
+func NewPollVoteSelectionResultSet(rs kallax.ResultSet) *PollVoteSelectionResultSet {
+	return &PollVoteSelectionResultSet{ResultSet: rs}
+}
+
+// Next fetches the next item in the result set and returns true if there is
+// a next item.
+// The result set is closed automatically when there are no more items.
+func (rs *PollVoteSelectionResultSet) Next() bool {
+	if !rs.ResultSet.Next() {
+		rs.lastErr = rs.ResultSet.Close()
+		rs.last = nil
+		return false
+	}
+
+	var record kallax.Record
+	record, rs.lastErr = rs.ResultSet.Get(Schema.PollVoteSelection.BaseSchema)
+	if rs.lastErr != nil {
+		rs.last = nil
+	} else {
+		var ok bool
+		rs.last, ok = record.(*PollVoteSelection)
+		if !ok {
+			rs.lastErr = fmt.Errorf("kallax: unable to convert record to *PollVoteSelection")
+			rs.last = nil
+		}
+	}
+
+	return true
+}
+
+// Get retrieves the last fetched item from the result set and the last error.
+func (rs *PollVoteSelectionResultSet) Get() (*PollVoteSelection, error) {
+	return rs.last, rs.lastErr
+}
+
+// ForEach iterates over the complete result set passing every record found to
+// the given callback. It is possible to stop the iteration by returning
+// `kallax.ErrStop` in the callback.
+// Result set is always closed at the end.
+func (rs *PollVoteSelectionResultSet) ForEach(fn func(*PollVoteSelection) error) error {
+	for rs.Next() {
+		record, err := rs.Get()
+		if err != nil {
+			return err
+		}
+
+		if err := fn(record); err != nil {
+			if err == kallax.ErrStop {
+				return rs.Close()
+			}
+
+			return err
+		}
+	}
+	return nil
+}
+
+// All returns all records on the result set and closes the result set.
+func (rs *PollVoteSelectionResultSet) All() ([]*PollVoteSelection, error) {
+	var result []*PollVoteSelection
+	for rs.Next() {
+		record, err := rs.Get()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, record)
+	}
+	return result, nil
+}
+
+// One returns the first record on the result set and closes the result set.
+func (rs *PollVoteSelectionResultSet) One() (*PollVoteSelection, error) {
+	if !rs.Next() {
+		return nil, kallax.ErrNotFound
+	}
+
+	record, err := rs.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rs.Close(); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// Err returns the last error occurred.
+func (rs *PollVoteSelectionResultSet) Err() error {
+	return rs.lastErr
+}
+
+// Close closes the result set.
+func (rs *PollVoteSelectionResultSet) Close() error {
+	return rs.ResultSet.Close()
+}
+
+// NewPollWebhook returns a new instance of PollWebhook.
+func NewPollWebhook() (record *PollWebhook) {
+	return new(PollWebhook)
+}
+
+// GetID returns the primary key of the model.
+func (r *PollWebhook) GetID() kallax.Identifier {
+	return (*kallax.ULID)(&r.ID)
+}
+
+// ColumnAddress returns the pointer to the value of the given column.
+func (r *PollWebhook) ColumnAddress(col string) (interface{}, error) {
+	switch col {
+	case "id":
+		return (*kallax.ULID)(&r.ID), nil
+	case "created_at":
+		return &r.Timestamps.CreatedAt, nil
+	case "updated_at":
+		return &r.Timestamps.UpdatedAt, nil
+	case "poll_id":
+		return &r.PollID, nil
+	case "url":
+		return &r.URL, nil
+	case "secret":
+		return &r.Secret, nil
+	case "on_publish":
+		return &r.OnPublish, nil
+	case "on_close":
+		return &r.OnClose, nil
+	case "vote_threshold":
+		return &r.VoteThreshold, nil
+	case "threshold_reached":
+		return &r.ThresholdReached, nil
+
+	default:
+		return nil, fmt.Errorf("kallax: invalid column in PollWebhook: %s", col)
+	}
+}
+
+// Value returns the value of the given column.
+func (r *PollWebhook) Value(col string) (interface{}, error) {
+	switch col {
+	case "id":
+		return r.ID, nil
+	case "created_at":
+		return r.Timestamps.CreatedAt, nil
+	case "updated_at":
+		return r.Timestamps.UpdatedAt, nil
+	case "poll_id":
+		return r.PollID, nil
+	case "url":
+		return r.URL, nil
+	case "secret":
+		return r.Secret, nil
+	case "on_publish":
+		return r.OnPublish, nil
+	case "on_close":
+		return r.OnClose, nil
+	case "vote_threshold":
+		return r.VoteThreshold, nil
+	case "threshold_reached":
+		return r.ThresholdReached, nil
+
+	default:
+		return nil, fmt.Errorf("kallax: invalid column in PollWebhook: %s", col)
+	}
+}
+
+// NewRelationshipRecord returns a new record for the relatiobship in the given
+// field.
+func (r *PollWebhook) NewRelationshipRecord(field string) (kallax.Record, error) {
+	return nil, fmt.Errorf("kallax: model PollWebhook has no relationships")
+}
+
+// SetRelationship sets the given relationship in the given field.
+func (r *PollWebhook) SetRelationship(field string, rel interface{}) error {
+	return fmt.Errorf("kallax: model PollWebhook has no relationships")
+}
+
+// PollWebhookStore is the entity to access the records of the type PollWebhook
+// in the database.
+type PollWebhookStore struct {
+	*kallax.Store
+}
+
+// NewPollWebhookStore creates a new instance of PollWebhookStore
+// using a SQL database.
+func NewPollWebhookStore(db *sql.DB) *PollWebhookStore {
+	return &PollWebhookStore{kallax.NewStore(db)}
+}
+
+// GenericStore returns the generic store of this store.
+func (s *PollWebhookStore) GenericStore() *kallax.Store {
+	return s.Store
+}
+
+// SetGenericStore changes the generic store of this store.
+func (s *PollWebhookStore) SetGenericStore(store *kallax.Store) {
+	s.Store = store
+}
+
+// Debug returns a new store that will print all SQL statements to stdout using
+// the log.Printf function.
+func (s *PollWebhookStore) Debug() *PollWebhookStore {
+	return &PollWebhookStore{s.Store.Debug()}
+}
+
+// DebugWith returns a new store that will print all SQL statements using the
+// given logger function.
+func (s *PollWebhookStore) DebugWith(logger kallax.LoggerFunc) *PollWebhookStore {
+	return &PollWebhookStore{s.Store.DebugWith(logger)}
+}
+
+// DisableCacher turns off prepared statements, which can be useful in some scenarios.
+func (s *PollWebhookStore) DisableCacher() *PollWebhookStore {
+	return &PollWebhookStore{s.Store.DisableCacher()}
+}
+
+// Insert inserts a PollWebhook in the database. A non-persisted object is
+// required for this operation.
+func (s *PollWebhookStore) Insert(record *PollWebhook) error {
+	record.SetSaving(true)
+	defer record.SetSaving(false)
+
+	record.CreatedAt = record.CreatedAt.Truncate(time.Microsecond)
+	record.UpdatedAt = record.UpdatedAt.Truncate(time.Microsecond)
+
+	if err := record.BeforeSave(); err != nil {
+		return err
+	}
+
+	return s.Store.Insert(Schema.PollWebhook.BaseSchema, record)
+}
+
+// Update updates the given record on the database. If the columns are given,
+// only these columns will be updated. Otherwise all of them will be.
+// Be very careful with this, as you will have a potentially different object
+// in memory but not on the database.
+// Only writable records can be updated. Writable objects are those that have
+// been just inserted or retrieved using a query with no custom select fields.
+func (s *PollWebhookStore) Update(record *PollWebhook, cols ...kallax.SchemaField) (updated int64, err error) {
+	record.CreatedAt = record.CreatedAt.Truncate(time.Microsecond)
+	record.UpdatedAt = record.UpdatedAt.Truncate(time.Microsecond)
+
+	record.SetSaving(true)
+	defer record.SetSaving(false)
+
+	if err := record.BeforeSave(); err != nil {
+		return 0, err
+	}
+
+	return s.Store.Update(Schema.PollWebhook.BaseSchema, record, cols...)
+}
+
+// Save inserts the object if the record is not persisted, otherwise it updates
+// it. Same rules of Update and Insert apply depending on the case.
+func (s *PollWebhookStore) Save(record *PollWebhook) (updated bool, err error) {
+	if !record.IsPersisted() {
+		return false, s.Insert(record)
+	}
+
+	rowsUpdated, err := s.Update(record)
+	if err != nil {
+		return false, err
+	}
+
+	return rowsUpdated > 0, nil
+}
+
+// Delete removes the given record from the database.
+func (s *PollWebhookStore) Delete(record *PollWebhook) error {
+	return s.Store.Delete(Schema.PollWebhook.BaseSchema, record)
+}
+
+// Find returns the set of results for the given query.
+func (s *PollWebhookStore) Find(q *PollWebhookQuery) (*PollWebhookResultSet, error) {
+	rs, err := s.Store.Find(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPollWebhookResultSet(rs), nil
+}
+
+// MustFind returns the set of results for the given query, but panics if there
+// is any error.
+func (s *PollWebhookStore) MustFind(q *PollWebhookQuery) *PollWebhookResultSet {
+	return NewPollWebhookResultSet(s.Store.MustFind(q))
+}
+
+// Count returns the number of rows that would be retrieved with the given
+// query.
+func (s *PollWebhookStore) Count(q *PollWebhookQuery) (int64, error) {
+	return s.Store.Count(q)
+}
+
+// MustCount returns the number of rows that would be retrieved with the given
+// query, but panics if there is an error.
+func (s *PollWebhookStore) MustCount(q *PollWebhookQuery) int64 {
+	return s.Store.MustCount(q)
+}
+
+// FindOne returns the first row returned by the given query.
+// `ErrNotFound` is returned if there are no results.
+func (s *PollWebhookStore) FindOne(q *PollWebhookQuery) (*PollWebhook, error) {
+	q.Limit(1)
+	q.Offset(0)
+	rs, err := s.Find(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if !rs.Next() {
+		return nil, kallax.ErrNotFound
+	}
+
+	record, err := rs.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rs.Close(); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// FindAll returns a list of all the rows returned by the given query.
+func (s *PollWebhookStore) FindAll(q *PollWebhookQuery) ([]*PollWebhook, error) {
+	rs, err := s.Find(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return rs.All()
+}
+
+// MustFindOne returns the first row retrieved by the given query. It panics
+// if there is an error or if there are no rows.
+func (s *PollWebhookStore) MustFindOne(q *PollWebhookQuery) *PollWebhook {
+	record, err := s.FindOne(q)
+	if err != nil {
+		panic(err)
+	}
+	return record
+}
+
+// Reload refreshes the PollWebhook with the data in the database and
+// makes it writable.
+func (s *PollWebhookStore) Reload(record *PollWebhook) error {
+	return s.Store.Reload(Schema.PollWebhook.BaseSchema, record)
+}
+
+// Transaction executes the given callback in a transaction and rollbacks if
+// an error is returned.
+// The transaction is only open in the store passed as a parameter to the
+// callback.
+func (s *PollWebhookStore) Transaction(callback func(*PollWebhookStore) error) error {
+	if callback == nil {
+		return kallax.ErrInvalidTxCallback
+	}
+
+	return s.Store.Transaction(func(store *kallax.Store) error {
+		return callback(&PollWebhookStore{store})
+	})
+}
+
+// PollWebhookQuery is the object used to create queries for the PollWebhook
+// entity.
+type PollWebhookQuery struct {
+	*kallax.BaseQuery
+}
+
+// NewPollWebhookQuery returns a new instance of PollWebhookQuery.
+func NewPollWebhookQuery() *PollWebhookQuery {
+	return &PollWebhookQuery{
+		BaseQuery: kallax.NewBaseQuery(Schema.PollWebhook.BaseSchema),
+	}
+}
+
+// Select adds columns to select in the query.
+func (q *PollWebhookQuery) Select(columns ...kallax.SchemaField) *PollWebhookQuery {
+	if len(columns) == 0 {
+		return q
+	}
+	q.BaseQuery.Select(columns...)
+	return q
+}
+
+// SelectNot excludes columns from being selected in the query.
+func (q *PollWebhookQuery) SelectNot(columns ...kallax.SchemaField) *PollWebhookQuery {
+	q.BaseQuery.SelectNot(columns...)
+	return q
+}
+
+// Copy returns a new identical copy of the query. Remember queries are mutable
+// so make a copy any time you need to reuse them.
+func (q *PollWebhookQuery) Copy() *PollWebhookQuery {
+	return &PollWebhookQuery{
+		BaseQuery: q.BaseQuery.Copy(),
+	}
+}
+
+// Order adds order clauses to the query for the given columns.
+func (q *PollWebhookQuery) Order(cols ...kallax.ColumnOrder) *PollWebhookQuery {
+	q.BaseQuery.Order(cols...)
+	return q
+}
+
+// BatchSize sets the number of items to fetch per batch when there are 1:N
+// relationships selected in the query.
+func (q *PollWebhookQuery) BatchSize(size uint64) *PollWebhookQuery {
+	q.BaseQuery.BatchSize(size)
+	return q
+}
+
+// Limit sets the max number of items to retrieve.
+func (q *PollWebhookQuery) Limit(n uint64) *PollWebhookQuery {
+	q.BaseQuery.Limit(n)
+	return q
+}
+
+// Offset sets the number of items to skip from the result set of items.
+func (q *PollWebhookQuery) Offset(n uint64) *PollWebhookQuery {
+	q.BaseQuery.Offset(n)
+	return q
+}
+
+// Where adds a condition to the query. All conditions added are concatenated
+// using a logical AND.
+func (q *PollWebhookQuery) Where(cond kallax.Condition) *PollWebhookQuery {
+	q.BaseQuery.Where(cond)
+	return q
+}
+
+// FindByID adds a new filter to the query that will require that
+// the ID property is equal to one of the passed values; if no passed values,
+// it will do nothing.
+func (q *PollWebhookQuery) FindByID(v ...kallax.ULID) *PollWebhookQuery {
+	if len(v) == 0 {
+		return q
+	}
+	values := make([]interface{}, len(v))
+	for i, val := range v {
+		values[i] = val
+	}
+	return q.Where(kallax.In(Schema.PollWebhook.ID, values...))
+}
+
+// FindByCreatedAt adds a new filter to the query that will require that
+// the CreatedAt property is equal to the passed value.
+func (q *PollWebhookQuery) FindByCreatedAt(cond kallax.ScalarCond, v time.Time) *PollWebhookQuery {
+	return q.Where(cond(Schema.PollWebhook.CreatedAt, v))
+}
+
+// FindByUpdatedAt adds a new filter to the query that will require that
+// the UpdatedAt property is equal to the passed value.
+func (q *PollWebhookQuery) FindByUpdatedAt(cond kallax.ScalarCond, v time.Time) *PollWebhookQuery {
+	return q.Where(cond(Schema.PollWebhook.UpdatedAt, v))
+}
+
+// FindByPollID adds a new filter to the query that will require that
+// the PollID property is equal to the passed value.
+func (q *PollWebhookQuery) FindByPollID(v kallax.ULID) *PollWebhookQuery {
+	return q.Where(kallax.Eq(Schema.PollWebhook.PollID, v))
+}
+
+// FindByURL adds a new filter to the query that will require that
+// the URL property is equal to the passed value.
+func (q *PollWebhookQuery) FindByURL(v string) *PollWebhookQuery {
+	return q.Where(kallax.Eq(Schema.PollWebhook.URL, v))
+}
+
+// FindBySecret adds a new filter to the query that will require that
+// the Secret property is equal to the passed value.
+func (q *PollWebhookQuery) FindBySecret(v string) *PollWebhookQuery {
+	return q.Where(kallax.Eq(Schema.PollWebhook.Secret, v))
+}
+
+// FindByOnPublish adds a new filter to the query that will require that
+// the OnPublish property is equal to the passed value.
+func (q *PollWebhookQuery) FindByOnPublish(v bool) *PollWebhookQuery {
+	return q.Where(kallax.Eq(Schema.PollWebhook.OnPublish, v))
+}
+
+// FindByOnClose adds a new filter to the query that will require that
+// the OnClose property is equal to the passed value.
+func (q *PollWebhookQuery) FindByOnClose(v bool) *PollWebhookQuery {
+	return q.Where(kallax.Eq(Schema.PollWebhook.OnClose, v))
+}
+
+// FindByVoteThreshold adds a new filter to the query that will require that
+// the VoteThreshold property is equal to the passed value.
+func (q *PollWebhookQuery) FindByVoteThreshold(cond kallax.ScalarCond, v int) *PollWebhookQuery {
+	return q.Where(cond(Schema.PollWebhook.VoteThreshold, v))
+}
+
+// FindByThresholdReached adds a new filter to the query that will require that
+// the ThresholdReached property is equal to the passed value.
+func (q *PollWebhookQuery) FindByThresholdReached(v bool) *PollWebhookQuery {
+	return q.Where(kallax.Eq(Schema.PollWebhook.ThresholdReached, v))
+}
+
+// PollWebhookResultSet is the set of results returned by a query to the
+// database.
+type PollWebhookResultSet struct {
+	ResultSet kallax.ResultSet
+	last      *PollWebhook
+	lastErr   error
+}
+
+// NewPollWebhookResultSet creates a new result set for rows of the type
+// PollWebhook.
+func NewPollWebhookResultSet(rs kallax.ResultSet) *PollWebhookResultSet {
+	return &PollWebhookResultSet{ResultSet: rs}
+}
+
+// Next fetches the next item in the result set and returns true if there is
+// a next item.
+// The result set is closed automatically when there are no more items.
+func (rs *PollWebhookResultSet) Next() bool {
+	if !rs.ResultSet.Next() {
+		rs.lastErr = rs.ResultSet.Close()
+		rs.last = nil
+		return false
+	}
+
+	var record kallax.Record
+	record, rs.lastErr = rs.ResultSet.Get(Schema.PollWebhook.BaseSchema)
+	if rs.lastErr != nil {
+		rs.last = nil
+	} else {
+		var ok bool
+		rs.last, ok = record.(*PollWebhook)
+		if !ok {
+			rs.lastErr = fmt.Errorf("kallax: unable to convert record to *PollWebhook")
+			rs.last = nil
+		}
+	}
+
+	return true
+}
+
+// Get retrieves the last fetched item from the result set and the last error.
+func (rs *PollWebhookResultSet) Get() (*PollWebhook, error) {
+	return rs.last, rs.lastErr
+}
+
+// ForEach iterates over the complete result set passing every record found to
+// the given callback. It is possible to stop the iteration by returning
+// `kallax.ErrStop` in the callback.
+// Result set is always closed at the end.
+func (rs *PollWebhookResultSet) ForEach(fn func(*PollWebhook) error) error {
+	for rs.Next() {
+		record, err := rs.Get()
+		if err != nil {
+			return err
+		}
+
+		if err := fn(record); err != nil {
+			if err == kallax.ErrStop {
+				return rs.Close()
+			}
+
+			return err
+		}
+	}
+	return nil
+}
+
+// All returns all records on the result set and closes the result set.
+func (rs *PollWebhookResultSet) All() ([]*PollWebhook, error) {
+	var result []*PollWebhook
+	for rs.Next() {
+		record, err := rs.Get()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, record)
+	}
+	return result, nil
+}
+
+// One returns the first record on the result set and closes the result set.
+func (rs *PollWebhookResultSet) One() (*PollWebhook, error) {
+	if !rs.Next() {
+		return nil, kallax.ErrNotFound
+	}
+
+	record, err := rs.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rs.Close(); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// Err returns the last error occurred.
+func (rs *PollWebhookResultSet) Err() error {
+	return rs.lastErr
+}
+
+// Close closes the result set.
+func (rs *PollWebhookResultSet) Close() error {
+	return rs.ResultSet.Close()
+}
+
+// NewPollWebhookDelivery returns a new instance of PollWebhookDelivery.
+func NewPollWebhookDelivery() (record *PollWebhookDelivery) {
+	return new(PollWebhookDelivery)
+}
+
+// GetID returns the primary key of the model.
+func (r *PollWebhookDelivery) GetID() kallax.Identifier {
+	return (*kallax.ULID)(&r.ID)
+}
+
+// ColumnAddress returns the pointer to the value of the given column.
+func (r *PollWebhookDelivery) ColumnAddress(col string) (interface{}, error) {
+	switch col {
+	case "id":
+		return (*kallax.ULID)(&r.ID), nil
+	case "created_at":
+		return &r.Timestamps.CreatedAt, nil
+	case "updated_at":
+		return &r.Timestamps.UpdatedAt, nil
+	case "webhook_id":
+		return &r.WebhookID, nil
+	case "event":
+		return &r.Event, nil
+	case "payload":
+		return &r.Payload, nil
+	case "status":
+		return &r.Status, nil
+	case "attempts":
+		return &r.Attempts, nil
+	case "next_attempt_at":
+		return &r.NextAttemptAt, nil
+	case "last_error":
+		return &r.LastError, nil
+	case "event_key":
+		return &r.EventKey, nil
+
+	default:
+		return nil, fmt.Errorf("kallax: invalid column in PollWebhookDelivery: %s", col)
+	}
+}
+
+// Value returns the value of the given column.
+func (r *PollWebhookDelivery) Value(col string) (interface{}, error) {
+	switch col {
+	case "id":
+		return r.ID, nil
+	case "created_at":
+		return r.Timestamps.CreatedAt, nil
+	case "updated_at":
+		return r.Timestamps.UpdatedAt, nil
+	case "webhook_id":
+		return r.WebhookID, nil
+	case "event":
+		return r.Event, nil
+	case "payload":
+		return r.Payload, nil
+	case "status":
+		return r.Status, nil
+	case "attempts":
+		return r.Attempts, nil
+	case "next_attempt_at":
+		return r.NextAttemptAt, nil
+	case "last_error":
+		return r.LastError, nil
+	case "event_key":
+		return r.EventKey, nil
+
+	default:
+		return nil, fmt.Errorf("kallax: invalid column in PollWebhookDelivery: %s", col)
+	}
+}
+
+// NewRelationshipRecord returns a new record for the relatiobship in the given
+// field.
+func (r *PollWebhookDelivery) NewRelationshipRecord(field string) (kallax.Record, error) {
+	return nil, fmt.Errorf("kallax: model PollWebhookDelivery has no relationships")
+}
+
+// SetRelationship sets the given relationship in the given field.
+func (r *PollWebhookDelivery) SetRelationship(field string, rel interface{}) error {
+	return fmt.Errorf("kallax: model PollWebhookDelivery has no relationships")
+}
+
+// PollWebhookDeliveryStore is the entity to access the records of the type PollWebhookDelivery
+// in the database.
+type PollWebhookDeliveryStore struct {
+	*kallax.Store
+}
+
+// NewPollWebhookDeliveryStore creates a new instance of PollWebhookDeliveryStore
+// using a SQL database.
+func NewPollWebhookDeliveryStore(db *sql.DB) *PollWebhookDeliveryStore {
+	return &PollWebhookDeliveryStore{kallax.NewStore(db)}
+}
+
+// GenericStore returns the generic store of this store.
+func (s *PollWebhookDeliveryStore) GenericStore() *kallax.Store {
+	return s.Store
+}
+
+// SetGenericStore changes the generic store of this store.
+func (s *PollWebhookDeliveryStore) SetGenericStore(store *kallax.Store) {
+	s.Store = store
+}
+
+// Debug returns a new store that will print all SQL statements to stdout using
+// the log.Printf function.
+func (s *PollWebhookDeliveryStore) Debug() *PollWebhookDeliveryStore {
+	return &PollWebhookDeliveryStore{s.Store.Debug()}
+}
+
+// DebugWith returns a new store that will print all SQL statements using the
+// given logger function.
+func (s *PollWebhookDeliveryStore) DebugWith(logger kallax.LoggerFunc) *PollWebhookDeliveryStore {
+	return &PollWebhookDeliveryStore{s.Store.DebugWith(logger)}
+}
+
+// DisableCacher turns off prepared statements, which can be useful in some scenarios.
+func (s *PollWebhookDeliveryStore) DisableCacher() *PollWebhookDeliveryStore {
+	return &PollWebhookDeliveryStore{s.Store.DisableCacher()}
+}
+
+// Insert inserts a PollWebhookDelivery in the database. A non-persisted object is
+// required for this operation.
+func (s *PollWebhookDeliveryStore) Insert(record *PollWebhookDelivery) error {
+	record.SetSaving(true)
+	defer record.SetSaving(false)
+
+	record.CreatedAt = record.CreatedAt.Truncate(time.Microsecond)
+	record.UpdatedAt = record.UpdatedAt.Truncate(time.Microsecond)
+	record.NextAttemptAt = record.NextAttemptAt.Truncate(time.Microsecond)
+
+	if err := record.BeforeSave(); err != nil {
+		return err
+	}
+
+	return s.Store.Insert(Schema.PollWebhookDelivery.BaseSchema, record)
+}
+
+// Update updates the given record on the database. If the columns are given,
+// only these columns will be updated. Otherwise all of them will be.
+// Be very careful with this, as you will have a potentially different object
+// in memory but not on the database.
+// Only writable records can be updated. Writable objects are those that have
+// been just inserted or retrieved using a query with no custom select fields.
+func (s *PollWebhookDeliveryStore) Update(record *PollWebhookDelivery, cols ...kallax.SchemaField) (updated int64, err error) {
+	record.CreatedAt = record.CreatedAt.Truncate(time.Microsecond)
+	record.UpdatedAt = record.UpdatedAt.Truncate(time.Microsecond)
+	record.NextAttemptAt = record.NextAttemptAt.Truncate(time.Microsecond)
+
+	record.SetSaving(true)
+	defer record.SetSaving(false)
+
+	if err := record.BeforeSave(); err != nil {
+		return 0, err
+	}
+
+	return s.Store.Update(Schema.PollWebhookDelivery.BaseSchema, record, cols...)
+}
+
+// Save inserts the object if the record is not persisted, otherwise it updates
+// it. Same rules of Update and Insert apply depending on the case.
+func (s *PollWebhookDeliveryStore) Save(record *PollWebhookDelivery) (updated bool, err error) {
+	if !record.IsPersisted() {
+		return false, s.Insert(record)
+	}
+
+	rowsUpdated, err := s.Update(record)
+	if err != nil {
+		return false, err
+	}
+
+	return rowsUpdated > 0, nil
+}
+
+// Delete removes the given record from the database.
+func (s *PollWebhookDeliveryStore) Delete(record *PollWebhookDelivery) error {
+	return s.Store.Delete(Schema.PollWebhookDelivery.BaseSchema, record)
+}
+
+// Find returns the set of results for the given query.
+func (s *PollWebhookDeliveryStore) Find(q *PollWebhookDeliveryQuery) (*PollWebhookDeliveryResultSet, error) {
+	rs, err := s.Store.Find(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPollWebhookDeliveryResultSet(rs), nil
+}
+
+// MustFind returns the set of results for the given query, but panics if there
+// is any error.
+func (s *PollWebhookDeliveryStore) MustFind(q *PollWebhookDeliveryQuery) *PollWebhookDeliveryResultSet {
+	return NewPollWebhookDeliveryResultSet(s.Store.MustFind(q))
+}
+
+// Count returns the number of rows that would be retrieved with the given
+// query.
+func (s *PollWebhookDeliveryStore) Count(q *PollWebhookDeliveryQuery) (int64, error) {
+	return s.Store.Count(q)
+}
+
+// MustCount returns the number of rows that would be retrieved with the given
+// query, but panics if there is an error.
+func (s *PollWebhookDeliveryStore) MustCount(q *PollWebhookDeliveryQuery) int64 {
+	return s.Store.MustCount(q)
+}
+
+// FindOne returns the first row returned by the given query.
+// `ErrNotFound` is returned if there are no results.
+func (s *PollWebhookDeliveryStore) FindOne(q *PollWebhookDeliveryQuery) (*PollWebhookDelivery, error) {
+	q.Limit(1)
+	q.Offset(0)
+	rs, err := s.Find(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if !rs.Next() {
+		return nil, kallax.ErrNotFound
+	}
+
+	record, err := rs.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rs.Close(); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// FindAll returns a list of all the rows returned by the given query.
+func (s *PollWebhookDeliveryStore) FindAll(q *PollWebhookDeliveryQuery) ([]*PollWebhookDelivery, error) {
+	rs, err := s.Find(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return rs.All()
+}
+
+// MustFindOne returns the first row retrieved by the given query. It panics
+// if there is an error or if there are no rows.
+func (s *PollWebhookDeliveryStore) MustFindOne(q *PollWebhookDeliveryQuery) *PollWebhookDelivery {
+	record, err := s.FindOne(q)
+	if err != nil {
+		panic(err)
+	}
+	return record
+}
+
+// Reload refreshes the PollWebhookDelivery with the data in the database and
+// makes it writable.
+func (s *PollWebhookDeliveryStore) Reload(record *PollWebhookDelivery) error {
+	return s.Store.Reload(Schema.PollWebhookDelivery.BaseSchema, record)
+}
+
+// Transaction executes the given callback in a transaction and rollbacks if
+// an error is returned.
+// The transaction is only open in the store passed as a parameter to the
+// callback.
+func (s *PollWebhookDeliveryStore) Transaction(callback func(*PollWebhookDeliveryStore) error) error {
+	if callback == nil {
+		return kallax.ErrInvalidTxCallback
+	}
+
+	return s.Store.Transaction(func(store *kallax.Store) error {
+		return callback(&PollWebhookDeliveryStore{store})
+	})
+}
+
+// PollWebhookDeliveryQuery is the object used to create queries for the PollWebhookDelivery
+// entity.
+type PollWebhookDeliveryQuery struct {
+	*kallax.BaseQuery
+}
+
+// NewPollWebhookDeliveryQuery returns a new instance of PollWebhookDeliveryQuery.
+func NewPollWebhookDeliveryQuery() *PollWebhookDeliveryQuery {
+	return &PollWebhookDeliveryQuery{
+		BaseQuery: kallax.NewBaseQuery(Schema.PollWebhookDelivery.BaseSchema),
+	}
+}
+
+// Select adds columns to select in the query.
+func (q *PollWebhookDeliveryQuery) Select(columns ...kallax.SchemaField) *PollWebhookDeliveryQuery {
+	if len(columns) == 0 {
+		return q
+	}
+	q.BaseQuery.Select(columns...)
+	return q
+}
+
+// SelectNot excludes columns from being selected in the query.
+func (q *PollWebhookDeliveryQuery) SelectNot(columns ...kallax.SchemaField) *PollWebhookDeliveryQuery {
+	q.BaseQuery.SelectNot(columns...)
+	return q
+}
+
+// Copy returns a new identical copy of the query. Remember queries are mutable
+// so make a copy any time you need to reuse them.
+func (q *PollWebhookDeliveryQuery) Copy() *PollWebhookDeliveryQuery {
+	return &PollWebhookDeliveryQuery{
+		BaseQuery: q.BaseQuery.Copy(),
+	}
+}
+
+// Order adds order clauses to the query for the given columns.
+func (q *PollWebhookDeliveryQuery) Order(cols ...kallax.ColumnOrder) *PollWebhookDeliveryQuery {
+	q.BaseQuery.Order(cols...)
+	return q
+}
+
+// BatchSize sets the number of items to fetch per batch when there are 1:N
+// relationships selected in the query.
+func (q *PollWebhookDeliveryQuery) BatchSize(size uint64) *PollWebhookDeliveryQuery {
+	q.BaseQuery.BatchSize(size)
+	return q
+}
+
+// Limit sets the max number of items to retrieve.
+func (q *PollWebhookDeliveryQuery) Limit(n uint64) *PollWebhookDeliveryQuery {
+	q.BaseQuery.Limit(n)
+	return q
+}
+
+// Offset sets the number of items to skip from the result set of items.
+func (q *PollWebhookDeliveryQuery) Offset(n uint64) *PollWebhookDeliveryQuery {
+	q.BaseQuery.Offset(n)
+	return q
+}
+
+// Where adds a condition to the query. All conditions added are concatenated
+// using a logical AND.
+func (q *PollWebhookDeliveryQuery) Where(cond kallax.Condition) *PollWebhookDeliveryQuery {
+	q.BaseQuery.Where(cond)
+	return q
+}
+
+// FindByID adds a new filter to the query that will require that
+// the ID property is equal to one of the passed values; if no passed values,
+// it will do nothing.
+func (q *PollWebhookDeliveryQuery) FindByID(v ...kallax.ULID) *PollWebhookDeliveryQuery {
+	if len(v) == 0 {
+		return q
+	}
+	values := make([]interface{}, len(v))
+	for i, val := range v {
+		values[i] = val
+	}
+	return q.Where(kallax.In(Schema.PollWebhookDelivery.ID, values...))
+}
+
+// FindByCreatedAt adds a new filter to the query that will require that
+// the CreatedAt property is equal to the passed value.
+func (q *PollWebhookDeliveryQuery) FindByCreatedAt(cond kallax.ScalarCond, v time.Time) *PollWebhookDeliveryQuery {
+	return q.Where(cond(Schema.PollWebhookDelivery.CreatedAt, v))
+}
+
+// FindByUpdatedAt adds a new filter to the query that will require that
+// the UpdatedAt property is equal to the passed value.
+func (q *PollWebhookDeliveryQuery) FindByUpdatedAt(cond kallax.ScalarCond, v time.Time) *PollWebhookDeliveryQuery {
+	return q.Where(cond(Schema.PollWebhookDelivery.UpdatedAt, v))
+}
+
+// FindByWebhookID adds a new filter to the query that will require that
+// the WebhookID property is equal to the passed value.
+func (q *PollWebhookDeliveryQuery) FindByWebhookID(v kallax.ULID) *PollWebhookDeliveryQuery {
+	return q.Where(kallax.Eq(Schema.PollWebhookDelivery.WebhookID, v))
+}
+
+// FindByEvent adds a new filter to the query that will require that
+// the Event property is equal to the passed value.
+func (q *PollWebhookDeliveryQuery) FindByEvent(v string) *PollWebhookDeliveryQuery {
+	return q.Where(kallax.Eq(Schema.PollWebhookDelivery.Event, v))
+}
+
+// FindByPayload adds a new filter to the query that will require that
+// the Payload property is equal to the passed value.
+func (q *PollWebhookDeliveryQuery) FindByPayload(v string) *PollWebhookDeliveryQuery {
+	return q.Where(kallax.Eq(Schema.PollWebhookDelivery.Payload, v))
+}
+
+// FindByStatus adds a new filter to the query that will require that
+// the Status property is equal to the passed value.
+func (q *PollWebhookDeliveryQuery) FindByStatus(v string) *PollWebhookDeliveryQuery {
+	return q.Where(kallax.Eq(Schema.PollWebhookDelivery.Status, v))
+}
+
+// FindByAttempts adds a new filter to the query that will require that
+// the Attempts property is equal to the passed value.
+func (q *PollWebhookDeliveryQuery) FindByAttempts(cond kallax.ScalarCond, v int) *PollWebhookDeliveryQuery {
+	return q.Where(cond(Schema.PollWebhookDelivery.Attempts, v))
+}
+
+// FindByNextAttemptAt adds a new filter to the query that will require that
+// the NextAttemptAt property is equal to the passed value.
+func (q *PollWebhookDeliveryQuery) FindByNextAttemptAt(cond kallax.ScalarCond, v time.Time) *PollWebhookDeliveryQuery {
+	return q.Where(cond(Schema.PollWebhookDelivery.NextAttemptAt, v))
+}
+
+// FindByLastError adds a new filter to the query that will require that
+// the LastError property is equal to the passed value.
+func (q *PollWebhookDeliveryQuery) FindByLastError(v string) *PollWebhookDeliveryQuery {
+	return q.Where(kallax.Eq(Schema.PollWebhookDelivery.LastError, v))
+}
+
+// FindByEventKey adds a new filter to the query that will require that
+// the EventKey property is equal to the passed value.
+func (q *PollWebhookDeliveryQuery) FindByEventKey(v string) *PollWebhookDeliveryQuery {
+	return q.Where(kallax.Eq(Schema.PollWebhookDelivery.EventKey, v))
+}
+
+// PollWebhookDeliveryResultSet is the set of results returned by a query to the
+// database.
+type PollWebhookDeliveryResultSet struct {
+	ResultSet kallax.ResultSet
+	last      *PollWebhookDelivery
+	lastErr   error
+}
+
+// NewPollWebhookDeliveryResultSet creates a new result set for rows of the type
+// PollWebhookDelivery.
+func NewPollWebhookDeliveryResultSet(rs kallax.ResultSet) *PollWebhookDeliveryResultSet {
+	return &PollWebhookDeliveryResultSet{ResultSet: rs}
+}
+
+// Next fetches the next item in the result set and returns true if there is
+// a next item.
+// The result set is closed automatically when there are no more items.
+func (rs *PollWebhookDeliveryResultSet) Next() bool {
+	if !rs.ResultSet.Next() {
+		rs.lastErr = rs.ResultSet.Close()
+		rs.last = nil
+		return false
+	}
+
+	var record kallax.Record
+	record, rs.lastErr = rs.ResultSet.Get(Schema.PollWebhookDelivery.BaseSchema)
+	if rs.lastErr != nil {
+		rs.last = nil
+	} else {
+		var ok bool
+		rs.last, ok = record.(*PollWebhookDelivery)
+		if !ok {
+			rs.lastErr = fmt.Errorf("kallax: unable to convert record to *PollWebhookDelivery")
+			rs.last = nil
+		}
+	}
+
+	return true
+}
+
+// Get retrieves the last fetched item from the result set and the last error.
+func (rs *PollWebhookDeliveryResultSet) Get() (*PollWebhookDelivery, error) {
+	return rs.last, rs.lastErr
+}
+
+// ForEach iterates over the complete result set passing every record found to
+// the given callback. It is possible to stop the iteration by returning
+// `kallax.ErrStop` in the callback.
+// Result set is always closed at the end.
+func (rs *PollWebhookDeliveryResultSet) ForEach(fn func(*PollWebhookDelivery) error) error {
+	for rs.Next() {
+		record, err := rs.Get()
+		if err != nil {
+			return err
+		}
+
+		if err := fn(record); err != nil {
+			if err == kallax.ErrStop {
+				return rs.Close()
+			}
+
+			return err
+		}
+	}
+	return nil
+}
+
+// All returns all records on the result set and closes the result set.
+func (rs *PollWebhookDeliveryResultSet) All() ([]*PollWebhookDelivery, error) {
+	var result []*PollWebhookDelivery
+	for rs.Next() {
+		record, err := rs.Get()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, record)
+	}
+	return result, nil
+}
+
+// One returns the first record on the result set and closes the result set.
+func (rs *PollWebhookDeliveryResultSet) One() (*PollWebhookDelivery, error) {
+	if !rs.Next() {
+		return nil, kallax.ErrNotFound
+	}
+
+	record, err := rs.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rs.Close(); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// Err returns the last error occurred.
+func (rs *PollWebhookDeliveryResultSet) Err() error {
+	return rs.lastErr
+}
+
+// Close closes the result set.
+func (rs *PollWebhookDeliveryResultSet) Close() error {
+	return rs.ResultSet.Close()
+}
+
+// NewSession returns a new instance of Session.
+func NewSession() (record *Session) {
+	return new(Session)
+}
+
+// GetID returns the primary key of the model.
+func (r *Session) GetID() kallax.Identifier {
+	return (*kallax.ULID)(&r.ID)
+}
+
+// ColumnAddress returns the pointer to the value of the given column.
+func (r *Session) ColumnAddress(col string) (interface{}, error) {
+	switch col {
+	case "id":
+		return (*kallax.ULID)(&r.ID), nil
+	case "created_at":
+		return &r.Timestamps.CreatedAt, nil
+	case "updated_at":
+		return &r.Timestamps.UpdatedAt, nil
+	case "user_id":
+		return &r.UserID, nil
+	case "registered_user":
+		return &r.RegisteredUser, nil
+	case "expires_at":
+		return &r.ExpiresAt, nil
+
+	default:
+		return nil, fmt.Errorf("kallax: invalid column in Session: %s", col)
+	}
+}
+
+// Value returns the value of the given column.
+func (r *Session) Value(col string) (interface{}, error) {
+	switch col {
+	case "id":
+		return r.ID, nil
+	case "created_at":
+		return r.Timestamps.CreatedAt, nil
+	case "updated_at":
+		return r.Timestamps.UpdatedAt, nil
+	case "user_id":
+		return r.UserID, nil
+	case "registered_user":
+		return r.RegisteredUser, nil
+	case "expires_at":
+		return r.ExpiresAt, nil
+
+	default:
+		return nil, fmt.Errorf("kallax: invalid column in Session: %s", col)
+	}
+}
+
+// NewRelationshipRecord returns a new record for the relatiobship in the given
+// field.
+func (r *Session) NewRelationshipRecord(field string) (kallax.Record, error) {
+	return nil, fmt.Errorf("kallax: model Session has no relationships")
+}
+
+// SetRelationship sets the given relationship in the given field.
+func (r *Session) SetRelationship(field string, rel interface{}) error {
+	return fmt.Errorf("kallax: model Session has no relationships")
+}
+
+// SessionStore is the entity to access the records of the type Session
+// in the database.
+type SessionStore struct {
+	*kallax.Store
+}
+
+// NewSessionStore creates a new instance of SessionStore
+// using a SQL database.
+func NewSessionStore(db *sql.DB) *SessionStore {
+	return &SessionStore{kallax.NewStore(db)}
+}
+
+// GenericStore returns the generic store of this store.
+func (s *SessionStore) GenericStore() *kallax.Store {
+	return s.Store
+}
+
+// SetGenericStore changes the generic store of this store.
+func (s *SessionStore) SetGenericStore(store *kallax.Store) {
+	s.Store = store
+}
+
+// Debug returns a new store that will print all SQL statements to stdout using
+// the log.Printf function.
+func (s *SessionStore) Debug() *SessionStore {
+	return &SessionStore{s.Store.Debug()}
+}
+
+// DebugWith returns a new store that will print all SQL statements using the
+// given logger function.
+func (s *SessionStore) DebugWith(logger kallax.LoggerFunc) *SessionStore {
+	return &SessionStore{s.Store.DebugWith(logger)}
+}
+
+// DisableCacher turns off prepared statements, which can be useful in some scenarios.
+func (s *SessionStore) DisableCacher() *SessionStore {
+	return &SessionStore{s.Store.DisableCacher()}
+}
+
+// Insert inserts a Session in the database. A non-persisted object is
+// required for this operation.
+func (s *SessionStore) Insert(record *Session) error {
+	record.SetSaving(true)
+	defer record.SetSaving(false)
+
+	record.CreatedAt = record.CreatedAt.Truncate(time.Microsecond)
+	record.UpdatedAt = record.UpdatedAt.Truncate(time.Microsecond)
+	record.ExpiresAt = record.ExpiresAt.Truncate(time.Microsecond)
+
+	if err := record.BeforeSave(); err != nil {
+		return err
+	}
+
+	return s.Store.Insert(Schema.Session.BaseSchema, record)
+}
+
+// Update updates the given record on the database. If the columns are given,
+// only these columns will be updated. Otherwise all of them will be.
+// Be very careful with this, as you will have a potentially different object
+// in memory but not on the database.
+// Only writable records can be updated. Writable objects are those that have
+// been just inserted or retrieved using a query with no custom select fields.
+func (s *SessionStore) Update(record *Session, cols ...kallax.SchemaField) (updated int64, err error) {
+	record.CreatedAt = record.CreatedAt.Truncate(time.Microsecond)
+	record.UpdatedAt = record.UpdatedAt.Truncate(time.Microsecond)
+	record.ExpiresAt = record.ExpiresAt.Truncate(time.Microsecond)
+
+	record.SetSaving(true)
+	defer record.SetSaving(false)
+
+	if err := record.BeforeSave(); err != nil {
+		return 0, err
+	}
+
+	return s.Store.Update(Schema.Session.BaseSchema, record, cols...)
+}
+
+// Save inserts the object if the record is not persisted, otherwise it updates
+// it. Same rules of Update and Insert apply depending on the case.
+func (s *SessionStore) Save(record *Session) (updated bool, err error) {
+	if !record.IsPersisted() {
+		return false, s.Insert(record)
+	}
+
+	rowsUpdated, err := s.Update(record)
+	if err != nil {
+		return false, err
+	}
+
+	return rowsUpdated > 0, nil
+}
+
+// Delete removes the given record from the database.
+func (s *SessionStore) Delete(record *Session) error {
+	return s.Store.Delete(Schema.Session.BaseSchema, record)
+}
+
+// Find returns the set of results for the given query.
+func (s *SessionStore) Find(q *SessionQuery) (*SessionResultSet, error) {
+	rs, err := s.Store.Find(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSessionResultSet(rs), nil
+}
+
+// MustFind returns the set of results for the given query, but panics if there
+// is any error.
+func (s *SessionStore) MustFind(q *SessionQuery) *SessionResultSet {
+	return NewSessionResultSet(s.Store.MustFind(q))
+}
+
+// Count returns the number of rows that would be retrieved with the given
+// query.
+func (s *SessionStore) Count(q *SessionQuery) (int64, error) {
+	return s.Store.Count(q)
+}
+
+// MustCount returns the number of rows that would be retrieved with the given
+// query, but panics if there is an error.
+func (s *SessionStore) MustCount(q *SessionQuery) int64 {
+	return s.Store.MustCount(q)
+}
+
+// FindOne returns the first row returned by the given query.
+// `ErrNotFound` is returned if there are no results.
+func (s *SessionStore) FindOne(q *SessionQuery) (*Session, error) {
+	q.Limit(1)
+	q.Offset(0)
+	rs, err := s.Find(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if !rs.Next() {
+		return nil, kallax.ErrNotFound
+	}
+
+	record, err := rs.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rs.Close(); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// FindAll returns a list of all the rows returned by the given query.
+func (s *SessionStore) FindAll(q *SessionQuery) ([]*Session, error) {
+	rs, err := s.Find(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return rs.All()
+}
+
+// MustFindOne returns the first row retrieved by the given query. It panics
+// if there is an error or if there are no rows.
+func (s *SessionStore) MustFindOne(q *SessionQuery) *Session {
+	record, err := s.FindOne(q)
+	if err != nil {
+		panic(err)
+	}
+	return record
+}
+
+// Reload refreshes the Session with the data in the database and
+// makes it writable.
+func (s *SessionStore) Reload(record *Session) error {
+	return s.Store.Reload(Schema.Session.BaseSchema, record)
 }
 
 // Transaction executes the given callback in a transaction and rollbacks if
@@ -2006,6 +4666,12 @@ func (q *SessionQuery) FindByRegisteredUser(v bool) *SessionQuery {
 	return q.Where(kallax.Eq(Schema.Session.RegisteredUser, v))
 }
 
+// FindByExpiresAt adds a new filter to the query that will require that
+// the ExpiresAt property is equal to the passed value.
+func (q *SessionQuery) FindByExpiresAt(cond kallax.ScalarCond, v time.Time) *SessionQuery {
+	return q.Where(cond(Schema.Session.ExpiresAt, v))
+}
+
 // SessionResultSet is the set of results returned by a query to the
 // database.
 type SessionResultSet struct {
@@ -2587,28 +5253,55 @@ func (rs *UserResultSet) Close() error {
 }
 
 type schema struct {
-	Poll       *schemaPoll
-	PollOption *schemaPollOption
-	PollVote   *schemaPollVote
-	Session    *schemaSession
-	User       *schemaUser
+	Poll                *schemaPoll
+	PollOption          *schemaPollOption
+	PollOutbox          *schemaPollOutbox
+	PollVote            *schemaPollVote
+	PollVoteAudit       *schemaPollVoteAudit
+	PollVoteSelection   *schemaPollVoteSelection
+	PollWebhook         *schemaPollWebhook
+	PollWebhookDelivery *schemaPollWebhookDelivery
+	Session             *schemaSession
+	User                *schemaUser
 }
 
 type schemaPoll struct {
 	*kallax.BaseSchema
-	ID        kallax.SchemaField
-	CreatedAt kallax.SchemaField
-	UpdatedAt kallax.SchemaField
-	Name      kallax.SchemaField
-	Owner     kallax.SchemaField
-	Published kallax.SchemaField
+	ID           kallax.SchemaField
+	CreatedAt    kallax.SchemaField
+	UpdatedAt    kallax.SchemaField
+	Name         kallax.SchemaField
+	Owner        kallax.SchemaField
+	Status       kallax.SchemaField
+	OpensAt      kallax.SchemaField
+	ClosesAt     kallax.SchemaField
+	MinChoices   kallax.SchemaField
+	MaxChoices   kallax.SchemaField
+	VotingMethod kallax.SchemaField
+	MaxScore     kallax.SchemaField
 }
 
 type schemaPollOption struct {
 	*kallax.BaseSchema
-	ID      kallax.SchemaField
-	OwnerFK kallax.SchemaField
-	Content kallax.SchemaField
+	ID       kallax.SchemaField
+	OwnerFK  kallax.SchemaField
+	Content  kallax.SchemaField
+	Position kallax.SchemaField
+}
+
+type schemaPollOutbox struct {
+	*kallax.BaseSchema
+	ID            kallax.SchemaField
+	CreatedAt     kallax.SchemaField
+	UpdatedAt     kallax.SchemaField
+	Event         kallax.SchemaField
+	Payload       kallax.SchemaField
+	Published     kallax.SchemaField
+	PublishedAt   kallax.SchemaField
+	Attempts      kallax.SchemaField
+	NextAttemptAt kallax.SchemaField
+	LastError     kallax.SchemaField
+	Dead          kallax.SchemaField
 }
 
 type schemaPollVote struct {
@@ -2619,6 +5312,60 @@ type schemaPollVote struct {
 	PollID       kallax.SchemaField
 	UserID       kallax.SchemaField
 	ChosenOption kallax.SchemaField
+	OptionID     kallax.SchemaField
+}
+
+type schemaPollVoteAudit struct {
+	*kallax.BaseSchema
+	ID             kallax.SchemaField
+	CreatedAt      kallax.SchemaField
+	UpdatedAt      kallax.SchemaField
+	VoteID         kallax.SchemaField
+	PollID         kallax.SchemaField
+	UserID         kallax.SchemaField
+	PreviousOption kallax.SchemaField
+	Action         kallax.SchemaField
+}
+
+type schemaPollVoteSelection struct {
+	*kallax.BaseSchema
+	ID        kallax.SchemaField
+	CreatedAt kallax.SchemaField
+	UpdatedAt kallax.SchemaField
+	VoteID    kallax.SchemaField
+	PollID    kallax.SchemaField
+	OptionID  kallax.SchemaField
+	Rank      kallax.SchemaField
+	Score     kallax.SchemaField
+}
+
+type schemaPollWebhook struct {
+	*kallax.BaseSchema
+	ID               kallax.SchemaField
+	CreatedAt        kallax.SchemaField
+	UpdatedAt        kallax.SchemaField
+	PollID           kallax.SchemaField
+	URL              kallax.SchemaField
+	Secret           kallax.SchemaField
+	OnPublish        kallax.SchemaField
+	OnClose          kallax.SchemaField
+	VoteThreshold    kallax.SchemaField
+	ThresholdReached kallax.SchemaField
+}
+
+type schemaPollWebhookDelivery struct {
+	*kallax.BaseSchema
+	ID            kallax.SchemaField
+	CreatedAt     kallax.SchemaField
+	UpdatedAt     kallax.SchemaField
+	WebhookID     kallax.SchemaField
+	Event         kallax.SchemaField
+	Payload       kallax.SchemaField
+	Status        kallax.SchemaField
+	Attempts      kallax.SchemaField
+	NextAttemptAt kallax.SchemaField
+	LastError     kallax.SchemaField
+	EventKey      kallax.SchemaField
 }
 
 type schemaSession struct {
@@ -2628,6 +5375,7 @@ type schemaSession struct {
 	UpdatedAt      kallax.SchemaField
 	UserID         kallax.SchemaField
 	RegisteredUser kallax.SchemaField
+	ExpiresAt      kallax.SchemaField
 }
 
 type schemaUser struct {
@@ -2658,14 +5406,26 @@ var Schema = &schema{
 			kallax.NewSchemaField("updated_at"),
 			kallax.NewSchemaField("name"),
 			kallax.NewSchemaField("owner"),
-			kallax.NewSchemaField("published"),
+			kallax.NewSchemaField("status"),
+			kallax.NewSchemaField("opens_at"),
+			kallax.NewSchemaField("closes_at"),
+			kallax.NewSchemaField("min_choices"),
+			kallax.NewSchemaField("max_choices"),
+			kallax.NewSchemaField("voting_method"),
+			kallax.NewSchemaField("max_score"),
 		),
-		ID:        kallax.NewSchemaField("id"),
-		CreatedAt: kallax.NewSchemaField("created_at"),
-		UpdatedAt: kallax.NewSchemaField("updated_at"),
-		Name:      kallax.NewSchemaField("name"),
-		Owner:     kallax.NewSchemaField("owner"),
-		Published: kallax.NewSchemaField("published"),
+		ID:           kallax.NewSchemaField("id"),
+		CreatedAt:    kallax.NewSchemaField("created_at"),
+		UpdatedAt:    kallax.NewSchemaField("updated_at"),
+		Name:         kallax.NewSchemaField("name"),
+		Owner:        kallax.NewSchemaField("owner"),
+		Status:       kallax.NewSchemaField("status"),
+		OpensAt:      kallax.NewSchemaField("opens_at"),
+		ClosesAt:     kallax.NewSchemaField("closes_at"),
+		MinChoices:   kallax.NewSchemaField("min_choices"),
+		MaxChoices:   kallax.NewSchemaField("max_choices"),
+		VotingMethod: kallax.NewSchemaField("voting_method"),
+		MaxScore:     kallax.NewSchemaField("max_score"),
 	},
 	PollOption: &schemaPollOption{
 		BaseSchema: kallax.NewBaseSchema(
@@ -2682,10 +5442,46 @@ var Schema = &schema{
 			kallax.NewSchemaField("id"),
 			kallax.NewSchemaField("poll_id"),
 			kallax.NewSchemaField("content"),
+			kallax.NewSchemaField("position"),
+		),
+		ID:       kallax.NewSchemaField("id"),
+		OwnerFK:  kallax.NewSchemaField("poll_id"),
+		Content:  kallax.NewSchemaField("content"),
+		Position: kallax.NewSchemaField("position"),
+	},
+	PollOutbox: &schemaPollOutbox{
+		BaseSchema: kallax.NewBaseSchema(
+			"poll_outbox",
+			"__polloutbox",
+			kallax.NewSchemaField("id"),
+			kallax.ForeignKeys{},
+			func() kallax.Record {
+				return new(PollOutbox)
+			},
+			false,
+			kallax.NewSchemaField("id"),
+			kallax.NewSchemaField("created_at"),
+			kallax.NewSchemaField("updated_at"),
+			kallax.NewSchemaField("event"),
+			kallax.NewSchemaField("payload"),
+			kallax.NewSchemaField("published"),
+			kallax.NewSchemaField("published_at"),
+			kallax.NewSchemaField("attempts"),
+			kallax.NewSchemaField("next_attempt_at"),
+			kallax.NewSchemaField("last_error"),
+			kallax.NewSchemaField("dead"),
 		),
-		ID:      kallax.NewSchemaField("id"),
-		OwnerFK: kallax.NewSchemaField("poll_id"),
-		Content: kallax.NewSchemaField("content"),
+		ID:            kallax.NewSchemaField("id"),
+		CreatedAt:     kallax.NewSchemaField("created_at"),
+		UpdatedAt:     kallax.NewSchemaField("updated_at"),
+		Event:         kallax.NewSchemaField("event"),
+		Payload:       kallax.NewSchemaField("payload"),
+		Published:     kallax.NewSchemaField("published"),
+		PublishedAt:   kallax.NewSchemaField("published_at"),
+		Attempts:      kallax.NewSchemaField("attempts"),
+		NextAttemptAt: kallax.NewSchemaField("next_attempt_at"),
+		LastError:     kallax.NewSchemaField("last_error"),
+		Dead:          kallax.NewSchemaField("dead"),
 	},
 	PollVote: &schemaPollVote{
 		BaseSchema: kallax.NewBaseSchema(
@@ -2703,6 +5499,7 @@ var Schema = &schema{
 			kallax.NewSchemaField("poll_id"),
 			kallax.NewSchemaField("user_id"),
 			kallax.NewSchemaField("chosen_option"),
+			kallax.NewSchemaField("poll_option_id"),
 		),
 		ID:           kallax.NewSchemaField("id"),
 		CreatedAt:    kallax.NewSchemaField("created_at"),
@@ -2710,6 +5507,129 @@ var Schema = &schema{
 		PollID:       kallax.NewSchemaField("poll_id"),
 		UserID:       kallax.NewSchemaField("user_id"),
 		ChosenOption: kallax.NewSchemaField("chosen_option"),
+		OptionID:     kallax.NewSchemaField("poll_option_id"),
+	},
+	PollVoteAudit: &schemaPollVoteAudit{
+		BaseSchema: kallax.NewBaseSchema(
+			"poll_vote_audit",
+			"__pollvoteaudit",
+			kallax.NewSchemaField("id"),
+			kallax.ForeignKeys{},
+			func() kallax.Record {
+				return new(PollVoteAudit)
+			},
+			false,
+			kallax.NewSchemaField("id"),
+			kallax.NewSchemaField("created_at"),
+			kallax.NewSchemaField("updated_at"),
+			kallax.NewSchemaField("vote_id"),
+			kallax.NewSchemaField("poll_id"),
+			kallax.NewSchemaField("user_id"),
+			kallax.NewSchemaField("previous_option"),
+			kallax.NewSchemaField("action"),
+		),
+		ID:             kallax.NewSchemaField("id"),
+		CreatedAt:      kallax.NewSchemaField("created_at"),
+		UpdatedAt:      kallax.NewSchemaField("updated_at"),
+		VoteID:         kallax.NewSchemaField("vote_id"),
+		PollID:         kallax.NewSchemaField("poll_id"),
+		UserID:         kallax.NewSchemaField("user_id"),
+		PreviousOption: kallax.NewSchemaField("previous_option"),
+		Action:         kallax.NewSchemaField("action"),
+	},
+	PollVoteSelection: &schemaPollVoteSelection{
+		BaseSchema: kallax.NewBaseSchema(
+			"poll_vote_selection",
+			"__pollvoteselection",
+			kallax.NewSchemaField("id"),
+			kallax.ForeignKeys{},
+			func() kallax.Record {
+				return new(PollVoteSelection)
+			},
+			false,
+			kallax.NewSchemaField("id"),
+			kallax.NewSchemaField("created_at"),
+			kallax.NewSchemaField("updated_at"),
+			kallax.NewSchemaField("vote_id"),
+			kallax.NewSchemaField("poll_id"),
+			kallax.NewSchemaField("poll_option_id"),
+			kallax.NewSchemaField("rank"),
+			kallax.NewSchemaField("score"),
+		),
+		ID:        kallax.NewSchemaField("id"),
+		CreatedAt: kallax.NewSchemaField("created_at"),
+		UpdatedAt: kallax.NewSchemaField("updated_at"),
+		VoteID:    kallax.NewSchemaField("vote_id"),
+		PollID:    kallax.NewSchemaField("poll_id"),
+		OptionID:  kallax.NewSchemaField("poll_option_id"),
+		Rank:      kallax.NewSchemaField("rank"),
+		Score:     kallax.NewSchemaField("score"),
+	},
+	PollWebhook: &schemaPollWebhook{
+		BaseSchema: kallax.NewBaseSchema(
+			"poll_webhook",
+			"__pollwebhook",
+			kallax.NewSchemaField("id"),
+			kallax.ForeignKeys{},
+			func() kallax.Record {
+				return new(PollWebhook)
+			},
+			false,
+			kallax.NewSchemaField("id"),
+			kallax.NewSchemaField("created_at"),
+			kallax.NewSchemaField("updated_at"),
+			kallax.NewSchemaField("poll_id"),
+			kallax.NewSchemaField("url"),
+			kallax.NewSchemaField("secret"),
+			kallax.NewSchemaField("on_publish"),
+			kallax.NewSchemaField("on_close"),
+			kallax.NewSchemaField("vote_threshold"),
+			kallax.NewSchemaField("threshold_reached"),
+		),
+		ID:               kallax.NewSchemaField("id"),
+		CreatedAt:        kallax.NewSchemaField("created_at"),
+		UpdatedAt:        kallax.NewSchemaField("updated_at"),
+		PollID:           kallax.NewSchemaField("poll_id"),
+		URL:              kallax.NewSchemaField("url"),
+		Secret:           kallax.NewSchemaField("secret"),
+		OnPublish:        kallax.NewSchemaField("on_publish"),
+		OnClose:          kallax.NewSchemaField("on_close"),
+		VoteThreshold:    kallax.NewSchemaField("vote_threshold"),
+		ThresholdReached: kallax.NewSchemaField("threshold_reached"),
+	},
+	PollWebhookDelivery: &schemaPollWebhookDelivery{
+		BaseSchema: kallax.NewBaseSchema(
+			"poll_webhook_delivery",
+			"__pollwebhookdelivery",
+			kallax.NewSchemaField("id"),
+			kallax.ForeignKeys{},
+			func() kallax.Record {
+				return new(PollWebhookDelivery)
+			},
+			false,
+			kallax.NewSchemaField("id"),
+			kallax.NewSchemaField("created_at"),
+			kallax.NewSchemaField("updated_at"),
+			kallax.NewSchemaField("webhook_id"),
+			kallax.NewSchemaField("event"),
+			kallax.NewSchemaField("payload"),
+			kallax.NewSchemaField("status"),
+			kallax.NewSchemaField("attempts"),
+			kallax.NewSchemaField("next_attempt_at"),
+			kallax.NewSchemaField("last_error"),
+			kallax.NewSchemaField("event_key"),
+		),
+		ID:            kallax.NewSchemaField("id"),
+		CreatedAt:     kallax.NewSchemaField("created_at"),
+		UpdatedAt:     kallax.NewSchemaField("updated_at"),
+		WebhookID:     kallax.NewSchemaField("webhook_id"),
+		Event:         kallax.NewSchemaField("event"),
+		Payload:       kallax.NewSchemaField("payload"),
+		Status:        kallax.NewSchemaField("status"),
+		Attempts:      kallax.NewSchemaField("attempts"),
+		NextAttemptAt: kallax.NewSchemaField("next_attempt_at"),
+		LastError:     kallax.NewSchemaField("last_error"),
+		EventKey:      kallax.NewSchemaField("event_key"),
 	},
 	Session: &schemaSession{
 		BaseSchema: kallax.NewBaseSchema(
@@ -2726,12 +5646,14 @@ var Schema = &schema{
 			kallax.NewSchemaField("updated_at"),
 			kallax.NewSchemaField("user_id"),
 			kallax.NewSchemaField("registered_user"),
+			kallax.NewSchemaField("expires_at"),
 		),
 		ID:             kallax.NewSchemaField("id"),
 		CreatedAt:      kallax.NewSchemaField("created_at"),
 		UpdatedAt:      kallax.NewSchemaField("updated_at"),
 		UserID:         kallax.NewSchemaField("user_id"),
 		RegisteredUser: kallax.NewSchemaField("registered_user"),
+		ExpiresAt:      kallax.NewSchemaField("expires_at"),
 	},
 	User: &schemaUser{
 		BaseSchema: kallax.NewBaseSchema(
@@ -1,10 +1,13 @@
 package app
 
 import (
+	"fmt"
+	"time"
+
 	"gopkg.in/src-d/go-kallax.v1"
 )
 
-//go:generate kallax gen -e main.go -e persistence_user.go -e persistence_session.go -e bis.go -e persistence_poll.go -e persistence_pollvote.go
+//go:generate kallax gen -e main.go -e persistence_user.go -e persistence_session.go -e bis.go -e persistence_poll.go -e persistence_pollvote.go -e persistence_webhook.go -e persistence_outbox.go
 
 //User ...
 type User struct {
@@ -28,33 +31,350 @@ type Session struct {
 	ID             kallax.ULID `pk:""`
 	UserID         kallax.ULID
 	RegisteredUser bool
+	ExpiresAt      time.Time
+}
+
+//IsExpired ...
+func (s *Session) IsExpired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
 }
 
 //Poll ...
 type Poll struct {
 	kallax.Model
 	kallax.Timestamps
-	ID        kallax.ULID `pk:""`
-	Name      string
-	Options   []*PollOption
-	Owner     kallax.ULID
-	Published bool
+	ID           kallax.ULID `pk:""`
+	Name         string
+	Options      []*PollOption
+	Owner        kallax.ULID
+	Status       string
+	OpensAt      *time.Time
+	ClosesAt     *time.Time
+	MinChoices   int
+	MaxChoices   int
+	VotingMethod string
+	MaxScore     int
+}
+
+//Poll statuses.
+const (
+	PollDraft     = "draft"
+	PollScheduled = "scheduled"
+	PollOpen      = "open"
+	PollClosed    = "closed"
+	PollArchived  = "archived"
+)
+
+//PollTransitions lists the statuses each status can move to.
+var PollTransitions = map[string][]string{
+	PollDraft:     {PollScheduled, PollOpen, PollArchived},
+	PollScheduled: {PollDraft, PollOpen},
+	PollOpen:      {PollClosed},
+	PollClosed:    {PollOpen, PollArchived},
+	PollArchived:  {},
+}
+
+//CurrentStatus treats polls without status as drafts.
+func (p *Poll) CurrentStatus() string {
+	if p.Status == "" {
+		return PollDraft
+	}
+
+	return p.Status
+}
+
+//CanTransitionTo ...
+func (p *Poll) CanTransitionTo(status string) bool {
+	for _, allowed := range PollTransitions[p.CurrentStatus()] {
+		if allowed == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+//CheckTransition fails when the transition table doesn't allow the move.
+func (p *Poll) CheckTransition(status string) error {
+	if !p.CanTransitionTo(status) {
+		return ErrNotChangePoll(fmt.Sprintf("Can't move a poll from %s to %s.", p.CurrentStatus(), status))
+	}
+
+	return nil
+}
+
+//TransitionTo moves the poll to the status when the transition table allows it.
+func (p *Poll) TransitionTo(status string) error {
+	if err := p.CheckTransition(status); err != nil {
+		return err
+	}
+
+	p.Status = status
+	return nil
+}
+
+//CheckReopen fails unless the poll is closed. The transition table also lets drafts and
+//scheduled polls open, but they must go through publishing.
+//A poll whose closing time has passed would reject every vote, so it only reopens with a
+//new closing time, which must still be to come.
+func (p *Poll) CheckReopen(closesAt *time.Time, now time.Time) error {
+	if p.CurrentStatus() != PollClosed {
+		return ErrNotChangePoll(fmt.Sprintf("Can't reopen a poll that is %s.", p.CurrentStatus()))
+	}
+
+	if closesAt != nil {
+		if !now.Before(*closesAt) {
+			return ErrValidation("The new closing time must be in the future.")
+		}
+
+		return nil
+	}
+
+	if p.ClosesAt != nil && !now.Before(*p.ClosesAt) {
+		return ErrNotChangePoll("The closing time of the poll has passed. Give a new closesAt to reopen it.")
+	}
+
+	return nil
+}
+
+//IsEditable tells if name and options can still change. Only drafts can.
+func (p *Poll) IsEditable() bool {
+	return p.CurrentStatus() == PollDraft
+}
+
+//IsPublished tells if the poll has left the draft stage.
+func (p *Poll) IsPublished() bool {
+	return p.CurrentStatus() != PollDraft
+}
+
+//IsOpen tells if the poll accepts votes.
+func (p *Poll) IsOpen() bool {
+	return p.CurrentStatus() == PollOpen
+}
+
+//PublishStatus tells where publishing leads: polls with an opening time still to come are scheduled.
+func (p *Poll) PublishStatus(now time.Time) string {
+	if p.OpensAt != nil && now.Before(*p.OpensAt) {
+		return PollScheduled
+	}
+
+	return PollOpen
+}
+
+//AcceptsVotesAt tells if the poll is open and the moment falls inside its time window.
+func (p *Poll) AcceptsVotesAt(now time.Time) bool {
+	if !p.IsOpen() {
+		return false
+	}
+
+	if p.OpensAt != nil && now.Before(*p.OpensAt) {
+		return false
+	}
+
+	return p.ClosesAt == nil || now.Before(*p.ClosesAt)
+}
+
+//ChoiceRange tells how many options a vote must select. Polls without settings are single-choice.
+func (p *Poll) ChoiceRange() (int, int) {
+	min, max := p.MinChoices, p.MaxChoices
+	if min < 1 {
+		min = 1
+	}
+
+	if max < min {
+		max = min
+	}
+
+	return min, max
+}
+
+//Voting methods.
+const (
+	VotingPlurality = "plurality"
+	VotingRanked    = "ranked"
+	VotingApproval  = "approval"
+	VotingScore     = "score"
+	VotingBorda     = "borda"
+)
+
+//VotingMethods lists every voting method a poll can follow.
+var VotingMethods = []string{VotingPlurality, VotingRanked, VotingApproval, VotingScore, VotingBorda}
+
+//DefaultMaxScore is the highest score of score polls that don't set one.
+const DefaultMaxScore = 5
+
+//CurrentVotingMethod treats polls without voting method as plurality polls.
+func (p *Poll) CurrentVotingMethod() string {
+	if p.VotingMethod == "" {
+		return VotingPlurality
+	}
+
+	return p.VotingMethod
+}
+
+//BallotRange tells how many options a vote must select among the given number of options.
+//Only plurality polls are single-choice by default; the other methods let voters choose
+//every option unless the poll sets a maximum.
+func (p *Poll) BallotRange(optionCount int) (int, int) {
+	min, max := p.ChoiceRange()
+	if p.CurrentVotingMethod() != VotingPlurality && p.MaxChoices == 0 && optionCount > max {
+		max = optionCount
+	}
+
+	return min, max
+}
+
+//IsScored tells if voters give each option a score.
+func (p *Poll) IsScored() bool {
+	return p.CurrentVotingMethod() == VotingScore
+}
+
+//ScoreScale gives the highest score an option can get on score polls.
+func (p *Poll) ScoreScale() int {
+	if p.MaxScore < 1 {
+		return DefaultMaxScore
+	}
+
+	return p.MaxScore
 }
 
 // PollOption ...
 type PollOption struct {
 	kallax.Model
-	ID      kallax.ULID `pk:""`
-	Owner   *Poll       `fk:"poll_id,inverse"`
-	Content string
+	ID       kallax.ULID `pk:""`
+	Owner    *Poll       `fk:"poll_id,inverse"`
+	Content  string
+	Position int
 }
 
-//PollVote ...
+//PollVote is the vote of a user in a poll. OptionID references the first chosen option,
+//the only one on single-choice polls. ChosenOption keeps the contents of the chosen
+//options for reading; the selections are what counting relies on.
 type PollVote struct {
 	kallax.Model
 	kallax.Timestamps
 	ID           kallax.ULID `pk:""`
 	PollID       kallax.ULID
 	UserID       kallax.ULID
+	OptionID     *kallax.ULID `kallax:"poll_option_id"`
 	ChosenOption string
 }
+
+//Vote audit actions.
+const (
+	VoteChanged   = "changed"
+	VoteRetracted = "retracted"
+)
+
+//PollVoteSelection is one of the options chosen in a vote. Rank is the position of the
+//option in the order the voter chose them, starting at 1; ranked and Borda polls count on it.
+//Score is the score given to the option on score polls.
+type PollVoteSelection struct {
+	kallax.Model
+	kallax.Timestamps
+	ID       kallax.ULID `pk:""`
+	VoteID   kallax.ULID
+	PollID   kallax.ULID
+	OptionID kallax.ULID `kallax:"poll_option_id"`
+	Rank     int
+	Score    int
+}
+
+//PollVoteAudit keeps the option a user had chosen before changing or retracting the vote.
+type PollVoteAudit struct {
+	kallax.Model
+	kallax.Timestamps
+	ID             kallax.ULID `pk:""`
+	VoteID         kallax.ULID
+	PollID         kallax.ULID
+	UserID         kallax.ULID
+	PreviousOption string
+	Action         string
+}
+
+//PollWebhook notifies a URL chosen by the owner of the poll when the poll is published,
+//closes or reaches VoteThreshold votes. Payloads are signed with Secret.
+type PollWebhook struct {
+	kallax.Model
+	kallax.Timestamps
+	ID               kallax.ULID `pk:""`
+	PollID           kallax.ULID
+	URL              string `kallax:"url"`
+	Secret           string
+	OnPublish        bool
+	OnClose          bool
+	VoteThreshold    int
+	ThresholdReached bool
+}
+
+//Webhook triggers, as chosen when the webhook is created.
+const (
+	TriggerPublished = "published"
+	TriggerClosed    = "closed"
+	TriggerVotes     = "votes"
+)
+
+//WebhookTriggers lists the known triggers.
+var WebhookTriggers = []string{TriggerPublished, TriggerClosed, TriggerVotes}
+
+//Triggers lists the triggers the webhook is notified on.
+func (w *PollWebhook) Triggers() []string {
+	triggers := make([]string, 0, len(WebhookTriggers))
+	if w.OnPublish {
+		triggers = append(triggers, TriggerPublished)
+	}
+	if w.OnClose {
+		triggers = append(triggers, TriggerClosed)
+	}
+	if w.VoteThreshold > 0 {
+		triggers = append(triggers, TriggerVotes)
+	}
+
+	return triggers
+}
+
+//Webhook delivery statuses. Dead deliveries gave up after MaxWebhookAttempts and are kept
+//as the record of what was not delivered.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+//PollWebhookDelivery is one payload to be sent to a webhook, with how its attempts went.
+type PollWebhookDelivery struct {
+	kallax.Model
+	kallax.Timestamps
+	ID            kallax.ULID `pk:""`
+	WebhookID     kallax.ULID
+	Event         string
+	Payload       string
+	Status        string
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	EventKey      string
+}
+
+//PollOutbox is an event recorded in the same transaction as the change that caused it.
+//It stays unpublished until the relay hands it to the publisher. Attempts counts the times
+//the handlers failed on it, and it is not relayed again before NextAttemptAt. A dead record
+//is no longer relayed and is kept as the record of what was not handled.
+type PollOutbox struct {
+	kallax.Model
+	kallax.Timestamps
+	ID            kallax.ULID `pk:""`
+	Event         string
+	Payload       string
+	Published     bool
+	PublishedAt   *time.Time
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	Dead          bool
+}
+
+//IdempotencyKey is the same every time the record is relayed.
+func (r *PollOutbox) IdempotencyKey() string {
+	return r.Event + ":" + r.ID.String()
+}
@@ -0,0 +1,110 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chai2010/assert"
+)
+
+func TestPollWithoutStatusIsDraft(t *testing.T) {
+	poll := &Poll{}
+
+	assert.AssertEqual(t, PollDraft, poll.CurrentStatus())
+	assert.AssertTrue(t, poll.IsEditable())
+	assert.AssertFalse(t, poll.IsPublished())
+	assert.AssertFalse(t, poll.IsOpen())
+}
+
+func TestPollTransitions(t *testing.T) {
+	cases := []struct {
+		from, to string
+		allowed  bool
+	}{
+		{PollDraft, PollOpen, true},
+		{PollDraft, PollScheduled, true},
+		{PollDraft, PollClosed, false},
+		{PollScheduled, PollOpen, true},
+		{PollOpen, PollClosed, true},
+		{PollOpen, PollDraft, false},
+		{PollOpen, PollArchived, false},
+		{PollClosed, PollOpen, true},
+		{PollClosed, PollArchived, true},
+		{PollArchived, PollOpen, false},
+		{PollArchived, PollDraft, false},
+	}
+
+	for _, c := range cases {
+		poll := &Poll{Status: c.from}
+
+		err := poll.TransitionTo(c.to)
+
+		if c.allowed {
+			assert.AssertNil(t, err, c.from, c.to)
+			assert.AssertEqual(t, c.to, poll.Status)
+		} else {
+			assert.AssertEqual(t, CodePollNotChangeable, err.(CodedError).Code(), c.from, c.to)
+			assert.AssertEqual(t, c.from, poll.Status)
+		}
+	}
+}
+
+func TestPollAcceptsVotesInsideTimeWindow(t *testing.T) {
+	opensAt := time.Date(2018, 12, 10, 12, 0, 0, 0, time.UTC)
+	closesAt := opensAt.Add(time.Hour)
+	poll := &Poll{Status: PollOpen, OpensAt: &opensAt, ClosesAt: &closesAt}
+
+	assert.AssertFalse(t, poll.AcceptsVotesAt(opensAt.Add(-time.Second)))
+	assert.AssertTrue(t, poll.AcceptsVotesAt(opensAt))
+	assert.AssertTrue(t, poll.AcceptsVotesAt(closesAt.Add(-time.Second)))
+	assert.AssertFalse(t, poll.AcceptsVotesAt(closesAt))
+
+	poll.Status = PollClosed
+	assert.AssertFalse(t, poll.AcceptsVotesAt(opensAt))
+}
+
+func TestPollPublishStatus(t *testing.T) {
+	opensAt := time.Date(2018, 12, 10, 12, 0, 0, 0, time.UTC)
+	poll := &Poll{OpensAt: &opensAt}
+
+	assert.AssertEqual(t, PollScheduled, poll.PublishStatus(opensAt.Add(-time.Minute)))
+	assert.AssertEqual(t, PollOpen, poll.PublishStatus(opensAt))
+	assert.AssertEqual(t, PollOpen, (&Poll{}).PublishStatus(opensAt))
+}
+
+func TestPollChoiceRange(t *testing.T) {
+	min, max := (&Poll{}).ChoiceRange()
+	assert.AssertEqual(t, 1, min)
+	assert.AssertEqual(t, 1, max)
+
+	min, max = (&Poll{MinChoices: 2, MaxChoices: 4}).ChoiceRange()
+	assert.AssertEqual(t, 2, min)
+	assert.AssertEqual(t, 4, max)
+
+	min, max = (&Poll{MinChoices: 3}).ChoiceRange()
+	assert.AssertEqual(t, 3, min)
+	assert.AssertEqual(t, 3, max)
+}
+
+func TestPollBallotRange(t *testing.T) {
+	min, max := (&Poll{}).BallotRange(4)
+	assert.AssertEqual(t, 1, min)
+	assert.AssertEqual(t, 1, max)
+
+	min, max = (&Poll{VotingMethod: VotingRanked}).BallotRange(4)
+	assert.AssertEqual(t, 1, min)
+	assert.AssertEqual(t, 4, max)
+
+	min, max = (&Poll{VotingMethod: VotingApproval}).BallotRange(4)
+	assert.AssertEqual(t, 1, min)
+	assert.AssertEqual(t, 4, max)
+
+	min, max = (&Poll{VotingMethod: VotingRanked, MinChoices: 2, MaxChoices: 3}).BallotRange(4)
+	assert.AssertEqual(t, 2, min)
+	assert.AssertEqual(t, 3, max)
+}
+
+func TestPollScoreScale(t *testing.T) {
+	assert.AssertEqual(t, DefaultMaxScore, (&Poll{}).ScoreScale())
+	assert.AssertEqual(t, 10, (&Poll{MaxScore: 10}).ScoreScale())
+}
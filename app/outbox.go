@@ -0,0 +1,293 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+//outboxRelayBatch is how many records the relay takes at a time.
+const outboxRelayBatch = 100
+
+//Outbox relay limits. The wait before an event whose handlers failed is handed again starts
+//at OutboxBackoff and doubles after each failed attempt.
+const (
+	MaxOutboxAttempts = 10
+	OutboxBackoff     = 5 * time.Second
+)
+
+//OutboxBackoffAfter tells how long to wait for the next attempt after the given number
+//of failed attempts.
+func OutboxBackoffAfter(attempts int) time.Duration {
+	if attempts < 1 {
+		return OutboxBackoff
+	}
+
+	return OutboxBackoff << uint(attempts-1)
+}
+
+//outboxEvent is an event that can be read back from the outbox.
+type outboxEvent interface {
+	withKey(key string) Event
+}
+
+func (e PollCreatedEvent) withKey(key string) Event {
+	e.Key = key
+	return e
+}
+
+func (e PollScheduledEvent) withKey(key string) Event {
+	e.Key = key
+	return e
+}
+
+func (e PollPublishedEvent) withKey(key string) Event {
+	e.Key = key
+	return e
+}
+
+func (e PollClosedEvent) withKey(key string) Event {
+	e.Key = key
+	return e
+}
+
+func (e VoteCastEvent) withKey(key string) Event {
+	e.Key = key
+	return e
+}
+
+func (e VoteChangedEvent) withKey(key string) Event {
+	e.Key = key
+	return e
+}
+
+func (e VoteRetractedEvent) withKey(key string) Event {
+	e.Key = key
+	return e
+}
+
+func (e OptionAddedEvent) withKey(key string) Event {
+	e.Key = key
+	return e
+}
+
+func (e OptionRemovedEvent) withKey(key string) Event {
+	e.Key = key
+	return e
+}
+
+func (e UserRegisteredEvent) withKey(key string) Event {
+	e.Key = key
+	return e
+}
+
+//outboxEvents makes an empty event of each name recorded in the outbox, to decode it into.
+var outboxEvents = map[string]func() outboxEvent{
+	EventPollCreated:    func() outboxEvent { return &PollCreatedEvent{} },
+	EventPollScheduled:  func() outboxEvent { return &PollScheduledEvent{} },
+	EventPollPublished:  func() outboxEvent { return &PollPublishedEvent{} },
+	EventPollClosed:     func() outboxEvent { return &PollClosedEvent{} },
+	EventVoteCast:       func() outboxEvent { return &VoteCastEvent{} },
+	EventVoteChanged:    func() outboxEvent { return &VoteChangedEvent{} },
+	EventVoteRetracted:  func() outboxEvent { return &VoteRetractedEvent{} },
+	EventOptionAdded:    func() outboxEvent { return &OptionAddedEvent{} },
+	EventOptionRemoved:  func() outboxEvent { return &OptionRemovedEvent{} },
+	EventUserRegistered: func() outboxEvent { return &UserRegisteredEvent{} },
+}
+
+//DecodeOutboxEvent reads the event of the record back, keyed by the record.
+func DecodeOutboxEvent(record *PollOutbox) (Event, error) {
+	newEvent, known := outboxEvents[record.Event]
+	if !known {
+		return nil, fmt.Errorf("Unknown event %s in the outbox", record.Event)
+	}
+
+	event := newEvent()
+	if err := json.Unmarshal([]byte(record.Payload), event); err != nil {
+		return nil, err
+	}
+
+	return event.withKey(record.IdempotencyKey()), nil
+}
+
+//OutboxMetrics follows the relay. Lag is the age of the oldest event that was waiting in
+//the outbox when the relay last ran, whether its next attempt was due or not.
+type OutboxMetrics struct {
+	lock      sync.Mutex
+	relayed   int64
+	failures  int64
+	dead      int64
+	pending   int64
+	lag       time.Duration
+	lastRunAt time.Time
+}
+
+//Snapshot ...
+func (m *OutboxMetrics) Snapshot() OutboxMetricsView {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return OutboxMetricsView{
+		Relayed:    m.relayed,
+		Failures:   m.failures,
+		Dead:       m.dead,
+		Pending:    m.pending,
+		LagSeconds: m.lag.Seconds(),
+		LastRunAt:  m.lastRunAt,
+	}
+}
+
+func (m *OutboxMetrics) observe(pending int64, oldest *PollOutbox, now time.Time) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.pending = pending
+	m.lastRunAt = now
+	m.lag = 0
+	if oldest != nil {
+		m.lag = now.Sub(oldest.CreatedAt)
+	}
+}
+
+func (m *OutboxMetrics) relay() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.relayed++
+	m.pending--
+}
+
+func (m *OutboxMetrics) fail() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.failures++
+}
+
+func (m *OutboxMetrics) bury() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.dead++
+	m.pending--
+}
+
+//RelayOutbox hands the pending events of the outbox to the publisher, the oldest first,
+//marking each one published once the publisher handled it. An event is handed again when
+//the publisher fails or it can't be marked, so handlers should skip the keys they already
+//handled. A failed event waits OutboxBackoffAfter its attempts and is left dead after
+//MaxOutboxAttempts; a record that can't be decoded is left dead at once.
+func RelayOutbox(outboxHandler OutboxHandler, publisher EventPublisher, metrics *OutboxMetrics,
+	now time.Time) (int, error) {
+	pending, err := outboxHandler.CountPendingEvents()
+	if err != nil {
+		metrics.fail()
+		return 0, err
+	}
+
+	oldest, err := outboxHandler.FindOldestPendingEvent()
+	if err != nil {
+		metrics.fail()
+		return 0, err
+	}
+
+	metrics.observe(pending, oldest, now)
+
+	records, err := outboxHandler.FindPendingEvents(now, outboxRelayBatch)
+	if err != nil {
+		metrics.fail()
+		return 0, err
+	}
+
+	relayed := 0
+	for _, record := range records {
+		event, err := DecodeOutboxEvent(record)
+		if err != nil {
+			log.Println("Unable to decode outbox record", record.ID, err)
+			if err := buryOutboxRecord(outboxHandler, metrics, record, err); err != nil {
+				return relayed, err
+			}
+			continue
+		}
+
+		if err := publisher.Publish(event); err != nil {
+			if err := retryOutboxRecord(outboxHandler, metrics, record, err, now); err != nil {
+				return relayed, err
+			}
+			continue
+		}
+
+		if err := outboxHandler.MarkPublished(record.ID, now); err != nil {
+			metrics.fail()
+			return relayed, err
+		}
+
+		metrics.relay()
+		relayed++
+	}
+
+	return relayed, nil
+}
+
+//retryOutboxRecord leaves the record pending for a later attempt, or dead when it ran out of them.
+func retryOutboxRecord(outboxHandler OutboxHandler, metrics *OutboxMetrics, record *PollOutbox, cause error,
+	now time.Time) error {
+	attempts := record.Attempts + 1
+	if attempts >= MaxOutboxAttempts {
+		log.Println("Outbox record dead after", attempts, "attempts:", record.ID, cause)
+		return buryOutboxRecord(outboxHandler, metrics, record, cause)
+	}
+
+	metrics.fail()
+	if err := outboxHandler.MarkFailed(record.ID, cause.Error(), now.Add(OutboxBackoffAfter(attempts))); err != nil {
+		metrics.fail()
+		return err
+	}
+
+	return nil
+}
+
+func buryOutboxRecord(outboxHandler OutboxHandler, metrics *OutboxMetrics, record *PollOutbox, cause error) error {
+	metrics.fail()
+	if err := outboxHandler.MarkDead(record.ID, cause.Error()); err != nil {
+		metrics.fail()
+		return err
+	}
+
+	metrics.bury()
+	return nil
+}
+
+//relayOutboxBacklog keeps relaying while full batches come out of the outbox.
+func relayOutboxBacklog(outboxHandler OutboxHandler, publisher EventPublisher, metrics *OutboxMetrics,
+	clock Clock) {
+	for {
+		relayed, err := RelayOutbox(outboxHandler, publisher, metrics, clock())
+		if err != nil {
+			log.Println("Unable to relay the outbox", err)
+			return
+		}
+
+		if relayed < outboxRelayBatch {
+			return
+		}
+	}
+}
+
+//RelayEvents runs the outbox relay every interval until done is closed.
+func RelayEvents(outboxHandler OutboxHandler, publisher EventPublisher, metrics *OutboxMetrics, clock Clock,
+	interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			relayOutboxBacklog(outboxHandler, publisher, metrics, clock)
+		}
+	}
+}
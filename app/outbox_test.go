@@ -0,0 +1,291 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/chai2010/assert"
+
+	"gopkg.in/src-d/go-kallax.v1"
+)
+
+func newOutboxRecord(t *testing.T, event Event, createdAt time.Time) *PollOutbox {
+	payload, err := json.Marshal(event)
+	assert.AssertNil(t, err)
+
+	record := &PollOutbox{ID: kallax.NewULID(), Event: event.EventName(), Payload: string(payload)}
+	record.CreatedAt = createdAt
+	return record
+}
+
+func createOutboxHandlerMock(records []*PollOutbox) *OutboxHandlerMock {
+	return &OutboxHandlerMock{
+		CountPendingEventsFunc: func() (int64, error) {
+			return int64(len(records)), nil
+		},
+		FindOldestPendingEventFunc: func() (*PollOutbox, error) {
+			if len(records) == 0 {
+				return nil, nil
+			}
+			return records[0], nil
+		},
+		FindPendingEventsFunc: func(now time.Time, limit uint64) ([]*PollOutbox, error) {
+			return records, nil
+		},
+		MarkPublishedFunc: func(ID kallax.ULID, at time.Time) error {
+			return nil
+		},
+		MarkFailedFunc: func(ID kallax.ULID, reason string, nextAttemptAt time.Time) error {
+			return nil
+		},
+		MarkDeadFunc: func(ID kallax.ULID, reason string) error {
+			return nil
+		},
+	}
+}
+
+func createEventPublisherMock() *EventPublisherMock {
+	return &EventPublisherMock{
+		PublishFunc: func(event Event) error {
+			return nil
+		},
+	}
+}
+
+func TestDecodeOutboxEvent(t *testing.T) {
+	at := time.Date(2018, 12, 20, 12, 0, 0, 0, time.UTC)
+	vote := VoteCastEvent{PollID: kallax.NewULID(), VoteID: kallax.NewULID(), OptionIDs: []kallax.ULID{kallax.NewULID()}, At: at}
+	record := newOutboxRecord(t, vote, at)
+
+	event, err := DecodeOutboxEvent(record)
+
+	assert.AssertNil(t, err)
+	vote.Key = EventVoteCast + ":" + record.ID.String()
+	assert.AssertEqual(t, vote, event)
+	assert.AssertEqual(t, vote.Key, KeyOf(event))
+}
+
+func TestDecodeOutboxEventOfChangedAndRetractedVotes(t *testing.T) {
+	at := time.Date(2018, 12, 20, 12, 0, 0, 0, time.UTC)
+	changed := VoteChangedEvent{PollID: kallax.NewULID(), UserID: kallax.NewULID(), OptionIDs: []kallax.ULID{kallax.NewULID()}, At: at}
+	retracted := VoteRetractedEvent{PollID: kallax.NewULID(), UserID: kallax.NewULID(), At: at}
+
+	changedEvent, err := DecodeOutboxEvent(newOutboxRecord(t, changed, at))
+	assert.AssertNil(t, err)
+	retractedEvent, err := DecodeOutboxEvent(newOutboxRecord(t, retracted, at))
+	assert.AssertNil(t, err)
+
+	assert.AssertEqual(t, changed.OptionIDs, changedEvent.(VoteChangedEvent).OptionIDs)
+	assert.AssertEqual(t, retracted.UserID, retractedEvent.(VoteRetractedEvent).UserID)
+}
+
+func TestDecodeOutboxEventUnknown(t *testing.T) {
+	_, err := DecodeOutboxEvent(&PollOutbox{Event: "poll.archived", Payload: "{}"})
+
+	assert.AssertEqual(t, "Unknown event poll.archived in the outbox", err.Error())
+}
+
+func TestRelayOutboxPublishesAndMarksEachEvent(t *testing.T) {
+	now := time.Date(2018, 12, 20, 12, 0, 0, 0, time.UTC)
+	first := newOutboxRecord(t, PollPublishedEvent{PollID: kallax.NewULID(), Status: PollOpen}, now.Add(-5*time.Second))
+	second := newOutboxRecord(t, PollClosedEvent{PollID: kallax.NewULID(), At: now}, now.Add(-time.Second))
+	outboxMock := createOutboxHandlerMock([]*PollOutbox{first, second})
+	publisherMock := createEventPublisherMock()
+	metrics := &OutboxMetrics{}
+
+	relayed, err := RelayOutbox(outboxMock, publisherMock, metrics, now)
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, 2, relayed)
+	assert.AssertEqual(t, 2, len(publisherMock.PublishCalls()))
+	assert.AssertEqual(t, EventPollPublished+":"+first.ID.String(), KeyOf(publisherMock.PublishCalls()[0].Event))
+	assert.AssertEqual(t, EventPollClosed+":"+second.ID.String(), KeyOf(publisherMock.PublishCalls()[1].Event))
+	assert.AssertEqual(t, first.ID, outboxMock.MarkPublishedCalls()[0].ID)
+	assert.AssertEqual(t, second.ID, outboxMock.MarkPublishedCalls()[1].ID)
+	assert.AssertEqual(t, now, outboxMock.MarkPublishedCalls()[1].At)
+
+	snapshot := metrics.Snapshot()
+	assert.AssertEqual(t, int64(2), snapshot.Relayed)
+	assert.AssertEqual(t, int64(0), snapshot.Pending)
+	assert.AssertEqual(t, float64(5), snapshot.LagSeconds)
+	assert.AssertEqual(t, now, snapshot.LastRunAt)
+}
+
+func TestRelayOutboxStopsWhenEventCantBeMarked(t *testing.T) {
+	now := time.Date(2018, 12, 20, 12, 0, 0, 0, time.UTC)
+	first := newOutboxRecord(t, PollCreatedEvent{PollID: kallax.NewULID()}, now)
+	second := newOutboxRecord(t, PollCreatedEvent{PollID: kallax.NewULID()}, now)
+	outboxMock := createOutboxHandlerMock([]*PollOutbox{first, second})
+	outboxMock.MarkPublishedFunc = func(ID kallax.ULID, at time.Time) error {
+		return fmt.Errorf("Connection lost")
+	}
+	publisherMock := createEventPublisherMock()
+	metrics := &OutboxMetrics{}
+
+	relayed, err := RelayOutbox(outboxMock, publisherMock, metrics, now)
+
+	assert.AssertEqual(t, "Connection lost", err.Error())
+	assert.AssertEqual(t, 0, relayed)
+	assert.AssertEqual(t, 1, len(publisherMock.PublishCalls()))
+	assert.AssertEqual(t, int64(1), metrics.Snapshot().Failures)
+	assert.AssertEqual(t, int64(2), metrics.Snapshot().Pending)
+}
+
+func TestRelayOutboxLeavesUndecodableRecordsDead(t *testing.T) {
+	now := time.Date(2018, 12, 20, 12, 0, 0, 0, time.UTC)
+	broken := &PollOutbox{ID: kallax.NewULID(), Event: EventVoteCast, Payload: "{"}
+	vote := newOutboxRecord(t, VoteCastEvent{PollID: kallax.NewULID()}, now)
+	outboxMock := createOutboxHandlerMock([]*PollOutbox{broken, vote})
+	publisherMock := createEventPublisherMock()
+	metrics := &OutboxMetrics{}
+
+	relayed, err := RelayOutbox(outboxMock, publisherMock, metrics, now)
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, 1, relayed)
+	assert.AssertEqual(t, 1, len(publisherMock.PublishCalls()))
+	assert.AssertEqual(t, 1, len(outboxMock.MarkDeadCalls()))
+	assert.AssertEqual(t, broken.ID, outboxMock.MarkDeadCalls()[0].ID)
+	assert.AssertEqual(t, "unexpected end of JSON input", outboxMock.MarkDeadCalls()[0].Reason)
+	assert.AssertEqual(t, 1, len(outboxMock.MarkPublishedCalls()))
+	assert.AssertEqual(t, vote.ID, outboxMock.MarkPublishedCalls()[0].ID)
+
+	snapshot := metrics.Snapshot()
+	assert.AssertEqual(t, int64(1), snapshot.Failures)
+	assert.AssertEqual(t, int64(1), snapshot.Dead)
+	assert.AssertEqual(t, int64(0), snapshot.Pending)
+}
+
+func TestRelayOutboxLeavesEventsPendingWhenHandlersFail(t *testing.T) {
+	now := time.Date(2018, 12, 20, 12, 0, 0, 0, time.UTC)
+	failing := newOutboxRecord(t, PollPublishedEvent{PollID: kallax.NewULID()}, now)
+	failing.Attempts = 2
+	handled := newOutboxRecord(t, PollClosedEvent{PollID: kallax.NewULID()}, now)
+	outboxMock := createOutboxHandlerMock([]*PollOutbox{failing, handled})
+	publisherMock := &EventPublisherMock{
+		PublishFunc: func(event Event) error {
+			if event.EventName() == EventPollPublished {
+				return fmt.Errorf("Connection lost")
+			}
+			return nil
+		},
+	}
+	metrics := &OutboxMetrics{}
+
+	relayed, err := RelayOutbox(outboxMock, publisherMock, metrics, now)
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, 1, relayed)
+	assert.AssertEqual(t, 1, len(outboxMock.MarkFailedCalls()))
+	failed := outboxMock.MarkFailedCalls()[0]
+	assert.AssertEqual(t, failing.ID, failed.ID)
+	assert.AssertEqual(t, "Connection lost", failed.Reason)
+	assert.AssertEqual(t, now.Add(4*OutboxBackoff), failed.NextAttemptAt)
+	assert.AssertEqual(t, 0, len(outboxMock.MarkDeadCalls()))
+	assert.AssertEqual(t, 1, len(outboxMock.MarkPublishedCalls()))
+	assert.AssertEqual(t, handled.ID, outboxMock.MarkPublishedCalls()[0].ID)
+	assert.AssertEqual(t, int64(1), metrics.Snapshot().Failures)
+	assert.AssertEqual(t, int64(1), metrics.Snapshot().Pending)
+}
+
+func TestRelayOutboxLeavesEventsDeadAfterTheLastAttempt(t *testing.T) {
+	now := time.Date(2018, 12, 20, 12, 0, 0, 0, time.UTC)
+	record := newOutboxRecord(t, PollPublishedEvent{PollID: kallax.NewULID()}, now)
+	record.Attempts = MaxOutboxAttempts - 1
+	outboxMock := createOutboxHandlerMock([]*PollOutbox{record})
+	publisherMock := &EventPublisherMock{
+		PublishFunc: func(event Event) error {
+			return fmt.Errorf("Connection lost")
+		},
+	}
+	metrics := &OutboxMetrics{}
+
+	relayed, err := RelayOutbox(outboxMock, publisherMock, metrics, now)
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, 0, relayed)
+	assert.AssertEqual(t, 0, len(outboxMock.MarkFailedCalls()))
+	assert.AssertEqual(t, record.ID, outboxMock.MarkDeadCalls()[0].ID)
+	assert.AssertEqual(t, "Connection lost", outboxMock.MarkDeadCalls()[0].Reason)
+	assert.AssertEqual(t, int64(1), metrics.Snapshot().Dead)
+}
+
+func TestRelayOutboxStopsWhenFailureCantBeMarked(t *testing.T) {
+	now := time.Date(2018, 12, 20, 12, 0, 0, 0, time.UTC)
+	first := newOutboxRecord(t, PollCreatedEvent{PollID: kallax.NewULID()}, now)
+	second := newOutboxRecord(t, PollCreatedEvent{PollID: kallax.NewULID()}, now)
+	outboxMock := createOutboxHandlerMock([]*PollOutbox{first, second})
+	outboxMock.MarkFailedFunc = func(ID kallax.ULID, reason string, nextAttemptAt time.Time) error {
+		return fmt.Errorf("Disk full")
+	}
+	publisherMock := &EventPublisherMock{
+		PublishFunc: func(event Event) error {
+			return fmt.Errorf("Connection lost")
+		},
+	}
+
+	relayed, err := RelayOutbox(outboxMock, publisherMock, &OutboxMetrics{}, now)
+
+	assert.AssertEqual(t, "Disk full", err.Error())
+	assert.AssertEqual(t, 0, relayed)
+	assert.AssertEqual(t, 1, len(publisherMock.PublishCalls()))
+}
+
+func TestOutboxBackoffAfter(t *testing.T) {
+	assert.AssertEqual(t, OutboxBackoff, OutboxBackoffAfter(0))
+	assert.AssertEqual(t, OutboxBackoff, OutboxBackoffAfter(1))
+	assert.AssertEqual(t, 8*OutboxBackoff, OutboxBackoffAfter(4))
+}
+
+func TestRelayOutboxWithNothingPending(t *testing.T) {
+	now := time.Date(2018, 12, 20, 12, 0, 0, 0, time.UTC)
+	metrics := &OutboxMetrics{}
+
+	relayed, err := RelayOutbox(createOutboxHandlerMock(nil), createEventPublisherMock(), metrics, now)
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, 0, relayed)
+	assert.AssertEqual(t, float64(0), metrics.Snapshot().LagSeconds)
+}
+
+func TestRelayOutboxLagCountsEventsWaitingForTheirNextAttempt(t *testing.T) {
+	now := time.Date(2018, 12, 20, 12, 0, 0, 0, time.UTC)
+	waiting := newOutboxRecord(t, PollClosedEvent{PollID: kallax.NewULID(), At: now}, now.Add(-30*time.Second))
+	waiting.NextAttemptAt = now.Add(time.Minute)
+	outboxMock := createOutboxHandlerMock(nil)
+	outboxMock.CountPendingEventsFunc = func() (int64, error) {
+		return 1, nil
+	}
+	outboxMock.FindOldestPendingEventFunc = func() (*PollOutbox, error) {
+		return waiting, nil
+	}
+	metrics := &OutboxMetrics{}
+
+	relayed, err := RelayOutbox(outboxMock, createEventPublisherMock(), metrics, now)
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, 0, relayed)
+	assert.AssertEqual(t, int64(1), metrics.Snapshot().Pending)
+	assert.AssertEqual(t, float64(30), metrics.Snapshot().LagSeconds)
+}
+
+func TestDecodeOutboxEventOfUsersAndOptions(t *testing.T) {
+	at := time.Date(2018, 12, 20, 12, 0, 0, 0, time.UTC)
+	registered := UserRegisteredEvent{UserID: kallax.NewULID(), Login: "phineas@disney.com", At: at}
+	added := OptionAddedEvent{PollID: kallax.NewULID(), OptionID: kallax.NewULID(), Content: "Pizza", At: at}
+	removed := OptionRemovedEvent{PollID: kallax.NewULID(), OptionID: kallax.NewULID(), At: at}
+
+	registeredEvent, err := DecodeOutboxEvent(newOutboxRecord(t, registered, at))
+	assert.AssertNil(t, err)
+	addedEvent, err := DecodeOutboxEvent(newOutboxRecord(t, added, at))
+	assert.AssertNil(t, err)
+	removedEvent, err := DecodeOutboxEvent(newOutboxRecord(t, removed, at))
+	assert.AssertNil(t, err)
+
+	assert.AssertEqual(t, registered.Login, registeredEvent.(UserRegisteredEvent).Login)
+	assert.AssertEqual(t, added.Content, addedEvent.(OptionAddedEvent).Content)
+	assert.AssertEqual(t, removed.OptionID, removedEvent.(OptionRemovedEvent).OptionID)
+	assert.AssertTrue(t, KeyOf(removedEvent) != "")
+}
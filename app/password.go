@@ -0,0 +1,198 @@
+package app
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const passwordAlgorithmSeparator = "$"
+
+//PasswordHasher ...
+type PasswordHasher interface {
+	Algorithm() string
+	Hash(password string) (string, error)
+	Verify(hashed, password string) bool
+}
+
+//DefaultPasswordHasher is used to hash every new password.
+var DefaultPasswordHasher PasswordHasher = BcryptHasher{Cost: bcrypt.DefaultCost}
+
+//KnownPasswordHashers are the algorithms accepted when checking a stored password.
+var KnownPasswordHashers = []PasswordHasher{
+	BcryptHasher{Cost: bcrypt.DefaultCost},
+	NewArgon2idHasher(),
+	MD5Hasher{},
+}
+
+//EncodePassword hashes the password and prefixes the result with the algorithm name.
+func EncodePassword(hasher PasswordHasher, password string) (string, error) {
+	hashed, err := hasher.Hash(password)
+	if err != nil {
+		return "", err
+	}
+
+	return hasher.Algorithm() + passwordAlgorithmSeparator + hashed, nil
+}
+
+//HasherForEncoded finds the hasher that produced the encoded password.
+//Passwords stored without prefix are legacy MD5 digests.
+func HasherForEncoded(encoded string) (PasswordHasher, string) {
+	for _, hasher := range KnownPasswordHashers {
+		prefix := hasher.Algorithm() + passwordAlgorithmSeparator
+		if strings.HasPrefix(encoded, prefix) {
+			return hasher, strings.TrimPrefix(encoded, prefix)
+		}
+	}
+
+	if isLegacyMD5(encoded) {
+		return MD5Hasher{}, encoded
+	}
+
+	return nil, encoded
+}
+
+//VerifyPassword checks the password against the encoded one. It also tells if
+//the encoded password was made with an algorithm other than the preferred.
+func VerifyPassword(preferred PasswordHasher, encoded, password string) (valid bool, outdated bool) {
+	hasher, hashed := HasherForEncoded(encoded)
+	if hasher == nil || hashed == "" {
+		return false, false
+	}
+
+	if !hasher.Verify(hashed, password) {
+		return false, false
+	}
+
+	return true, hasher.Algorithm() != preferred.Algorithm()
+}
+
+func isLegacyMD5(encoded string) bool {
+	if len(encoded) != md5.Size*2 {
+		return false
+	}
+
+	_, err := hex.DecodeString(encoded)
+	return err == nil
+}
+
+//BcryptHasher ...
+type BcryptHasher struct {
+	Cost int
+}
+
+//Algorithm ...
+func (h BcryptHasher) Algorithm() string {
+	return "bcrypt"
+}
+
+//Hash ...
+func (h BcryptHasher) Hash(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), h.Cost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hashed), nil
+}
+
+//Verify ...
+func (h BcryptHasher) Verify(hashed, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hashed), []byte(password)) == nil
+}
+
+//Argon2idHasher ...
+type Argon2idHasher struct {
+	Time    uint32
+	Memory  uint32
+	Threads uint8
+	KeyLen  uint32
+	SaltLen uint32
+}
+
+//NewArgon2idHasher creates a hasher with the parameters recommended by the argon2 package.
+func NewArgon2idHasher() Argon2idHasher {
+	return Argon2idHasher{
+		Time:    1,
+		Memory:  64 * 1024,
+		Threads: 4,
+		KeyLen:  32,
+		SaltLen: 16,
+	}
+}
+
+//Algorithm ...
+func (h Argon2idHasher) Algorithm() string {
+	return "argon2id"
+}
+
+//Hash returns "m=<memory>,t=<time>,p=<threads>$<salt>$<key>" so the parameters
+//used travel along with the hash.
+func (h Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.Time, h.Memory, h.Threads, h.KeyLen)
+
+	return fmt.Sprintf("m=%d,t=%d,p=%d$%s$%s", h.Memory, h.Time, h.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+//Verify ...
+func (h Argon2idHasher) Verify(hashed, password string) bool {
+	parts := strings.Split(hashed, "$")
+	if len(parts) != 3 {
+		return false
+	}
+
+	var memory, time uint32
+	var threads uint8
+	if _, err := fmt.Sscanf(parts[0], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+		return false
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return false
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+
+	candidate := argon2.IDKey([]byte(password), salt, time, memory, threads, uint32(len(key)))
+
+	return subtle.ConstantTimeCompare(key, candidate) == 1
+}
+
+//MD5Hasher is only kept to check passwords stored before the hashers existed.
+type MD5Hasher struct{}
+
+//Algorithm ...
+func (h MD5Hasher) Algorithm() string {
+	return "md5"
+}
+
+//Hash ...
+func (h MD5Hasher) Hash(password string) (string, error) {
+	hasher := md5.New()
+	hasher.Write([]byte(password))
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+//Verify ...
+func (h MD5Hasher) Verify(hashed, password string) bool {
+	candidate, _ := h.Hash(password)
+	return subtle.ConstantTimeCompare([]byte(hashed), []byte(candidate)) == 1
+}
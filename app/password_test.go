@@ -0,0 +1,62 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/chai2010/assert"
+)
+
+func TestEncodeAndVerifyPasswordWithBcrypt(t *testing.T) {
+	hasher := BcryptHasher{Cost: 4}
+
+	encoded, err := EncodePassword(hasher, "summer")
+
+	assert.AssertNil(t, err)
+	assert.AssertMatchString(t, "^bcrypt\\$", encoded)
+
+	found, hashed := HasherForEncoded(encoded)
+	assert.AssertEqual(t, "bcrypt", found.Algorithm())
+	assert.AssertTrue(t, found.Verify(hashed, "summer"))
+	assert.AssertFalse(t, found.Verify(hashed, "winter"))
+}
+
+func TestEncodeAndVerifyPasswordWithArgon2id(t *testing.T) {
+	hasher := NewArgon2idHasher()
+
+	encoded, err := EncodePassword(hasher, "summer")
+
+	assert.AssertNil(t, err)
+	assert.AssertMatchString(t, "^argon2id\\$m=65536,t=1,p=4\\$", encoded)
+
+	valid, outdated := VerifyPassword(hasher, encoded, "summer")
+	assert.AssertTrue(t, valid)
+	assert.AssertFalse(t, outdated)
+
+	valid, _ = VerifyPassword(hasher, encoded, "winter")
+	assert.AssertFalse(t, valid)
+}
+
+func TestVerifyPasswordFlagsOtherAlgorithmAsOutdated(t *testing.T) {
+	encoded, _ := EncodePassword(NewArgon2idHasher(), "summer")
+
+	valid, outdated := VerifyPassword(BcryptHasher{Cost: 4}, encoded, "summer")
+
+	assert.AssertTrue(t, valid)
+	assert.AssertTrue(t, outdated)
+}
+
+func TestVerifyLegacyMD5Password(t *testing.T) {
+	found, _ := HasherForEncoded("6b1628b016dff46e6fa35684be6acc96")
+	assert.AssertEqual(t, "md5", found.Algorithm())
+
+	valid, outdated := VerifyPassword(DefaultPasswordHasher, "6b1628b016dff46e6fa35684be6acc96", "summer")
+	assert.AssertTrue(t, valid)
+	assert.AssertTrue(t, outdated)
+}
+
+func TestVerifyPasswordRejectsUnknownFormat(t *testing.T) {
+	valid, outdated := VerifyPassword(DefaultPasswordHasher, "summer", "summer")
+
+	assert.AssertFalse(t, valid)
+	assert.AssertFalse(t, outdated)
+}
@@ -0,0 +1,126 @@
+package app
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"gopkg.in/src-d/go-kallax.v1"
+)
+
+//OutboxHandler ...
+//go:generate moq -out outboxhandler_moq.go . OutboxHandler
+type OutboxHandler interface {
+	FindPendingEvents(now time.Time, limit uint64) ([]*PollOutbox, error)
+	CountPendingEvents() (int64, error)
+	FindOldestPendingEvent() (*PollOutbox, error)
+	MarkPublished(ID kallax.ULID, at time.Time) error
+	MarkFailed(ID kallax.ULID, reason string, nextAttemptAt time.Time) error
+	MarkDead(ID kallax.ULID, reason string) error
+}
+
+//IPollOutboxStore ...
+//go:generate moq -out ipolloutboxstore_moq.go . IPollOutboxStore
+type IPollOutboxStore interface {
+	FindAll(q *PollOutboxQuery) ([]*PollOutbox, error)
+	Count(q *PollOutboxQuery) (int64, error)
+	RawExec(sql string, params ...interface{}) (int64, error)
+}
+
+//OutboxRecorder is a store that can add records to the outbox in its own transaction.
+type OutboxRecorder interface {
+	SaveOutbox(record *PollOutbox) error
+}
+
+//recordEvents adds the events to the outbox through the recorder.
+func recordEvents(recorder OutboxRecorder, events []Event) error {
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		err = recorder.SaveOutbox(&PollOutbox{
+			ID:            kallax.NewULID(),
+			Event:         event.EventName(),
+			Payload:       string(payload),
+			NextAttemptAt: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//saveOutbox inserts the record with the connection of the store, so it joins any running transaction.
+func saveOutbox(store *kallax.Store, record *PollOutbox) error {
+	return (&PollOutboxStore{store}).Insert(record)
+}
+
+//OutboxHandlerImpl ...
+type OutboxHandlerImpl struct {
+	Store IPollOutboxStore
+}
+
+//NewOutboxHandler ...
+func NewOutboxHandler(db *sql.DB) *OutboxHandlerImpl {
+	return &OutboxHandlerImpl{
+		Store: NewPollOutboxStore(db),
+	}
+}
+
+func pendingOutboxQuery() *PollOutboxQuery {
+	return NewPollOutboxQuery().FindByPublished(false).FindByDead(false)
+}
+
+//FindPendingEvents gives the records not published yet whose next attempt is due, the oldest first.
+func (h OutboxHandlerImpl) FindPendingEvents(now time.Time, limit uint64) ([]*PollOutbox, error) {
+	query := pendingOutboxQuery().
+		FindByNextAttemptAt(kallax.LtOrEq, now).
+		Order(kallax.Asc(Schema.PollOutbox.CreatedAt)).
+		Limit(limit)
+
+	return h.Store.FindAll(query)
+}
+
+//CountPendingEvents counts the records not published yet, due or waiting for their next attempt.
+func (h OutboxHandlerImpl) CountPendingEvents() (int64, error) {
+	return h.Store.Count(pendingOutboxQuery())
+}
+
+//FindOldestPendingEvent gives the oldest record not published yet, due or waiting for its
+//next attempt, or nil when there is none.
+func (h OutboxHandlerImpl) FindOldestPendingEvent() (*PollOutbox, error) {
+	query := pendingOutboxQuery().
+		Order(kallax.Asc(Schema.PollOutbox.CreatedAt)).
+		Limit(1)
+
+	records, err := h.Store.FindAll(query)
+	if err != nil || len(records) == 0 {
+		return nil, err
+	}
+
+	return records[0], nil
+}
+
+//MarkPublished ...
+func (h OutboxHandlerImpl) MarkPublished(ID kallax.ULID, at time.Time) error {
+	_, err := h.Store.RawExec("UPDATE poll_outbox SET published = true, published_at = $2 WHERE id = $1", ID, at)
+	return err
+}
+
+//MarkFailed counts a failed attempt. The record stays pending until nextAttemptAt.
+func (h OutboxHandlerImpl) MarkFailed(ID kallax.ULID, reason string, nextAttemptAt time.Time) error {
+	_, err := h.Store.RawExec("UPDATE poll_outbox SET attempts = attempts + 1, last_error = $2, "+
+		"next_attempt_at = $3 WHERE id = $1", ID, reason, nextAttemptAt)
+	return err
+}
+
+//MarkDead counts a last attempt and leaves the record out of the relay.
+func (h OutboxHandlerImpl) MarkDead(ID kallax.ULID, reason string) error {
+	_, err := h.Store.RawExec("UPDATE poll_outbox SET attempts = attempts + 1, last_error = $2, "+
+		"dead = true WHERE id = $1", ID, reason)
+	return err
+}
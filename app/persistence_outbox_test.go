@@ -0,0 +1,163 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chai2010/assert"
+
+	"gopkg.in/src-d/go-kallax.v1"
+)
+
+func TestFindPendingEvents(t *testing.T) {
+	var sqlExecuted string
+	store := &IPollOutboxStoreMock{
+		FindAllFunc: func(q *PollOutboxQuery) ([]*PollOutbox, error) {
+			sqlExecuted = q.String()
+			return []*PollOutbox{}, nil
+		},
+	}
+
+	handler := OutboxHandlerImpl{
+		Store: store,
+	}
+
+	_, err := handler.FindPendingEvents(time.Now(), 10)
+
+	assert.AssertNil(t, err)
+	sqlExpected := "SELECT __polloutbox.id, __polloutbox.created_at, __polloutbox.updated_at, " +
+		"__polloutbox.event, __polloutbox.payload, __polloutbox.published, __polloutbox.published_at, " +
+		"__polloutbox.attempts, __polloutbox.next_attempt_at, __polloutbox.last_error, __polloutbox.dead " +
+		"FROM poll_outbox __polloutbox " +
+		"WHERE __polloutbox.published = $1 AND __polloutbox.dead = $2 AND __polloutbox.next_attempt_at <= $3 " +
+		"ORDER BY __polloutbox.created_at ASC LIMIT 10"
+	assert.AssertEqual(t, sqlExpected, sqlExecuted)
+}
+
+func TestCountPendingEvents(t *testing.T) {
+	store := &IPollOutboxStoreMock{
+		CountFunc: func(q *PollOutboxQuery) (int64, error) {
+			return 3, nil
+		},
+	}
+
+	handler := OutboxHandlerImpl{
+		Store: store,
+	}
+
+	pending, err := handler.CountPendingEvents()
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, int64(3), pending)
+}
+
+func TestFindOldestPendingEvent(t *testing.T) {
+	var sqlExecuted string
+	oldest := &PollOutbox{ID: kallax.NewULID()}
+	store := &IPollOutboxStoreMock{
+		FindAllFunc: func(q *PollOutboxQuery) ([]*PollOutbox, error) {
+			sqlExecuted = q.String()
+			return []*PollOutbox{oldest}, nil
+		},
+	}
+
+	handler := OutboxHandlerImpl{
+		Store: store,
+	}
+
+	record, err := handler.FindOldestPendingEvent()
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, oldest, record)
+	sqlExpected := "SELECT __polloutbox.id, __polloutbox.created_at, __polloutbox.updated_at, " +
+		"__polloutbox.event, __polloutbox.payload, __polloutbox.published, __polloutbox.published_at, " +
+		"__polloutbox.attempts, __polloutbox.next_attempt_at, __polloutbox.last_error, __polloutbox.dead " +
+		"FROM poll_outbox __polloutbox " +
+		"WHERE __polloutbox.published = $1 AND __polloutbox.dead = $2 " +
+		"ORDER BY __polloutbox.created_at ASC LIMIT 1"
+	assert.AssertEqual(t, sqlExpected, sqlExecuted)
+}
+
+func TestFindOldestPendingEventWithNothingPending(t *testing.T) {
+	store := &IPollOutboxStoreMock{
+		FindAllFunc: func(q *PollOutboxQuery) ([]*PollOutbox, error) {
+			return []*PollOutbox{}, nil
+		},
+	}
+
+	handler := OutboxHandlerImpl{
+		Store: store,
+	}
+
+	record, err := handler.FindOldestPendingEvent()
+
+	assert.AssertNil(t, err)
+	assert.AssertNil(t, record)
+}
+
+func TestMarkPublished(t *testing.T) {
+	var sqlExecuted string
+	var paramsPassed []interface{}
+	store := &IPollOutboxStoreMock{
+		RawExecFunc: func(sql string, params ...interface{}) (int64, error) {
+			sqlExecuted = sql
+			paramsPassed = params
+			return 1, nil
+		},
+	}
+
+	handler := OutboxHandlerImpl{
+		Store: store,
+	}
+
+	ID := kallax.NewULID()
+	at := time.Date(2018, 12, 20, 12, 0, 0, 0, time.UTC)
+	err := handler.MarkPublished(ID, at)
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, "UPDATE poll_outbox SET published = true, published_at = $2 WHERE id = $1", sqlExecuted)
+	assert.AssertEqual(t, []interface{}{ID, at}, paramsPassed)
+}
+
+func createOutboxExecStoreMock(sqlExecuted *string, paramsPassed *[]interface{}) *IPollOutboxStoreMock {
+	return &IPollOutboxStoreMock{
+		RawExecFunc: func(sql string, params ...interface{}) (int64, error) {
+			*sqlExecuted = sql
+			*paramsPassed = params
+			return 1, nil
+		},
+	}
+}
+
+func TestMarkFailed(t *testing.T) {
+	var sqlExecuted string
+	var paramsPassed []interface{}
+	handler := OutboxHandlerImpl{
+		Store: createOutboxExecStoreMock(&sqlExecuted, &paramsPassed),
+	}
+
+	ID := kallax.NewULID()
+	next := time.Date(2018, 12, 20, 12, 0, 5, 0, time.UTC)
+	err := handler.MarkFailed(ID, "Connection lost", next)
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, "UPDATE poll_outbox SET attempts = attempts + 1, last_error = $2, "+
+		"next_attempt_at = $3 WHERE id = $1", sqlExecuted)
+	assert.AssertEqual(t, []interface{}{ID, "Connection lost", next}, paramsPassed)
+}
+
+func TestMarkDead(t *testing.T) {
+	var sqlExecuted string
+	var paramsPassed []interface{}
+	handler := OutboxHandlerImpl{
+		Store: createOutboxExecStoreMock(&sqlExecuted, &paramsPassed),
+	}
+
+	ID := kallax.NewULID()
+	err := handler.MarkDead(ID, "Unknown event poll.archived in the outbox")
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, "UPDATE poll_outbox SET attempts = attempts + 1, last_error = $2, "+
+		"dead = true WHERE id = $1", sqlExecuted)
+	assert.AssertEqual(t, []interface{}{ID, "Unknown event poll.archived in the outbox"}, paramsPassed)
+}
@@ -3,6 +3,7 @@ package app
 import (
 	"database/sql"
 	"log"
+	"time"
 
 	kallax "gopkg.in/src-d/go-kallax.v1"
 )
@@ -10,8 +11,12 @@ import (
 //PollHandler ...
 //go:generate moq -out pollhandler_moq.go . PollHandler
 type PollHandler interface {
-	SavePoll(poll Poll) Poll
+	SavePoll(poll Poll, events ...Event) (Poll, error)
 	FindPollByID(ID kallax.ULID) (*Poll, error)
+	FindPolls(query *PollQuery) ([]*Poll, error)
+	FindPollsByOwner(userID kallax.ULID) ([]*Poll, error)
+	FindPollsToOpen(now time.Time) ([]*Poll, error)
+	FindPollsToClose(now time.Time) ([]*Poll, error)
 }
 
 //IPollStore ...
@@ -19,6 +24,26 @@ type PollHandler interface {
 type IPollStore interface {
 	Save(record *Poll) (updated bool, err error)
 	FindOne(q *PollQuery) (*Poll, error)
+	FindAll(q *PollQuery) ([]*Poll, error)
+	Transaction(callback func(IPollStore) error) error
+	SaveOutbox(record *PollOutbox) error
+}
+
+//txPollStore adapts PollStore.Transaction to IPollStore.
+type txPollStore struct {
+	*PollStore
+}
+
+//Transaction ...
+func (s txPollStore) Transaction(callback func(IPollStore) error) error {
+	return s.PollStore.Transaction(func(tx *PollStore) error {
+		return callback(txPollStore{tx})
+	})
+}
+
+//SaveOutbox ...
+func (s txPollStore) SaveOutbox(record *PollOutbox) error {
+	return saveOutbox(s.GenericStore(), record)
 }
 
 //PollHandlerImpl ...
@@ -30,7 +55,7 @@ type PollHandlerImpl struct {
 //NewPollHandler ...
 func NewPollHandler(db *sql.DB, optionHandler PollOptionHandler) *PollHandlerImpl {
 	return &PollHandlerImpl{
-		Store:         NewPollStore(db),
+		Store:         txPollStore{NewPollStore(db)},
 		OptionHandler: optionHandler,
 	}
 }
@@ -38,10 +63,9 @@ func NewPollHandler(db *sql.DB, optionHandler PollOptionHandler) *PollHandlerImp
 //PollOptionHandler ...
 //go:generate moq -out polloptionhandler_moq.go . PollOptionHandler
 type PollOptionHandler interface {
-	SavePollOption(poll PollOption) PollOption
-	DeletePollOption(id kallax.ULID) error
+	SavePollOption(poll PollOption, events ...Event) (PollOption, error)
+	DeletePollOption(pollID, id kallax.ULID, events ...Event) error
 	FindPollOptions(id kallax.ULID) ([]*PollOption, error)
-	ExistsOption(pollID kallax.ULID, candidate string) (bool, error)
 }
 
 //IPollOptionStore ...
@@ -52,6 +76,25 @@ type IPollOptionStore interface {
 	FindOne(q *PollOptionQuery) (*PollOption, error)
 	FindAll(q *PollOptionQuery) ([]*PollOption, error)
 	Count(q *PollOptionQuery) (int64, error)
+	Transaction(callback func(IPollOptionStore) error) error
+	SaveOutbox(record *PollOutbox) error
+}
+
+//txPollOptionStore adapts PollOptionStore.Transaction to IPollOptionStore.
+type txPollOptionStore struct {
+	*PollOptionStore
+}
+
+//Transaction ...
+func (s txPollOptionStore) Transaction(callback func(IPollOptionStore) error) error {
+	return s.PollOptionStore.Transaction(func(tx *PollOptionStore) error {
+		return callback(txPollOptionStore{tx})
+	})
+}
+
+//SaveOutbox ...
+func (s txPollOptionStore) SaveOutbox(record *PollOutbox) error {
+	return saveOutbox(s.GenericStore(), record)
 }
 
 //PollOptionHandlerImpl ...
@@ -62,16 +105,23 @@ type PollOptionHandlerImpl struct {
 //NewPollOptionHandler ...
 func NewPollOptionHandler(db *sql.DB) *PollOptionHandlerImpl {
 	return &PollOptionHandlerImpl{
-		Store: NewPollOptionStore(db),
+		Store: txPollOptionStore{NewPollOptionStore(db)},
 	}
 }
 
-//SavePoll ...
-func (h PollHandlerImpl) SavePoll(poll Poll) Poll {
+//SavePoll saves the poll and records the events in the outbox in the same transaction.
+func (h PollHandlerImpl) SavePoll(poll Poll, events ...Event) (Poll, error) {
 	log.Println("Saving Poll", poll)
 
-	h.Store.Save(&poll)
-	return poll
+	err := h.Store.Transaction(func(store IPollStore) error {
+		if _, err := store.Save(&poll); err != nil {
+			return err
+		}
+
+		return recordEvents(store, events)
+	})
+
+	return poll, err
 }
 
 //FindPollByID ...
@@ -93,46 +143,81 @@ func (h PollHandlerImpl) FindPollByID(ID kallax.ULID) (*Poll, error) {
 	return poll, nil
 }
 
-// SavePollOption ...
-func (h PollOptionHandlerImpl) SavePollOption(pollOption PollOption) PollOption {
+//FindPolls ...
+func (h PollHandlerImpl) FindPolls(query *PollQuery) ([]*Poll, error) {
+	return h.Store.FindAll(query)
+}
+
+//FindPollsByOwner ...
+func (h PollHandlerImpl) FindPollsByOwner(userID kallax.ULID) ([]*Poll, error) {
+	query := NewPollQuery().
+		FindByOwner(userID).
+		Order(kallax.Asc(Schema.Poll.CreatedAt))
+
+	return h.FindPolls(query)
+}
+
+//FindPollsToOpen returns the scheduled polls whose opening time has come.
+func (h PollHandlerImpl) FindPollsToOpen(now time.Time) ([]*Poll, error) {
+	query := NewPollQuery().
+		FindByStatus(PollScheduled).
+		FindByOpensAt(kallax.LtOrEq, now)
+
+	return h.FindPolls(query)
+}
+
+//FindPollsToClose returns the open polls whose closing time has passed.
+func (h PollHandlerImpl) FindPollsToClose(now time.Time) ([]*Poll, error) {
+	query := NewPollQuery().
+		FindByStatus(PollOpen).
+		FindByClosesAt(kallax.LtOrEq, now)
+
+	return h.FindPolls(query)
+}
+
+// SavePollOption saves the option and records the events in the outbox in the same transaction.
+func (h PollOptionHandlerImpl) SavePollOption(pollOption PollOption, events ...Event) (PollOption, error) {
 	log.Println("Adding Poll Option", pollOption)
 
-	h.Store.Save(&pollOption)
-	return pollOption
+	err := h.Store.Transaction(func(store IPollOptionStore) error {
+		if _, err := store.Save(&pollOption); err != nil {
+			return err
+		}
+
+		return recordEvents(store, events)
+	})
+
+	return pollOption, err
 }
 
-// DeletePollOption ...
-func (h PollOptionHandlerImpl) DeletePollOption(id kallax.ULID) error {
+// DeletePollOption deletes the option of the poll and records the events in the outbox in the
+// same transaction. Options of other polls are not found.
+func (h PollOptionHandlerImpl) DeletePollOption(pollID, id kallax.ULID, events ...Event) error {
 	log.Println("Removing Poll Option", id)
 
-	query := NewPollOptionQuery().FindByID(id)
+	return h.Store.Transaction(func(store IPollOptionStore) error {
+		query := NewPollOptionQuery().FindByID(id).FindByOwner(pollID)
 
-	opt, err := h.Store.FindOne(query)
+		opt, err := store.FindOne(query)
 
-	if err != nil {
-		return err
-	}
+		if err != nil {
+			return err
+		}
 
-	return h.Store.Delete(opt)
-}
+		if err := store.Delete(opt); err != nil {
+			return err
+		}
 
-// FindPollOptions ...
-func (h PollOptionHandlerImpl) FindPollOptions(id kallax.ULID) ([]*PollOption, error) {
-	query := NewPollOptionQuery().FindByOwner(id)
-	return h.Store.FindAll(query)
+		return recordEvents(store, events)
+	})
 }
 
-//ExistsOption ...
-func (h PollOptionHandlerImpl) ExistsOption(pollID kallax.ULID, candidate string) (bool, error) {
+// FindPollOptions returns the options of the poll ordered by position.
+func (h PollOptionHandlerImpl) FindPollOptions(id kallax.ULID) ([]*PollOption, error) {
 	query := NewPollOptionQuery().
-		FindByOwner(pollID).
-		FindByContent(candidate)
-
-	count, err := h.Store.Count(query)
+		FindByOwner(id).
+		Order(kallax.Asc(Schema.PollOption.Position))
 
-	if err != nil {
-		return false, err
-	}
-
-	return count > 0, nil
+	return h.Store.FindAll(query)
 }
+
@@ -0,0 +1,242 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/chai2010/assert"
+
+	"gopkg.in/src-d/go-kallax.v1"
+)
+
+func TestFindPollsByOwner(t *testing.T) {
+	var sqlExecuted string
+
+	store := &IPollStoreMock{
+		FindAllFunc: func(q *PollQuery) ([]*Poll, error) {
+			sqlExecuted = q.String()
+			return []*Poll{&Poll{}}, nil
+		},
+	}
+
+	handler := PollHandlerImpl{
+		Store: store,
+	}
+
+	polls, err := handler.FindPollsByOwner(kallax.NewULID())
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, 1, len(polls))
+	assert.AssertEqual(t, 1, len(store.FindAllCalls()))
+	sqlExpected := "SELECT __poll.id, __poll.created_at, __poll.updated_at, __poll.name, __poll.owner, __poll.status, __poll.opens_at, __poll.closes_at, " +
+		"__poll.min_choices, __poll.max_choices, __poll.voting_method, __poll.max_score " +
+		"FROM poll __poll WHERE __poll.owner = $1 ORDER BY __poll.created_at ASC"
+	assert.AssertEqual(t, sqlExpected, sqlExecuted)
+}
+
+func TestFindPollOptionsOrderedByPosition(t *testing.T) {
+	var sqlExecuted string
+
+	store := &IPollOptionStoreMock{
+		FindAllFunc: func(q *PollOptionQuery) ([]*PollOption, error) {
+			sqlExecuted = q.String()
+			return []*PollOption{}, nil
+		},
+	}
+
+	handler := PollOptionHandlerImpl{
+		Store: store,
+	}
+
+	_, err := handler.FindPollOptions(kallax.NewULID())
+
+	assert.AssertNil(t, err)
+	sqlExpected := "SELECT __polloption.id, __polloption.poll_id, __polloption.content, __polloption.position " +
+		"FROM poll_option __polloption WHERE __polloption.poll_id = $1 ORDER BY __polloption.position ASC"
+	assert.AssertEqual(t, sqlExpected, sqlExecuted)
+}
+
+func TestFindPollsToOpen(t *testing.T) {
+	var sqlExecuted string
+
+	store := &IPollStoreMock{
+		FindAllFunc: func(q *PollQuery) ([]*Poll, error) {
+			sqlExecuted = q.String()
+			return []*Poll{}, nil
+		},
+	}
+
+	handler := PollHandlerImpl{
+		Store: store,
+	}
+
+	_, err := handler.FindPollsToOpen(time.Now())
+
+	assert.AssertNil(t, err)
+	sqlExpected := "SELECT __poll.id, __poll.created_at, __poll.updated_at, __poll.name, __poll.owner, __poll.status, __poll.opens_at, __poll.closes_at, " +
+		"__poll.min_choices, __poll.max_choices, __poll.voting_method, __poll.max_score " +
+		"FROM poll __poll WHERE __poll.status = $1 AND __poll.opens_at <= $2"
+	assert.AssertEqual(t, sqlExpected, sqlExecuted)
+}
+
+func TestFindPollsToClose(t *testing.T) {
+	var sqlExecuted string
+
+	store := &IPollStoreMock{
+		FindAllFunc: func(q *PollQuery) ([]*Poll, error) {
+			sqlExecuted = q.String()
+			return []*Poll{}, nil
+		},
+	}
+
+	handler := PollHandlerImpl{
+		Store: store,
+	}
+
+	_, err := handler.FindPollsToClose(time.Now())
+
+	assert.AssertNil(t, err)
+	sqlExpected := "SELECT __poll.id, __poll.created_at, __poll.updated_at, __poll.name, __poll.owner, __poll.status, __poll.opens_at, __poll.closes_at, " +
+		"__poll.min_choices, __poll.max_choices, __poll.voting_method, __poll.max_score " +
+		"FROM poll __poll WHERE __poll.status = $1 AND __poll.closes_at <= $2"
+	assert.AssertEqual(t, sqlExpected, sqlExecuted)
+}
+
+func createTxPollStoreMock() *IPollStoreMock {
+	store := &IPollStoreMock{
+		SaveFunc: func(record *Poll) (bool, error) {
+			return false, nil
+		},
+		SaveOutboxFunc: func(record *PollOutbox) error {
+			return nil
+		},
+	}
+	store.TransactionFunc = func(callback func(IPollStore) error) error {
+		return callback(store)
+	}
+
+	return store
+}
+
+func TestSavePollRecordsEventsInTheSameTransaction(t *testing.T) {
+	store := createTxPollStoreMock()
+	handler := PollHandlerImpl{
+		Store: store,
+	}
+	poll := Poll{ID: kallax.NewULID(), Name: "Lunch"}
+
+	_, err := handler.SavePoll(poll, PollCreatedEvent{PollID: poll.ID, Name: poll.Name})
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, 1, len(store.TransactionCalls()))
+	assert.AssertEqual(t, 1, len(store.SaveCalls()))
+	record := store.SaveOutboxCalls()[0].Record
+	assert.AssertEqual(t, EventPollCreated, record.Event)
+	assert.AssertFalse(t, record.Published)
+	event, _ := DecodeOutboxEvent(record)
+	assert.AssertEqual(t, poll.ID, event.(PollCreatedEvent).PollID)
+}
+
+func TestShouldNotRecordEventsWhenSavePollFails(t *testing.T) {
+	store := createTxPollStoreMock()
+	store.SaveFunc = func(record *Poll) (bool, error) {
+		return false, fmt.Errorf("Disk full")
+	}
+	handler := PollHandlerImpl{
+		Store: store,
+	}
+
+	_, err := handler.SavePoll(Poll{}, PollClosedEvent{})
+
+	assert.AssertEqual(t, "Disk full", err.Error())
+	assert.AssertEqual(t, 0, len(store.SaveOutboxCalls()))
+}
+
+func TestSavePollFailsWhenOutboxFails(t *testing.T) {
+	store := createTxPollStoreMock()
+	store.SaveOutboxFunc = func(record *PollOutbox) error {
+		return fmt.Errorf("Disk full")
+	}
+	handler := PollHandlerImpl{
+		Store: store,
+	}
+
+	_, err := handler.SavePoll(Poll{}, PollPublishedEvent{})
+
+	assert.AssertEqual(t, "Disk full", err.Error())
+}
+
+func createTxPollOptionStoreMock() *IPollOptionStoreMock {
+	store := &IPollOptionStoreMock{
+		SaveFunc: func(record *PollOption) (bool, error) {
+			return false, nil
+		},
+		FindOneFunc: func(q *PollOptionQuery) (*PollOption, error) {
+			return &PollOption{}, nil
+		},
+		DeleteFunc: func(record *PollOption) error {
+			return nil
+		},
+		SaveOutboxFunc: func(record *PollOutbox) error {
+			return nil
+		},
+	}
+	store.TransactionFunc = func(callback func(IPollOptionStore) error) error {
+		return callback(store)
+	}
+
+	return store
+}
+
+func TestSavePollOptionRecordsEventsInTheSameTransaction(t *testing.T) {
+	store := createTxPollOptionStoreMock()
+	handler := PollOptionHandlerImpl{
+		Store: store,
+	}
+	option := PollOption{ID: kallax.NewULID(), Content: "Pizza"}
+
+	_, err := handler.SavePollOption(option, OptionAddedEvent{OptionID: option.ID, Content: option.Content})
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, 1, len(store.TransactionCalls()))
+	assert.AssertEqual(t, 1, len(store.SaveCalls()))
+	record := store.SaveOutboxCalls()[0].Record
+	assert.AssertEqual(t, EventOptionAdded, record.Event)
+	event, _ := DecodeOutboxEvent(record)
+	assert.AssertEqual(t, option.ID, event.(OptionAddedEvent).OptionID)
+}
+
+func TestDeletePollOptionRecordsEventsInTheSameTransaction(t *testing.T) {
+	store := createTxPollOptionStoreMock()
+	handler := PollOptionHandlerImpl{
+		Store: store,
+	}
+	pollID, ID := kallax.NewULID(), kallax.NewULID()
+
+	err := handler.DeletePollOption(pollID, ID, OptionRemovedEvent{OptionID: ID})
+
+	assert.AssertNil(t, err)
+	sqlExpected := "SELECT __polloption.id, __polloption.poll_id, __polloption.content, __polloption.position " +
+		"FROM poll_option __polloption WHERE __polloption.id IN ($1) AND __polloption.poll_id = $2"
+	assert.AssertEqual(t, sqlExpected, store.FindOneCalls()[0].Q.String())
+	assert.AssertEqual(t, 1, len(store.TransactionCalls()))
+	assert.AssertEqual(t, 1, len(store.DeleteCalls()))
+	assert.AssertEqual(t, EventOptionRemoved, store.SaveOutboxCalls()[0].Record.Event)
+}
+
+func TestDeletePollOptionRecordsNoEventWhenOptionIsMissing(t *testing.T) {
+	store := createTxPollOptionStoreMock()
+	store.FindOneFunc = func(q *PollOptionQuery) (*PollOption, error) {
+		return nil, kallax.ErrNotFound
+	}
+	handler := PollOptionHandlerImpl{
+		Store: store,
+	}
+
+	err := handler.DeletePollOption(kallax.NewULID(), kallax.NewULID(), OptionRemovedEvent{})
+
+	assert.AssertEqual(t, kallax.ErrNotFound, err)
+	assert.AssertEqual(t, 0, len(store.DeleteCalls()))
+	assert.AssertEqual(t, 0, len(store.SaveOutboxCalls()))
+}
@@ -1,8 +1,11 @@
 package app
 
 import (
+	"bytes"
 	"database/sql"
 	"log"
+	"sort"
+	"time"
 
 	"gopkg.in/src-d/go-kallax.v1"
 )
@@ -11,16 +14,188 @@ import (
 //go:generate moq -out pollvotehandler_moq.go . PollVoteHandler
 type PollVoteHandler interface {
 	PollAlreadyVotedByUser(pollID kallax.ULID, userID kallax.ULID) (bool, error)
-	VotesFor(pollID kallax.ULID, option string) int64
-	SaveVote(vote PollVote) PollVote
+	SelectionsFor(pollID kallax.ULID, optionID kallax.ULID) (int64, error)
+	TallyByPoll(pollID kallax.ULID) (map[kallax.ULID]int64, error)
+	VotersOf(pollID kallax.ULID) (int64, error)
+	BallotsOf(pollID kallax.ULID) ([]Ballot, error)
+	ScoresOf(pollID kallax.ULID) (map[kallax.ULID]int64, error)
+	SaveVote(vote PollVote, selections []PollVoteSelection, events ...Event) (PollVote, error)
+	ChangeVote(pollID kallax.ULID, userID kallax.ULID, chosen string, selections []PollVoteSelection, events ...Event) (PollVote, error)
+	RetractVote(pollID kallax.ULID, userID kallax.ULID, events ...Event) (PollVote, error)
+	RebuildTally(pollID kallax.ULID) error
+	VoteTimeline(pollID kallax.ULID, fn func(time.Time) error) error
 }
 
 //IPollVoteStore ...
 //go:generate moq -out ipollvotestore_moq.go . IPollVoteStore
 type IPollVoteStore interface {
 	Save(record *PollVote) (updated bool, err error)
-	// FindOne(q *PollVoteQuery) (*PollVote, error)
+	Delete(record *PollVote) error
+	FindOne(q *PollVoteQuery) (*PollVote, error)
 	Count(q *PollVoteQuery) (int64, error)
+	Transaction(callback func(IPollVoteStore) error) error
+	SaveAudit(record *PollVoteAudit) error
+	SaveSelection(record *PollVoteSelection) error
+	CountSelections(q *PollVoteSelectionQuery) (int64, error)
+	CountSelectionsByOption(pollID kallax.ULID) (map[kallax.ULID]int64, error)
+	FindSelections(q *PollVoteSelectionQuery) ([]*PollVoteSelection, error)
+	DeleteSelections(voteID kallax.ULID) error
+	AddToTally(pollID kallax.ULID, optionID kallax.ULID, delta int64) error
+	FindTally(pollID kallax.ULID) (map[kallax.ULID]int64, error)
+	ReplaceTally(pollID kallax.ULID, counts map[kallax.ULID]int64) error
+	LockTally() error
+	SaveOutbox(record *PollOutbox) error
+	EachVoteTime(pollID kallax.ULID, fn func(time.Time) error) error
+}
+
+const pollVoteUserIndex = "poll_vote_poll_user_idx"
+
+var errAlreadyVoted = ErrConflict("You already voted in this poll")
+
+var errNotVoted = ErrNotFound("You didn't vote in this poll")
+
+//txPollVoteStore adapts PollVoteStore.Transaction to IPollVoteStore.
+type txPollVoteStore struct {
+	*PollVoteStore
+}
+
+//Transaction ...
+func (s txPollVoteStore) Transaction(callback func(IPollVoteStore) error) error {
+	return s.PollVoteStore.Transaction(func(tx *PollVoteStore) error {
+		return callback(txPollVoteStore{tx})
+	})
+}
+
+//SaveAudit inserts the audit row with the same connection, so it joins any running transaction.
+func (s txPollVoteStore) SaveAudit(record *PollVoteAudit) error {
+	return (&PollVoteAuditStore{s.GenericStore()}).Insert(record)
+}
+
+//SaveSelection inserts the selection with the same connection, so it joins any running transaction.
+func (s txPollVoteStore) SaveSelection(record *PollVoteSelection) error {
+	return (&PollVoteSelectionStore{s.GenericStore()}).Insert(record)
+}
+
+//SaveOutbox ...
+func (s txPollVoteStore) SaveOutbox(record *PollOutbox) error {
+	return saveOutbox(s.GenericStore(), record)
+}
+
+//CountSelections ...
+func (s txPollVoteStore) CountSelections(q *PollVoteSelectionQuery) (int64, error) {
+	return (&PollVoteSelectionStore{s.GenericStore()}).Count(q)
+}
+
+const selectionsByOptionQuery = `SELECT poll_option_id, count(*) FROM poll_vote_selection
+WHERE poll_id = $1 GROUP BY poll_option_id`
+
+//CountSelectionsByOption counts the selections of every option of the poll in a single query.
+func (s txPollVoteStore) CountSelectionsByOption(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+	rs, err := s.RawQuery(selectionsByOptionQuery, pollID)
+	if err != nil {
+		return nil, err
+	}
+	defer rs.Close()
+
+	counts := make(map[kallax.ULID]int64)
+	for rs.Next() {
+		var optionID kallax.ULID
+		var count int64
+		if err := rs.RawScan(&optionID, &count); err != nil {
+			return nil, err
+		}
+
+		counts[optionID] = count
+	}
+
+	return counts, nil
+}
+
+//FindSelections ...
+func (s txPollVoteStore) FindSelections(q *PollVoteSelectionQuery) ([]*PollVoteSelection, error) {
+	return (&PollVoteSelectionStore{s.GenericStore()}).FindAll(q)
+}
+
+//DeleteSelections removes every selection of the vote.
+func (s txPollVoteStore) DeleteSelections(voteID kallax.ULID) error {
+	_, err := s.RawExec("DELETE FROM poll_vote_selection WHERE vote_id = $1", voteID)
+	return err
+}
+
+const addToTallyQuery = `INSERT INTO poll_tally (poll_id, poll_option_id, selections) VALUES ($1, $2, $3)
+ON CONFLICT (poll_id, poll_option_id) DO UPDATE SET selections = poll_tally.selections + excluded.selections`
+
+//AddToTally adds delta to the cached count of the option, creating it when missing.
+func (s txPollVoteStore) AddToTally(pollID, optionID kallax.ULID, delta int64) error {
+	_, err := s.RawExec(addToTallyQuery, pollID, optionID, delta)
+	return err
+}
+
+//FindTally reads the cached count of every option of the poll.
+func (s txPollVoteStore) FindTally(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+	rs, err := s.RawQuery("SELECT poll_option_id, selections FROM poll_tally WHERE poll_id = $1", pollID)
+	if err != nil {
+		return nil, err
+	}
+	defer rs.Close()
+
+	counts := make(map[kallax.ULID]int64)
+	for rs.Next() {
+		var optionID kallax.ULID
+		var count int64
+		if err := rs.RawScan(&optionID, &count); err != nil {
+			return nil, err
+		}
+
+		counts[optionID] = count
+	}
+
+	return counts, nil
+}
+
+//ReplaceTally drops the cached counts of the poll and stores the given ones instead.
+func (s txPollVoteStore) ReplaceTally(pollID kallax.ULID, counts map[kallax.ULID]int64) error {
+	if _, err := s.RawExec("DELETE FROM poll_tally WHERE poll_id = $1", pollID); err != nil {
+		return err
+	}
+
+	for optionID, count := range counts {
+		if err := s.AddToTally(pollID, optionID, count); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//LockTally keeps votes from changing any tally until the transaction ends. It waits for
+//the votes already counting to commit, so a count made afterwards sees them.
+func (s txPollVoteStore) LockTally() error {
+	_, err := s.RawExec("LOCK TABLE poll_tally IN SHARE ROW EXCLUSIVE MODE")
+	return err
+}
+
+//EachVoteTime calls fn with the time of every vote of the poll, the oldest first, reading
+//the rows one at a time.
+func (s txPollVoteStore) EachVoteTime(pollID kallax.ULID, fn func(time.Time) error) error {
+	rs, err := s.RawQuery("SELECT created_at FROM poll_vote WHERE poll_id = $1 ORDER BY created_at", pollID)
+	if err != nil {
+		return err
+	}
+	defer rs.Close()
+
+	for rs.Next() {
+		var createdAt time.Time
+		if err := rs.RawScan(&createdAt); err != nil {
+			return err
+		}
+
+		if err := fn(createdAt); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 //PollVoteHandlerImpl ...
@@ -31,38 +206,296 @@ type PollVoteHandlerImpl struct {
 //NewPollVoteHandler ...
 func NewPollVoteHandler(db *sql.DB) *PollVoteHandlerImpl {
 	return &PollVoteHandlerImpl{
-		Store: NewPollVoteStore(db),
+		Store: txPollVoteStore{NewPollVoteStore(db)},
 	}
 }
 
 //PollAlreadyVotedByUser ...
 func (h PollVoteHandlerImpl) PollAlreadyVotedByUser(pollID, userID kallax.ULID) (bool, error) {
+	return votedBy(h.Store, pollID, userID)
+}
+
+//SaveVote registers the vote, its selections and the events in a transaction, counting
+//the selections in the tally of the poll. The unique index on (poll_id, user_id) is what
+//really keeps concurrent votes of the same user out.
+func (h PollVoteHandlerImpl) SaveVote(vote PollVote, selections []PollVoteSelection,
+	events ...Event) (PollVote, error) {
+	log.Println("Registering vote", vote)
+
+	err := h.Store.Transaction(func(store IPollVoteStore) error {
+		voted, err := votedBy(store, vote.PollID, vote.UserID)
+		if err != nil {
+			return err
+		}
+
+		if voted {
+			return errAlreadyVoted
+		}
+
+		vote.OptionID = firstOptionOf(selections)
+		if _, err := store.Save(&vote); err != nil {
+			return err
+		}
+
+		if err := saveSelections(store, &vote, selections); err != nil {
+			return err
+		}
+
+		return recordEvents(store, events)
+	})
+
+	if violatesConstraint(err, pollVoteUserIndex) {
+		return vote, errAlreadyVoted
+	}
+
+	return vote, err
+}
+
+//ChangeVote replaces the options chosen by the user, keeping the previous ones in the audit,
+//and records the events in the same transaction.
+func (h PollVoteHandlerImpl) ChangeVote(pollID, userID kallax.ULID, chosen string,
+	selections []PollVoteSelection, events ...Event) (PollVote, error) {
+	log.Println("Changing vote", pollID, userID, chosen)
+
+	var changed PollVote
+	err := h.Store.Transaction(func(store IPollVoteStore) error {
+		vote, err := auditVote(store, pollID, userID, VoteChanged)
+		if err != nil {
+			return err
+		}
+
+		if err := discardSelections(store, vote); err != nil {
+			return err
+		}
+
+		vote.ChosenOption = chosen
+		vote.OptionID = firstOptionOf(selections)
+		if _, err := store.Save(vote); err != nil {
+			return err
+		}
+
+		if err := saveSelections(store, vote, selections); err != nil {
+			return err
+		}
+
+		changed = *vote
+		return recordEvents(store, events)
+	})
+
+	return changed, err
+}
+
+//RetractVote removes the vote of the user, keeping the retracted option in the audit, and
+//records the events in the same transaction.
+func (h PollVoteHandlerImpl) RetractVote(pollID, userID kallax.ULID, events ...Event) (PollVote, error) {
+	log.Println("Retracting vote", pollID, userID)
+
+	var retracted PollVote
+	err := h.Store.Transaction(func(store IPollVoteStore) error {
+		vote, err := auditVote(store, pollID, userID, VoteRetracted)
+		if err != nil {
+			return err
+		}
+
+		if err := discardSelections(store, vote); err != nil {
+			return err
+		}
+
+		if err := store.Delete(vote); err != nil {
+			return err
+		}
+
+		retracted = *vote
+		return recordEvents(store, events)
+	})
+
+	return retracted, err
+}
+
+//RebuildTally counts the selections of the poll again and replaces its tally with them,
+//for when the tally drifts from the votes. The tally is locked before counting, so no vote
+//is counted in between and then lost by the replacement.
+func (h PollVoteHandlerImpl) RebuildTally(pollID kallax.ULID) error {
+	log.Println("Rebuilding tally", pollID)
+
+	return h.Store.Transaction(func(store IPollVoteStore) error {
+		if err := store.LockTally(); err != nil {
+			return err
+		}
+
+		counts, err := store.CountSelectionsByOption(pollID)
+		if err != nil {
+			return err
+		}
+
+		return store.ReplaceTally(pollID, counts)
+	})
+}
+
+//auditVote finds the vote of the user and records its current option under the action.
+func auditVote(store IPollVoteStore, pollID, userID kallax.ULID, action string) (*PollVote, error) {
+	query := NewPollVoteQuery().
+		FindByPollID(pollID).
+		FindByUserID(userID)
+
+	vote, err := store.FindOne(query)
+	if err == kallax.ErrNotFound {
+		return nil, errNotVoted
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = store.SaveAudit(&PollVoteAudit{
+		ID:             kallax.NewULID(),
+		VoteID:         vote.ID,
+		PollID:         vote.PollID,
+		UserID:         vote.UserID,
+		PreviousOption: vote.ChosenOption,
+		Action:         action,
+	})
+
+	return vote, err
+}
+
+//firstOptionOf gives the option of the first selection, or nil when nothing was selected.
+func firstOptionOf(selections []PollVoteSelection) *kallax.ULID {
+	if len(selections) == 0 {
+		return nil
+	}
+
+	optionID := selections[0].OptionID
+	return &optionID
+}
+
+func saveSelections(store IPollVoteStore, vote *PollVote, selections []PollVoteSelection) error {
+	optionIDs := make([]kallax.ULID, 0, len(selections))
+	for i := range selections {
+		selection := selections[i]
+		selection.ID = kallax.NewULID()
+		selection.VoteID = vote.ID
+		selection.PollID = vote.PollID
+		selection.Rank = i + 1
+
+		if err := store.SaveSelection(&selection); err != nil {
+			return err
+		}
+
+		optionIDs = append(optionIDs, selection.OptionID)
+	}
+
+	return addToTally(store, vote.PollID, optionIDs, 1)
+}
+
+//addToTally adds delta to the count of every option in the order of their IDs. Every vote
+//locks the tally rows in that same order, so votes on the same options can't deadlock.
+func addToTally(store IPollVoteStore, pollID kallax.ULID, optionIDs []kallax.ULID, delta int64) error {
+	sort.Slice(optionIDs, func(i, j int) bool {
+		return bytes.Compare(optionIDs[i][:], optionIDs[j][:]) < 0
+	})
+
+	for _, optionID := range optionIDs {
+		if err := store.AddToTally(pollID, optionID, delta); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//discardSelections removes the selections of the vote from the tally and then deletes them.
+func discardSelections(store IPollVoteStore, vote *PollVote) error {
+	selections, err := store.FindSelections(NewPollVoteSelectionQuery().FindByVoteID(vote.ID))
+	if err != nil {
+		return err
+	}
+
+	optionIDs := make([]kallax.ULID, 0, len(selections))
+	for _, selection := range selections {
+		optionIDs = append(optionIDs, selection.OptionID)
+	}
+
+	if err := addToTally(store, vote.PollID, optionIDs, -1); err != nil {
+		return err
+	}
+
+	return store.DeleteSelections(vote.ID)
+}
+
+func votedBy(store IPollVoteStore, pollID, userID kallax.ULID) (bool, error) {
 	query := NewPollVoteQuery().
 		FindByPollID(pollID).
 		FindByUserID(userID)
 
-	count, err := h.Store.Count(query)
+	count, err := store.Count(query)
 
 	return count > 0, err
 }
 
-//SaveVote ...
-func (h PollVoteHandlerImpl) SaveVote(vote PollVote) PollVote {
-	log.Println("Registering vote", vote)
+//TallyByPoll tells how many votes selected each option of the poll, reading the tally
+//kept up to date by every vote. Options nobody selected may be left out.
+func (h PollVoteHandlerImpl) TallyByPoll(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+	return h.Store.FindTally(pollID)
+}
 
-	h.Store.Save(&vote)
+//SelectionsFor tells how many votes selected the option. Prefer TallyByPoll to count
+//every option of a poll.
+func (h PollVoteHandlerImpl) SelectionsFor(pollID, optionID kallax.ULID) (int64, error) {
+	query := NewPollVoteSelectionQuery().
+		FindByPollID(pollID).
+		FindByOptionID(optionID)
 
-	return vote
+	return h.Store.CountSelections(query)
 }
 
-//VotesFor ...
-func (h PollVoteHandlerImpl) VotesFor(pollID kallax.ULID, option string) int64 {
-	query := NewPollVoteQuery().FindByPollID(pollID).FindByChosenOption(option)
+//VotersOf tells how many users voted in the poll.
+func (h PollVoteHandlerImpl) VotersOf(pollID kallax.ULID) (int64, error) {
+	return h.Store.Count(NewPollVoteQuery().FindByPollID(pollID))
+}
+
+//VoteTimeline calls fn with the time each vote of the poll was cast, the oldest first.
+//It tells nothing about the voter, so the timeline can be shared.
+func (h PollVoteHandlerImpl) VoteTimeline(pollID kallax.ULID, fn func(time.Time) error) error {
+	return h.Store.EachVoteTime(pollID, fn)
+}
 
-	votesOption, err := h.Store.Count(query)
+//BallotsOf gives the options each vote selected, in the order the voter ranked them.
+func (h PollVoteHandlerImpl) BallotsOf(pollID kallax.ULID) ([]Ballot, error) {
+	query := NewPollVoteSelectionQuery().
+		FindByPollID(pollID).
+		Order(kallax.Asc(Schema.PollVoteSelection.VoteID), kallax.Asc(Schema.PollVoteSelection.Rank))
+
+	selections, err := h.Store.FindSelections(query)
 	if err != nil {
-		return 0
+		return nil, err
+	}
+
+	ballots := make([]Ballot, 0)
+	var voteID kallax.ULID
+	for _, selection := range selections {
+		if len(ballots) == 0 || selection.VoteID != voteID {
+			voteID = selection.VoteID
+			ballots = append(ballots, Ballot{})
+		}
+
+		last := len(ballots) - 1
+		ballots[last] = append(ballots[last], selection.OptionID)
+	}
+
+	return ballots, nil
+}
+
+//ScoresOf sums the scores each option got.
+func (h PollVoteHandlerImpl) ScoresOf(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+	selections, err := h.Store.FindSelections(NewPollVoteSelectionQuery().FindByPollID(pollID))
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make(map[kallax.ULID]int64)
+	for _, selection := range selections {
+		scores[selection.OptionID] += int64(selection.Score)
 	}
 
-	return votesOption
+	return scores, nil
 }
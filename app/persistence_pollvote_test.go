@@ -0,0 +1,647 @@
+package app
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chai2010/assert"
+	"github.com/lib/pq"
+
+	"gopkg.in/src-d/go-kallax.v1"
+)
+
+//newMemoryPollVoteStore keeps votes in memory and refuses a second vote of
+//the same user on the same poll, like the unique index does. Selections are
+//kept per vote and the tally per option.
+func newMemoryPollVoteStore() (*IPollVoteStoreMock, map[string]*PollVote) {
+	var lock sync.Mutex
+	votes := make(map[string]*PollVote)
+	selections := make(map[kallax.ULID][]*PollVoteSelection)
+	tally := make(map[kallax.ULID]int64)
+
+	store := &IPollVoteStoreMock{
+		SaveFunc: func(record *PollVote) (bool, error) {
+			lock.Lock()
+			defer lock.Unlock()
+
+			key := record.PollID.String() + record.UserID.String()
+			if existing, exists := votes[key]; exists && existing != record {
+				return false, &pq.Error{Code: "23505", Message: `duplicate key value violates unique constraint "poll_vote_poll_user_idx"`, Constraint: "poll_vote_poll_user_idx"}
+			}
+
+			votes[key] = record
+			return false, nil
+		},
+		CountFunc: func(q *PollVoteQuery) (int64, error) {
+			lock.Lock()
+			defer lock.Unlock()
+
+			return int64(len(votes)), nil
+		},
+		FindOneFunc: func(q *PollVoteQuery) (*PollVote, error) {
+			lock.Lock()
+			defer lock.Unlock()
+
+			for _, vote := range votes {
+				return vote, nil
+			}
+			return nil, kallax.ErrNotFound
+		},
+		DeleteFunc: func(record *PollVote) error {
+			lock.Lock()
+			defer lock.Unlock()
+
+			delete(votes, record.PollID.String()+record.UserID.String())
+			return nil
+		},
+		SaveAuditFunc: func(record *PollVoteAudit) error {
+			return nil
+		},
+		SaveOutboxFunc: func(record *PollOutbox) error {
+			return nil
+		},
+		SaveSelectionFunc: func(record *PollVoteSelection) error {
+			lock.Lock()
+			defer lock.Unlock()
+
+			selections[record.VoteID] = append(selections[record.VoteID], record)
+			return nil
+		},
+		CountSelectionsFunc: func(q *PollVoteSelectionQuery) (int64, error) {
+			lock.Lock()
+			defer lock.Unlock()
+
+			count := 0
+			for _, chosen := range selections {
+				count += len(chosen)
+			}
+			return int64(count), nil
+		},
+		CountSelectionsByOptionFunc: func(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+			lock.Lock()
+			defer lock.Unlock()
+
+			counts := make(map[kallax.ULID]int64)
+			for _, chosen := range selections {
+				for _, selection := range chosen {
+					counts[selection.OptionID]++
+				}
+			}
+			return counts, nil
+		},
+		FindSelectionsFunc: func(q *PollVoteSelectionQuery) ([]*PollVoteSelection, error) {
+			lock.Lock()
+			defer lock.Unlock()
+
+			found := make([]*PollVoteSelection, 0)
+			for _, chosen := range selections {
+				found = append(found, chosen...)
+			}
+			return found, nil
+		},
+		DeleteSelectionsFunc: func(voteID kallax.ULID) error {
+			lock.Lock()
+			defer lock.Unlock()
+
+			delete(selections, voteID)
+			return nil
+		},
+		AddToTallyFunc: func(pollID kallax.ULID, optionID kallax.ULID, delta int64) error {
+			lock.Lock()
+			defer lock.Unlock()
+
+			tally[optionID] += delta
+			return nil
+		},
+		FindTallyFunc: func(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+			lock.Lock()
+			defer lock.Unlock()
+
+			counts := make(map[kallax.ULID]int64)
+			for optionID, count := range tally {
+				counts[optionID] = count
+			}
+			return counts, nil
+		},
+	}
+	store.TransactionFunc = func(callback func(IPollVoteStore) error) error {
+		return callback(store)
+	}
+
+	return store, votes
+}
+
+func TestSaveVote(t *testing.T) {
+	store, votes := newMemoryPollVoteStore()
+	handler := PollVoteHandlerImpl{
+		Store: store,
+	}
+
+	vote := PollVote{ID: kallax.NewULID(), PollID: kallax.NewULID(), UserID: kallax.NewULID()}
+	saved, err := handler.SaveVote(vote, nil)
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, vote.ID, saved.ID)
+	assert.AssertEqual(t, 1, len(store.TransactionCalls()))
+	assert.AssertEqual(t, 1, len(store.CountCalls()))
+	assert.AssertEqual(t, 1, len(votes))
+}
+
+func TestSaveVoteRecordsItsEventInTheOutbox(t *testing.T) {
+	store, _ := newMemoryPollVoteStore()
+	handler := PollVoteHandlerImpl{
+		Store: store,
+	}
+
+	vote := PollVote{ID: kallax.NewULID(), PollID: kallax.NewULID(), UserID: kallax.NewULID()}
+	_, err := handler.SaveVote(vote, nil, VoteCastEvent{PollID: vote.PollID, VoteID: vote.ID})
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, 1, len(store.SaveOutboxCalls()))
+	assert.AssertEqual(t, EventVoteCast, store.SaveOutboxCalls()[0].Record.Event)
+}
+
+func TestChangeAndRetractVoteRecordTheirEventsInTheOutbox(t *testing.T) {
+	store, _ := newMemoryPollVoteStore()
+	handler := PollVoteHandlerImpl{
+		Store: store,
+	}
+	vote := PollVote{ID: kallax.NewULID(), PollID: kallax.NewULID(), UserID: kallax.NewULID()}
+	handler.SaveVote(vote, nil)
+
+	_, err := handler.ChangeVote(vote.PollID, vote.UserID, "B", nil, VoteChangedEvent{PollID: vote.PollID})
+	assert.AssertNil(t, err)
+	_, err = handler.RetractVote(vote.PollID, vote.UserID, VoteRetractedEvent{PollID: vote.PollID})
+	assert.AssertNil(t, err)
+
+	assert.AssertEqual(t, 2, len(store.SaveOutboxCalls()))
+	assert.AssertEqual(t, EventVoteChanged, store.SaveOutboxCalls()[0].Record.Event)
+	assert.AssertEqual(t, EventVoteRetracted, store.SaveOutboxCalls()[1].Record.Event)
+}
+
+func TestSaveVoteWhenAlreadyVoted(t *testing.T) {
+	store := &IPollVoteStoreMock{
+		CountFunc: func(q *PollVoteQuery) (int64, error) {
+			return 1, nil
+		},
+	}
+	store.TransactionFunc = func(callback func(IPollVoteStore) error) error {
+		return callback(store)
+	}
+	handler := PollVoteHandlerImpl{
+		Store: store,
+	}
+
+	_, err := handler.SaveVote(PollVote{}, nil)
+
+	assert.AssertEqual(t, "You already voted in this poll", err.Error())
+	assert.AssertEqual(t, 0, len(store.SaveCalls()))
+}
+
+func TestSaveVoteMapsUniqueViolation(t *testing.T) {
+	store := &IPollVoteStoreMock{
+		TransactionFunc: func(callback func(IPollVoteStore) error) error {
+			return &pq.Error{Code: "23505", Message: `duplicate key value violates unique constraint "poll_vote_poll_user_idx"`, Constraint: "poll_vote_poll_user_idx"}
+		},
+	}
+	handler := PollVoteHandlerImpl{
+		Store: store,
+	}
+
+	_, err := handler.SaveVote(PollVote{}, nil)
+
+	assert.AssertEqual(t, "You already voted in this poll", err.Error())
+	assert.AssertEqual(t, CodeConflict, err.(CodedError).Code())
+}
+
+func TestSaveVoteFailWhenTransactionFail(t *testing.T) {
+	store := &IPollVoteStoreMock{
+		TransactionFunc: func(callback func(IPollVoteStore) error) error {
+			return fmt.Errorf("Connection reset")
+		},
+	}
+	handler := PollVoteHandlerImpl{
+		Store: store,
+	}
+
+	_, err := handler.SaveVote(PollVote{}, nil)
+
+	assert.AssertEqual(t, "Connection reset", err.Error())
+}
+
+func TestChangeVoteKeepsPreviousOptionInAudit(t *testing.T) {
+	store, votes := newMemoryPollVoteStore()
+	handler := PollVoteHandlerImpl{
+		Store: store,
+	}
+	vote := PollVote{ID: kallax.NewULID(), PollID: kallax.NewULID(), UserID: kallax.NewULID(), ChosenOption: "A"}
+	handler.SaveVote(vote, nil)
+
+	changed, err := handler.ChangeVote(vote.PollID, vote.UserID, "B", nil)
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, vote.ID, changed.ID)
+	assert.AssertEqual(t, "B", changed.ChosenOption)
+	assert.AssertEqual(t, 1, len(votes))
+	assert.AssertEqual(t, 1, len(store.SaveAuditCalls()))
+	audit := store.SaveAuditCalls()[0].Record
+	assert.AssertEqual(t, vote.ID, audit.VoteID)
+	assert.AssertEqual(t, "A", audit.PreviousOption)
+	assert.AssertEqual(t, VoteChanged, audit.Action)
+}
+
+func TestRetractVoteKeepsRetractedOptionInAudit(t *testing.T) {
+	store, votes := newMemoryPollVoteStore()
+	handler := PollVoteHandlerImpl{
+		Store: store,
+	}
+	vote := PollVote{ID: kallax.NewULID(), PollID: kallax.NewULID(), UserID: kallax.NewULID(), ChosenOption: "A"}
+	handler.SaveVote(vote, nil)
+
+	retracted, err := handler.RetractVote(vote.PollID, vote.UserID)
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, vote.ID, retracted.ID)
+	assert.AssertEqual(t, 0, len(votes))
+	audit := store.SaveAuditCalls()[0].Record
+	assert.AssertEqual(t, "A", audit.PreviousOption)
+	assert.AssertEqual(t, VoteRetracted, audit.Action)
+}
+
+func TestRetractVoteWhenNotVoted(t *testing.T) {
+	store, _ := newMemoryPollVoteStore()
+	handler := PollVoteHandlerImpl{
+		Store: store,
+	}
+
+	_, err := handler.RetractVote(kallax.NewULID(), kallax.NewULID())
+
+	assert.AssertEqual(t, CodeNotFound, err.(CodedError).Code())
+	assert.AssertEqual(t, 0, len(store.SaveAuditCalls()))
+	assert.AssertEqual(t, 0, len(store.DeleteCalls()))
+}
+
+func TestChangeVoteFailsWhenAuditFails(t *testing.T) {
+	store, votes := newMemoryPollVoteStore()
+	store.SaveAuditFunc = func(record *PollVoteAudit) error {
+		return fmt.Errorf("Disk full")
+	}
+	handler := PollVoteHandlerImpl{
+		Store: store,
+	}
+	vote := PollVote{ID: kallax.NewULID(), PollID: kallax.NewULID(), UserID: kallax.NewULID(), ChosenOption: "A"}
+	handler.SaveVote(vote, nil)
+
+	_, err := handler.ChangeVote(vote.PollID, vote.UserID, "B", nil)
+
+	assert.AssertEqual(t, "Disk full", err.Error())
+	assert.AssertEqual(t, 1, len(store.SaveCalls()))
+	for _, kept := range votes {
+		assert.AssertEqual(t, "A", kept.ChosenOption)
+	}
+}
+
+func TestTallyByPollReadsTheTally(t *testing.T) {
+	pollID := kallax.NewULID()
+	optionID := kallax.NewULID()
+
+	store := &IPollVoteStoreMock{
+		FindTallyFunc: func(ID kallax.ULID) (map[kallax.ULID]int64, error) {
+			return map[kallax.ULID]int64{optionID: 3}, nil
+		},
+	}
+	handler := PollVoteHandlerImpl{
+		Store: store,
+	}
+
+	counts, err := handler.TallyByPoll(pollID)
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, int64(3), counts[optionID])
+	assert.AssertEqual(t, 1, len(store.FindTallyCalls()))
+	assert.AssertEqual(t, pollID, store.FindTallyCalls()[0].PollID)
+	assert.AssertEqual(t, 0, len(store.CountSelectionsByOptionCalls()))
+}
+
+func TestVotesKeepTheTallyUpToDate(t *testing.T) {
+	store, _ := newMemoryPollVoteStore()
+	handler := PollVoteHandlerImpl{
+		Store: store,
+	}
+	a, b, c := kallax.NewULID(), kallax.NewULID(), kallax.NewULID()
+	vote := PollVote{ID: kallax.NewULID(), PollID: kallax.NewULID(), UserID: kallax.NewULID(), ChosenOption: "A"}
+
+	handler.SaveVote(vote, []PollVoteSelection{{OptionID: a}, {OptionID: b}})
+	counts, _ := handler.TallyByPoll(vote.PollID)
+	assert.AssertEqual(t, int64(1), counts[a])
+	assert.AssertEqual(t, int64(1), counts[b])
+
+	handler.ChangeVote(vote.PollID, vote.UserID, "C", []PollVoteSelection{{OptionID: b}, {OptionID: c}})
+	counts, _ = handler.TallyByPoll(vote.PollID)
+	assert.AssertEqual(t, int64(0), counts[a])
+	assert.AssertEqual(t, int64(1), counts[b])
+	assert.AssertEqual(t, int64(1), counts[c])
+
+	handler.RetractVote(vote.PollID, vote.UserID)
+	counts, _ = handler.TallyByPoll(vote.PollID)
+	assert.AssertEqual(t, int64(0), counts[b])
+	assert.AssertEqual(t, int64(0), counts[c])
+}
+
+func TestVotesCountOptionsInTheOrderOfTheirIDs(t *testing.T) {
+	store, _ := newMemoryPollVoteStore()
+	handler := PollVoteHandlerImpl{
+		Store: store,
+	}
+	first, second, third := kallax.ULID{1}, kallax.ULID{2}, kallax.ULID{3}
+	vote := PollVote{ID: kallax.NewULID(), PollID: kallax.NewULID(), UserID: kallax.NewULID(), ChosenOption: "C, A, B"}
+
+	handler.SaveVote(vote, []PollVoteSelection{{OptionID: third}, {OptionID: first}, {OptionID: second}})
+	handler.RetractVote(vote.PollID, vote.UserID)
+
+	counted := make([]kallax.ULID, 0)
+	for _, call := range store.AddToTallyCalls() {
+		counted = append(counted, call.OptionID)
+	}
+	assert.AssertEqual(t, []kallax.ULID{first, second, third, first, second, third}, counted)
+	assert.AssertEqual(t, third, store.SaveSelectionCalls()[0].Record.OptionID)
+	assert.AssertEqual(t, 1, store.SaveSelectionCalls()[0].Record.Rank)
+}
+
+func TestChangeVoteFailsWhenTallyFails(t *testing.T) {
+	store, votes := newMemoryPollVoteStore()
+	handler := PollVoteHandlerImpl{
+		Store: store,
+	}
+	vote := PollVote{ID: kallax.NewULID(), PollID: kallax.NewULID(), UserID: kallax.NewULID(), ChosenOption: "A"}
+	handler.SaveVote(vote, []PollVoteSelection{{OptionID: kallax.NewULID()}})
+	store.AddToTallyFunc = func(pollID kallax.ULID, optionID kallax.ULID, delta int64) error {
+		return fmt.Errorf("Connection reset")
+	}
+
+	_, err := handler.ChangeVote(vote.PollID, vote.UserID, "B", nil)
+
+	assert.AssertEqual(t, "Connection reset", err.Error())
+	assert.AssertEqual(t, 0, len(store.DeleteSelectionsCalls()))
+	for _, kept := range votes {
+		assert.AssertEqual(t, "A", kept.ChosenOption)
+	}
+}
+
+func TestRebuildTallyReplacesItWithTheSelections(t *testing.T) {
+	pollID := kallax.NewULID()
+	counts := map[kallax.ULID]int64{kallax.NewULID(): 4}
+	steps := make([]string, 0)
+
+	store := &IPollVoteStoreMock{
+		LockTallyFunc: func() error {
+			steps = append(steps, "lock")
+			return nil
+		},
+		CountSelectionsByOptionFunc: func(ID kallax.ULID) (map[kallax.ULID]int64, error) {
+			steps = append(steps, "count")
+			return counts, nil
+		},
+		ReplaceTallyFunc: func(ID kallax.ULID, replaced map[kallax.ULID]int64) error {
+			return nil
+		},
+	}
+	store.TransactionFunc = func(callback func(IPollVoteStore) error) error {
+		return callback(store)
+	}
+	handler := PollVoteHandlerImpl{
+		Store: store,
+	}
+
+	err := handler.RebuildTally(pollID)
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, 1, len(store.TransactionCalls()))
+	assert.AssertEqual(t, pollID, store.CountSelectionsByOptionCalls()[0].PollID)
+	assert.AssertEqual(t, pollID, store.ReplaceTallyCalls()[0].PollID)
+	assert.AssertEqual(t, counts, store.ReplaceTallyCalls()[0].Counts)
+	assert.AssertEqual(t, []string{"lock", "count"}, steps)
+}
+
+func TestRebuildTallyKeepsItWhenCountingFails(t *testing.T) {
+	store := &IPollVoteStoreMock{
+		LockTallyFunc: func() error {
+			return nil
+		},
+		CountSelectionsByOptionFunc: func(ID kallax.ULID) (map[kallax.ULID]int64, error) {
+			return nil, fmt.Errorf("Connection reset")
+		},
+	}
+	store.TransactionFunc = func(callback func(IPollVoteStore) error) error {
+		return callback(store)
+	}
+	handler := PollVoteHandlerImpl{
+		Store: store,
+	}
+
+	err := handler.RebuildTally(kallax.NewULID())
+
+	assert.AssertEqual(t, "Connection reset", err.Error())
+	assert.AssertEqual(t, 0, len(store.ReplaceTallyCalls()))
+}
+
+func TestSelectionsForCountsByPollAndOption(t *testing.T) {
+	var sqlExecuted string
+
+	store := &IPollVoteStoreMock{
+		CountSelectionsFunc: func(q *PollVoteSelectionQuery) (int64, error) {
+			sqlExecuted = q.String()
+			return 2, nil
+		},
+	}
+	handler := PollVoteHandlerImpl{
+		Store: store,
+	}
+
+	count, err := handler.SelectionsFor(kallax.NewULID(), kallax.NewULID())
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, int64(2), count)
+	sqlExpected := "SELECT __pollvoteselection.id, __pollvoteselection.created_at, __pollvoteselection.updated_at, " +
+		"__pollvoteselection.vote_id, __pollvoteselection.poll_id, __pollvoteselection.poll_option_id, __pollvoteselection.rank, __pollvoteselection.score " +
+		"FROM poll_vote_selection __pollvoteselection " +
+		"WHERE __pollvoteselection.poll_id = $1 AND __pollvoteselection.poll_option_id = $2"
+	assert.AssertEqual(t, sqlExpected, sqlExecuted)
+}
+
+func TestChangeVoteReplacesSelections(t *testing.T) {
+	store, _ := newMemoryPollVoteStore()
+	handler := PollVoteHandlerImpl{
+		Store: store,
+	}
+	vote := PollVote{ID: kallax.NewULID(), PollID: kallax.NewULID(), UserID: kallax.NewULID(), ChosenOption: "A"}
+	handler.SaveVote(vote, []PollVoteSelection{{OptionID: kallax.NewULID()}})
+
+	_, err := handler.ChangeVote(vote.PollID, vote.UserID, "B, C",
+		[]PollVoteSelection{{OptionID: kallax.NewULID()}, {OptionID: kallax.NewULID()}})
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, 1, len(store.DeleteSelectionsCalls()))
+	assert.AssertEqual(t, vote.ID, store.DeleteSelectionsCalls()[0].VoteID)
+	assert.AssertEqual(t, 3, len(store.SaveSelectionCalls()))
+	assert.AssertEqual(t, vote.ID, store.SaveSelectionCalls()[2].Record.VoteID)
+	assert.AssertEqual(t, vote.PollID, store.SaveSelectionCalls()[2].Record.PollID)
+	assert.AssertEqual(t, 1, store.SaveSelectionCalls()[1].Record.Rank)
+	assert.AssertEqual(t, 2, store.SaveSelectionCalls()[2].Record.Rank)
+}
+
+func TestBallotsOfGroupsRankedSelectionsByVote(t *testing.T) {
+	var sqlExecuted string
+	first, second := kallax.NewULID(), kallax.NewULID()
+	a, b, c := kallax.NewULID(), kallax.NewULID(), kallax.NewULID()
+
+	store := &IPollVoteStoreMock{
+		FindSelectionsFunc: func(q *PollVoteSelectionQuery) ([]*PollVoteSelection, error) {
+			sqlExecuted = q.String()
+			return []*PollVoteSelection{
+				{VoteID: first, OptionID: b, Rank: 1},
+				{VoteID: first, OptionID: a, Rank: 2},
+				{VoteID: second, OptionID: c, Rank: 1},
+			}, nil
+		},
+	}
+	handler := PollVoteHandlerImpl{
+		Store: store,
+	}
+
+	ballots, err := handler.BallotsOf(kallax.NewULID())
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, 2, len(ballots))
+	assert.AssertEqual(t, Ballot{b, a}, ballots[0])
+	assert.AssertEqual(t, Ballot{c}, ballots[1])
+	sqlExpected := "SELECT __pollvoteselection.id, __pollvoteselection.created_at, __pollvoteselection.updated_at, " +
+		"__pollvoteselection.vote_id, __pollvoteselection.poll_id, __pollvoteselection.poll_option_id, __pollvoteselection.rank, __pollvoteselection.score " +
+		"FROM poll_vote_selection __pollvoteselection " +
+		"WHERE __pollvoteselection.poll_id = $1 ORDER BY __pollvoteselection.vote_id ASC, __pollvoteselection.rank ASC"
+	assert.AssertEqual(t, sqlExpected, sqlExecuted)
+}
+
+func TestScoresOfSumsScoresByOption(t *testing.T) {
+	a, b := kallax.NewULID(), kallax.NewULID()
+	store := &IPollVoteStoreMock{
+		FindSelectionsFunc: func(q *PollVoteSelectionQuery) ([]*PollVoteSelection, error) {
+			return []*PollVoteSelection{
+				{OptionID: a, Score: 4},
+				{OptionID: b, Score: 1},
+				{OptionID: a, Score: 5},
+			}, nil
+		},
+	}
+	handler := PollVoteHandlerImpl{
+		Store: store,
+	}
+
+	scores, err := handler.ScoresOf(kallax.NewULID())
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, int64(9), scores[a])
+	assert.AssertEqual(t, int64(1), scores[b])
+}
+
+func TestSaveVoteReferencesFirstChosenOption(t *testing.T) {
+	store, votes := newMemoryPollVoteStore()
+	handler := PollVoteHandlerImpl{
+		Store: store,
+	}
+	first, second := kallax.NewULID(), kallax.NewULID()
+
+	vote := PollVote{ID: kallax.NewULID(), PollID: kallax.NewULID(), UserID: kallax.NewULID()}
+	saved, err := handler.SaveVote(vote, []PollVoteSelection{{OptionID: first}, {OptionID: second}})
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, first, *saved.OptionID)
+
+	changed, err := handler.ChangeVote(vote.PollID, vote.UserID, "B", []PollVoteSelection{{OptionID: second}})
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, second, *changed.OptionID)
+	for _, kept := range votes {
+		assert.AssertEqual(t, second, *kept.OptionID)
+	}
+}
+
+//roundTrip stands for the time a query takes to reach the database and come back.
+const roundTrip = 50 * time.Microsecond
+
+func benchmarkTallyMocks(optionCount int) (*PollOptionHandlerMock, PollVoteHandlerImpl) {
+	options := make([]string, optionCount)
+	for i := range options {
+		options[i] = fmt.Sprintf("Option %d", i)
+	}
+	pollOptions := newOptions(options...)
+
+	store := &IPollVoteStoreMock{
+		CountSelectionsFunc: func(q *PollVoteSelectionQuery) (int64, error) {
+			time.Sleep(roundTrip)
+			return 1, nil
+		},
+		CountSelectionsByOptionFunc: func(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+			time.Sleep(roundTrip)
+			counts := make(map[kallax.ULID]int64)
+			for _, opt := range pollOptions {
+				counts[opt.ID] = 1
+			}
+			return counts, nil
+		},
+		FindTallyFunc: func(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+			time.Sleep(roundTrip)
+			counts := make(map[kallax.ULID]int64)
+			for _, opt := range pollOptions {
+				counts[opt.ID] = 1
+			}
+			return counts, nil
+		},
+		CountFunc: func(q *PollVoteQuery) (int64, error) {
+			time.Sleep(roundTrip)
+			return 1, nil
+		},
+	}
+
+	return createOptionsMock(pollOptions), PollVoteHandlerImpl{Store: store}
+}
+
+func BenchmarkCountVotesPerOption(b *testing.B) {
+	pollOptionHandlerMock, pollVoteHandler := benchmarkTallyMocks(50)
+	pollID := kallax.NewULID()
+
+	for i := 0; i < b.N; i++ {
+		options, _ := pollOptionHandlerMock.FindPollOptions(pollID)
+		tally := VoteTally{Options: options, Selections: make(map[kallax.ULID]int64)}
+		for _, opt := range options {
+			tally.Selections[opt.ID], _ = pollVoteHandler.SelectionsFor(pollID, opt.ID)
+		}
+		tally.Voters, _ = pollVoteHandler.VotersOf(pollID)
+		tally.SelectionShares()
+	}
+}
+
+func BenchmarkCountVotesGroupedByPoll(b *testing.B) {
+	pollOptionHandlerMock, pollVoteHandler := benchmarkTallyMocks(50)
+	pollID := kallax.NewULID()
+
+	for i := 0; i < b.N; i++ {
+		options, _ := pollOptionHandlerMock.FindPollOptions(pollID)
+		counts, _ := pollVoteHandler.Store.CountSelectionsByOption(pollID)
+		voters, _ := pollVoteHandler.VotersOf(pollID)
+		tally := newVoteTally(options, counts, voters)
+		tally.SelectionShares()
+	}
+}
+
+func BenchmarkCountVotesFromTally(b *testing.B) {
+	pollOptionHandlerMock, pollVoteHandler := benchmarkTallyMocks(50)
+	pollID := kallax.NewULID()
+
+	for i := 0; i < b.N; i++ {
+		tally, _ := TallyVotes(pollID, pollOptionHandlerMock, pollVoteHandler)
+		tally.SelectionShares()
+	}
+}
@@ -3,15 +3,22 @@ package app
 import (
 	"database/sql"
 	"log"
+	"time"
 
 	kallax "gopkg.in/src-d/go-kallax.v1"
 )
 
+//DefaultSessionTTL is how long a session lives without being used.
+const DefaultSessionTTL = time.Hour
+
 //SessionHandler ...
 //go:generate moq -out sessionhandler_moq.go . SessionHandler
 type SessionHandler interface {
-	CreateSession(userID kallax.ULID, registeredUser bool) *Session
-	SaveSession(session Session) Session
+	CreateSession(userID kallax.ULID, registeredUser bool) (*Session, error)
+	SaveSession(session Session) (Session, error)
+	FindValidSession(id kallax.ULID) (*Session, error)
+	DeleteSession(id kallax.ULID) error
+	DeleteExpiredSessions() (int64, error)
 }
 
 //ISessionStore ...
@@ -19,38 +26,55 @@ type SessionHandler interface {
 type ISessionStore interface {
 	Save(record *Session) (updated bool, err error)
 	FindOne(q *SessionQuery) (*Session, error)
+	Delete(record *Session) error
+	RawExec(sql string, params ...interface{}) (int64, error)
 }
 
 //SessionHandlerImpl ...
 type SessionHandlerImpl struct {
 	Store ISessionStore
+	TTL   time.Duration
 }
 
 //NewSessionHandler ...
 func NewSessionHandler(db *sql.DB) *SessionHandlerImpl {
 	return &SessionHandlerImpl{
 		Store: NewSessionStore(db),
+		TTL:   DefaultSessionTTL,
 	}
 }
 
+func (h SessionHandlerImpl) ttl() time.Duration {
+	if h.TTL == 0 {
+		return DefaultSessionTTL
+	}
+
+	return h.TTL
+}
+
 //CreateSession ...
-func (h SessionHandlerImpl) CreateSession(userID kallax.ULID, registeredUser bool) *Session {
+func (h SessionHandlerImpl) CreateSession(userID kallax.ULID, registeredUser bool) (*Session, error) {
 	session := Session{
 		ID:             kallax.NewULID(),
 		UserID:         userID,
 		RegisteredUser: registeredUser,
+		ExpiresAt:      time.Now().Add(h.ttl()),
+	}
+
+	saved, err := h.SaveSession(session)
+	if err != nil {
+		return nil, err
 	}
-	h.SaveSession(session)
 
-	return &session
+	return &saved, nil
 }
 
 //SaveSession ...
-func (h SessionHandlerImpl) SaveSession(session Session) Session {
+func (h SessionHandlerImpl) SaveSession(session Session) (Session, error) {
 	log.Println("Saving Session", session)
 
-	h.Store.Save(&session)
-	return session
+	_, err := h.Store.Save(&session)
+	return session, err
 }
 
 // FindSessionByID ...
@@ -58,3 +82,63 @@ func (h SessionHandlerImpl) FindSessionByID(id kallax.ULID) (*Session, error) {
 	query := NewSessionQuery().FindByID(id)
 	return h.Store.FindOne(query)
 }
+
+//FindValidSession finds the session and, when it is still alive, slides its expiration.
+func (h SessionHandlerImpl) FindValidSession(id kallax.ULID) (*Session, error) {
+	session, err := h.FindSessionByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if session.IsExpired(now) {
+		return nil, ErrSessionExpired("Session expired. Must log in again.")
+	}
+
+	session.ExpiresAt = now.Add(h.ttl())
+	if _, err := h.Store.Save(session); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+//DeleteSession ...
+func (h SessionHandlerImpl) DeleteSession(id kallax.ULID) error {
+	log.Println("Removing Session", id)
+
+	session, err := h.FindSessionByID(id)
+	if err != nil {
+		return err
+	}
+
+	return h.Store.Delete(session)
+}
+
+//DeleteExpiredSessions ...
+func (h SessionHandlerImpl) DeleteExpiredSessions() (int64, error) {
+	return h.Store.RawExec("DELETE FROM poll_session WHERE expires_at <= $1", time.Now())
+}
+
+//SweepExpiredSessions removes the expired sessions every interval until done is closed.
+func SweepExpiredSessions(handler SessionHandler, interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			removed, err := handler.DeleteExpiredSessions()
+			if err != nil {
+				log.Println("Unable to remove expired sessions", err)
+				continue
+			}
+
+			if removed > 0 {
+				log.Println("Expired sessions removed:", removed)
+			}
+		}
+	}
+}
@@ -1,7 +1,12 @@
 package app
 
 import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/chai2010/assert"
 
@@ -19,13 +24,31 @@ func TestCreateSession(t *testing.T) {
 	}
 
 	userID := kallax.NewULID()
-	session := handler.CreateSession(userID, true)
+	session, err := handler.CreateSession(userID, true)
 
+	assert.AssertNil(t, err)
 	assert.AssertEqual(t, userID, session.UserID)
 	assert.AssertTrue(t, session.RegisteredUser)
+	assert.AssertTrue(t, session.ExpiresAt.After(time.Now()))
 	assert.AssertEqual(t, 1, len(store.SaveCalls()))
 }
 
+func TestCreateSessionFailWhenStoreFail(t *testing.T) {
+	store := &ISessionStoreMock{
+		SaveFunc: func(session *Session) (bool, error) {
+			return false, fmt.Errorf("Connection reset")
+		},
+	}
+	handler := SessionHandlerImpl{
+		Store: store,
+	}
+
+	session, err := handler.CreateSession(kallax.NewULID(), false)
+
+	assert.AssertNil(t, session)
+	assert.AssertEqual(t, "Connection reset", err.Error())
+}
+
 func TestFindSessionByID(t *testing.T) {
 	var sqlExecuted string
 
@@ -45,7 +68,164 @@ func TestFindSessionByID(t *testing.T) {
 	assert.AssertNotNil(t, session)
 	assert.AssertNil(t, err)
 	assert.AssertEqual(t, 1, len(store.FindOneCalls()))
-	sqlExpected := "SELECT __session.id, __session.created_at, __session.updated_at, __session.user_id, __session.registered_user " +
+	sqlExpected := "SELECT __session.id, __session.created_at, __session.updated_at, __session.user_id, __session.registered_user, __session.expires_at " +
 		"FROM poll_session __session WHERE __session.id IN ($1)"
 	assert.AssertEqual(t, sqlExpected, sqlExecuted)
 }
+
+func TestFindValidSessionSlidesExpiration(t *testing.T) {
+	almostExpired := time.Now().Add(time.Minute)
+	store := &ISessionStoreMock{
+		FindOneFunc: func(q *SessionQuery) (*Session, error) {
+			return &Session{ExpiresAt: almostExpired}, nil
+		},
+		SaveFunc: func(session *Session) (bool, error) {
+			return true, nil
+		},
+	}
+
+	handler := SessionHandlerImpl{
+		Store: store,
+		TTL:   2 * time.Hour,
+	}
+
+	session, err := handler.FindValidSession(kallax.NewULID())
+
+	assert.AssertNil(t, err)
+	assert.AssertTrue(t, session.ExpiresAt.After(time.Now().Add(time.Hour)))
+	assert.AssertEqual(t, 1, len(store.SaveCalls()))
+}
+
+func TestFindValidSessionRejectsExpired(t *testing.T) {
+	store := &ISessionStoreMock{
+		FindOneFunc: func(q *SessionQuery) (*Session, error) {
+			return &Session{ExpiresAt: time.Now().Add(-time.Minute)}, nil
+		},
+	}
+
+	handler := SessionHandlerImpl{
+		Store: store,
+	}
+
+	session, err := handler.FindValidSession(kallax.NewULID())
+
+	assert.AssertNil(t, session)
+	assert.AssertEqual(t, "Session expired. Must log in again.", err.Error())
+	assert.AssertEqual(t, 0, len(store.SaveCalls()))
+}
+
+func TestFindValidSessionWhenNotFound(t *testing.T) {
+	store := &ISessionStoreMock{
+		FindOneFunc: func(q *SessionQuery) (*Session, error) {
+			return nil, kallax.ErrNotFound
+		},
+	}
+
+	handler := SessionHandlerImpl{
+		Store: store,
+	}
+
+	session, err := handler.FindValidSession(kallax.NewULID())
+
+	assert.AssertNil(t, session)
+	assert.AssertEqual(t, kallax.ErrNotFound, err)
+}
+
+func TestDeleteSession(t *testing.T) {
+	found := &Session{}
+	var deleted *Session
+	store := &ISessionStoreMock{
+		FindOneFunc: func(q *SessionQuery) (*Session, error) {
+			return found, nil
+		},
+		DeleteFunc: func(record *Session) error {
+			deleted = record
+			return nil
+		},
+	}
+
+	handler := SessionHandlerImpl{
+		Store: store,
+	}
+
+	err := handler.DeleteSession(kallax.NewULID())
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, found, deleted)
+}
+
+func TestDeleteExpiredSessions(t *testing.T) {
+	var sqlExecuted string
+	store := &ISessionStoreMock{
+		RawExecFunc: func(sql string, params ...interface{}) (int64, error) {
+			sqlExecuted = sql
+			return 3, nil
+		},
+	}
+
+	handler := SessionHandlerImpl{
+		Store: store,
+	}
+
+	removed, err := handler.DeleteExpiredSessions()
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, int64(3), removed)
+	assert.AssertEqual(t, "DELETE FROM poll_session WHERE expires_at <= $1", sqlExecuted)
+}
+
+func TestSweepExpiredSessions(t *testing.T) {
+	swept := make(chan struct{}, 1)
+	handlerMock := &SessionHandlerMock{
+		DeleteExpiredSessionsFunc: func() (int64, error) {
+			select {
+			case swept <- struct{}{}:
+			default:
+			}
+			return 0, fmt.Errorf("Database is gone")
+		},
+	}
+
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		SweepExpiredSessions(handlerMock, time.Millisecond, done)
+		close(finished)
+	}()
+
+	<-swept
+	close(done)
+	<-finished
+
+	assert.AssertTrue(t, len(handlerMock.DeleteExpiredSessionsCalls()) > 0)
+}
+
+func TestSweepExpiredSessionsLogsOnlyWhenSessionsWereRemoved(t *testing.T) {
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	swept := make(chan struct{}, 1)
+	handlerMock := &SessionHandlerMock{
+		DeleteExpiredSessionsFunc: func() (int64, error) {
+			select {
+			case swept <- struct{}{}:
+			default:
+			}
+			return 0, nil
+		},
+	}
+
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		SweepExpiredSessions(handlerMock, time.Millisecond, done)
+		close(finished)
+	}()
+
+	<-swept
+	close(done)
+	<-finished
+
+	assert.AssertEqual(t, "", logged.String())
+}
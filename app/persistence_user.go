@@ -1,23 +1,23 @@
 package app
 
 import (
-	"crypto/md5"
 	"database/sql"
-	"encoding/hex"
-	"fmt"
 	"log"
 
 	kallax "gopkg.in/src-d/go-kallax.v1"
 )
 
+const userLoginIndex = "poll_user_login_idx"
+
 //UserHandler ...
 //go:generate moq -out userhandler_moq.go . UserHandler
 type UserHandler interface {
 	CreateUserFromData(d *UserCreationData) (User, error)
-	SaveUser(user User) User
+	SaveUser(user User, events ...Event) (User, error)
 	FindUserByLogin(login string) (*User, error)
 	FindUserByID(ID kallax.ULID) (*User, error)
-	CreateAnonUser() User
+	CreateAnonUser() (User, error)
+	ClaimAnonUser(ID kallax.ULID, d *UserCreationData, events ...Event) (*User, error)
 	FindUserByLoginAndPassword(login, password string) (*User, error)
 }
 
@@ -26,30 +26,56 @@ type UserHandler interface {
 type IUserStore interface {
 	Save(record *User) (updated bool, err error)
 	FindOne(q *UserQuery) (*User, error)
+	Transaction(callback func(IUserStore) error) error
+	SaveOutbox(record *PollOutbox) error
+}
+
+//txUserStore adapts UserStore.Transaction to IUserStore.
+type txUserStore struct {
+	*UserStore
+}
+
+//Transaction ...
+func (s txUserStore) Transaction(callback func(IUserStore) error) error {
+	return s.UserStore.Transaction(func(tx *UserStore) error {
+		return callback(txUserStore{tx})
+	})
+}
+
+//SaveOutbox ...
+func (s txUserStore) SaveOutbox(record *PollOutbox) error {
+	return saveOutbox(s.GenericStore(), record)
 }
 
 //UserHandlerImpl ...
 type UserHandlerImpl struct {
-	Store IUserStore
+	Store  IUserStore
+	Hasher PasswordHasher
 }
 
 //NewUserHandler ...
 func NewUserHandler(db *sql.DB) *UserHandlerImpl {
 	return &UserHandlerImpl{
-		Store: NewUserStore(db),
+		Store:  txUserStore{NewUserStore(db)},
+		Hasher: DefaultPasswordHasher,
 	}
 }
 
+func (handler *UserHandlerImpl) passwordHasher() PasswordHasher {
+	if handler.Hasher == nil {
+		return DefaultPasswordHasher
+	}
+
+	return handler.Hasher
+}
+
 //CreateUserFromData ...
 func (handler *UserHandlerImpl) CreateUserFromData(d *UserCreationData) (User, error) {
-	if d.Password != d.PasswordConfirm {
-		return User{}, ErrPasswordDoNotMatch("Passwords don't match")
+	encryptedPassword, err := handler.prepareRegistration(d)
+	if err != nil {
+		return User{}, err
 	}
 
-	hasher := md5.New()
-	hasher.Write([]byte(d.Password))
-	encryptedPassword := hex.EncodeToString(hasher.Sum(nil))
-
 	user := User{
 		ID:       kallax.NewULID(),
 		Login:    d.Login,
@@ -59,13 +85,82 @@ func (handler *UserHandlerImpl) CreateUserFromData(d *UserCreationData) (User, e
 	return user, nil
 }
 
-//SaveUser ...
-func (handler *UserHandlerImpl) SaveUser(user User) User {
+//ClaimAnonUser turns the anonymous user into a registered one. The ID is kept,
+//so everything the visitor did before registering stays attached to the account.
+//The events are recorded in the outbox with the user.
+func (handler *UserHandlerImpl) ClaimAnonUser(ID kallax.ULID, d *UserCreationData, events ...Event) (*User, error) {
+	user, err := handler.FindUserByID(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsRegistered() {
+		return nil, ErrConflict("User already registered.")
+	}
+
+	encryptedPassword, err := handler.prepareRegistration(d)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Login = d.Login
+	user.Name = d.Name
+	user.Password = encryptedPassword
+
+	saved, err := handler.SaveUser(*user, events...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+func (handler *UserHandlerImpl) prepareRegistration(d *UserCreationData) (string, error) {
+	if err := ValidateUserCreationData(d); err != nil {
+		return "", err
+	}
+
+	if d.Password != d.PasswordConfirm {
+		return "", ErrPasswordDoNotMatch("Passwords don't match")
+	}
+
+	if err := handler.checkLoginAvailable(d.Login); err != nil {
+		return "", err
+	}
+
+	return EncodePassword(handler.passwordHasher(), d.Password)
+}
+
+func (handler *UserHandlerImpl) checkLoginAvailable(login string) error {
+	_, err := handler.FindUserByLogin(login)
+
+	if err == kallax.ErrNotFound {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return ErrLoginTaken("Login already taken.")
+}
+
+//SaveUser saves the user and records the events in the outbox in the same transaction.
+func (handler *UserHandlerImpl) SaveUser(user User, events ...Event) (User, error) {
 	log.Println("Saving User", user)
 
-	handler.Store.Save(&user)
+	err := handler.Store.Transaction(func(store IUserStore) error {
+		if _, err := store.Save(&user); err != nil {
+			return err
+		}
 
-	return user
+		return recordEvents(store, events)
+	})
+	if violatesConstraint(err, userLoginIndex) {
+		return user, ErrLoginTaken("Login already taken.")
+	}
+
+	return user, err
 }
 
 //FindUserByLogin ...
@@ -81,29 +176,49 @@ func (handler *UserHandlerImpl) FindUserByID(ID kallax.ULID) (*User, error) {
 }
 
 //CreateAnonUser ...
-func (handler *UserHandlerImpl) CreateAnonUser() User {
+func (handler *UserHandlerImpl) CreateAnonUser() (User, error) {
 	user := User{
 		ID: kallax.NewULID(),
 	}
 	user.Name = "Anon" + user.ID.String()
 	user.Login = user.Name
 
-	handler.SaveUser(user)
-
-	return user
+	return handler.SaveUser(user)
 }
 
 //FindUserByLoginAndPassword ...
 func (handler *UserHandlerImpl) FindUserByLoginAndPassword(login, password string) (*User, error) {
-	query := NewUserQuery().
-		FindByLogin(login).
-		FindByPassword(password)
+	user, err := handler.FindUserByLogin(login)
 
-	user, err := handler.Store.FindOne(query)
+	if err == kallax.ErrNotFound {
+		return nil, ErrUserNotLogged("User and password invalid")
+	}
 
 	if err != nil {
-		return nil, fmt.Errorf("User and password invalid")
+		return nil, err
+	}
+
+	valid, outdated := VerifyPassword(handler.passwordHasher(), user.Password, password)
+	if !valid {
+		return nil, ErrUserNotLogged("User and password invalid")
+	}
+
+	if outdated {
+		handler.upgradePassword(user, password)
 	}
 
 	return user, nil
 }
+
+func (handler *UserHandlerImpl) upgradePassword(user *User, password string) {
+	encryptedPassword, err := EncodePassword(handler.passwordHasher(), password)
+	if err != nil {
+		log.Println("Unable to upgrade password of user", user.ID, err)
+		return
+	}
+
+	user.Password = encryptedPassword
+	if _, err := handler.SaveUser(*user); err != nil {
+		log.Println("Unable to upgrade password of user", user.ID, err)
+	}
+}
@@ -2,21 +2,43 @@ package app
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"gopkg.in/src-d/go-kallax.v1"
 
 	"github.com/chai2010/assert"
+	"github.com/lib/pq"
 )
 
+func newLoginFreeUserStoreMock() *IUserStoreMock {
+	return &IUserStoreMock{
+		FindOneFunc: func(q *UserQuery) (*User, error) {
+			return nil, kallax.ErrNotFound
+		},
+	}
+}
+
+func joinUserTransactions(store *IUserStoreMock) {
+	store.TransactionFunc = func(callback func(IUserStore) error) error {
+		return callback(store)
+	}
+	store.SaveOutboxFunc = func(record *PollOutbox) error {
+		return nil
+	}
+}
+
 func TestCreateUserFromData(t *testing.T) {
-	handler := UserHandlerImpl{}
+	userStoreMock := newLoginFreeUserStoreMock()
+	handler := UserHandlerImpl{
+		Store: userStoreMock,
+	}
 
 	data := &UserCreationData{
 		Login:           "phineas@disney.com",
 		Name:            "Phineas Flynn",
-		Password:        "summer",
-		PasswordConfirm: "summer",
+		Password:        "summer2019",
+		PasswordConfirm: "summer2019",
 	}
 
 	user, err := handler.CreateUserFromData(data)
@@ -24,7 +46,31 @@ func TestCreateUserFromData(t *testing.T) {
 	assert.AssertNil(t, err)
 	assert.AssertEqual(t, "phineas@disney.com", user.Login)
 	assert.AssertEqual(t, "Phineas Flynn", user.Name)
-	assert.AssertEqual(t, "6b1628b016dff46e6fa35684be6acc96", user.Password)
+	assert.AssertMatchString(t, "^bcrypt\\$", user.Password)
+
+	valid, outdated := VerifyPassword(DefaultPasswordHasher, user.Password, "summer2019")
+	assert.AssertTrue(t, valid)
+	assert.AssertFalse(t, outdated)
+	assert.AssertEqual(t, 1, len(userStoreMock.FindOneCalls()))
+}
+
+func TestCreateUserFromDataWithConfiguredHasher(t *testing.T) {
+	handler := UserHandlerImpl{
+		Store:  newLoginFreeUserStoreMock(),
+		Hasher: NewArgon2idHasher(),
+	}
+
+	data := &UserCreationData{
+		Login:           "ferb@disney.com",
+		Name:            "Ferb Fletcher",
+		Password:        "summer2019",
+		PasswordConfirm: "summer2019",
+	}
+
+	user, err := handler.CreateUserFromData(data)
+
+	assert.AssertNil(t, err)
+	assert.AssertMatchString(t, "^argon2id\\$", user.Password)
 }
 
 func TestShouldCreateUserWhenPasswordNotConfirmed(t *testing.T) {
@@ -33,8 +79,8 @@ func TestShouldCreateUserWhenPasswordNotConfirmed(t *testing.T) {
 	data := &UserCreationData{
 		Login:           "phineas@disney.com",
 		Name:            "Phineas Flynn",
-		Password:        "summer",
-		PasswordConfirm: "winter",
+		Password:        "summer2019",
+		PasswordConfirm: "winter2019",
 	}
 
 	user, err := handler.CreateUserFromData(data)
@@ -46,23 +92,166 @@ func TestShouldCreateUserWhenPasswordNotConfirmed(t *testing.T) {
 	assert.AssertEqual(t, "", user.Password)
 }
 
+func TestShouldNotCreateUserWithTakenLogin(t *testing.T) {
+	var sqlExecuted string
+
+	userStoreMock := &IUserStoreMock{
+		FindOneFunc: func(q *UserQuery) (*User, error) {
+			sqlExecuted = q.String()
+			return &User{Login: "phineas@disney.com"}, nil
+		},
+	}
+	handler := UserHandlerImpl{
+		Store: userStoreMock,
+	}
+
+	data := &UserCreationData{
+		Login:           "phineas@disney.com",
+		Password:        "summer2019",
+		PasswordConfirm: "summer2019",
+	}
+
+	_, err := handler.CreateUserFromData(data)
+
+	assert.AssertEqual(t, "Login already taken.", err.Error())
+	assert.AssertEqual(t, CodeLoginTaken, err.(CodedError).Code())
+	assert.AssertEqual(t, 409, err.(CodedError).Status())
+	sql := "SELECT __user.id, __user.created_at, __user.updated_at, __user.login, __user.name, __user.password " +
+		"FROM poll_user __user WHERE __user.login = $1"
+	assert.AssertEqual(t, sql, sqlExecuted)
+}
+
+func TestShouldNotCreateUserWhenLoginCheckFail(t *testing.T) {
+	userStoreMock := &IUserStoreMock{
+		FindOneFunc: func(q *UserQuery) (*User, error) {
+			return nil, fmt.Errorf("Connection reset")
+		},
+	}
+	handler := UserHandlerImpl{
+		Store: userStoreMock,
+	}
+
+	data := &UserCreationData{
+		Login:           "phineas@disney.com",
+		Password:        "summer2019",
+		PasswordConfirm: "summer2019",
+	}
+
+	_, err := handler.CreateUserFromData(data)
+
+	assert.AssertEqual(t, "Connection reset", err.Error())
+}
+
+func TestShouldNotCreateInvalidUser(t *testing.T) {
+	userStoreMock := newLoginFreeUserStoreMock()
+	handler := UserHandlerImpl{
+		Store: userStoreMock,
+	}
+
+	data := &UserCreationData{
+		Login:           "phineas",
+		Password:        "summer",
+		PasswordConfirm: "summer",
+	}
+
+	_, err := handler.CreateUserFromData(data)
+
+	invalid := err.(ErrInvalidFields)
+	assert.AssertEqual(t, "must be an e-mail address", invalid["login"])
+	assert.AssertEqual(t, "must have at least 8 characters", invalid["password"])
+	assert.AssertEqual(t, 0, len(userStoreMock.FindOneCalls()))
+}
+
+func TestSaveUserWithDuplicatedLogin(t *testing.T) {
+	userStoreMock := &IUserStoreMock{
+		SaveFunc: func(record *User) (bool, error) {
+			return false, &pq.Error{Code: "23505", Message: `duplicate key value violates unique constraint "poll_user_login_idx"`, Constraint: "poll_user_login_idx"}
+		},
+	}
+	joinUserTransactions(userStoreMock)
+	handler := UserHandlerImpl{
+		Store: userStoreMock,
+	}
+
+	_, err := handler.SaveUser(User{Login: "phineas@disney.com"})
+
+	assert.AssertEqual(t, CodeLoginTaken, err.(CodedError).Code())
+}
+
 func TestSave(t *testing.T) {
 	userStoreMock := &IUserStoreMock{
 		SaveFunc: func(record *User) (bool, error) {
 			return true, nil
 		},
 	}
+	joinUserTransactions(userStoreMock)
 	handler := UserHandlerImpl{
 		Store: userStoreMock,
 	}
 
 	user := User{}
-	savedUser := handler.SaveUser(user)
+	savedUser, err := handler.SaveUser(user)
 
+	assert.AssertNil(t, err)
 	assert.AssertEqual(t, user, savedUser)
 	assert.AssertEqual(t, 1, len(userStoreMock.SaveCalls()))
 }
 
+func TestSaveUserRecordsEventsInTheSameTransaction(t *testing.T) {
+	userStoreMock := &IUserStoreMock{
+		SaveFunc: func(record *User) (bool, error) {
+			return false, nil
+		},
+	}
+	joinUserTransactions(userStoreMock)
+	handler := UserHandlerImpl{
+		Store: userStoreMock,
+	}
+	user := User{ID: kallax.NewULID(), Login: "phineas@disney.com"}
+
+	_, err := handler.SaveUser(user, UserRegisteredEvent{UserID: user.ID, Login: user.Login})
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, 1, len(userStoreMock.TransactionCalls()))
+	record := userStoreMock.SaveOutboxCalls()[0].Record
+	assert.AssertEqual(t, EventUserRegistered, record.Event)
+	event, _ := DecodeOutboxEvent(record)
+	assert.AssertEqual(t, user.ID, event.(UserRegisteredEvent).UserID)
+}
+
+func TestSaveUserRecordsNoEventWhenStoreFail(t *testing.T) {
+	userStoreMock := &IUserStoreMock{
+		SaveFunc: func(record *User) (bool, error) {
+			return false, fmt.Errorf("Disk full")
+		},
+	}
+	joinUserTransactions(userStoreMock)
+	handler := UserHandlerImpl{
+		Store: userStoreMock,
+	}
+
+	_, err := handler.SaveUser(User{}, UserRegisteredEvent{})
+
+	assert.AssertEqual(t, "Disk full", err.Error())
+	assert.AssertEqual(t, 0, len(userStoreMock.SaveOutboxCalls()))
+}
+
+func TestSaveFailWhenStoreFail(t *testing.T) {
+	userStoreMock := &IUserStoreMock{
+		SaveFunc: func(record *User) (bool, error) {
+			return false, fmt.Errorf("Disk full")
+		},
+	}
+	joinUserTransactions(userStoreMock)
+	handler := UserHandlerImpl{
+		Store: userStoreMock,
+	}
+
+	_, err := handler.SaveUser(User{})
+
+	assert.AssertEqual(t, "Disk full", err.Error())
+}
+
 func TestFindUserByLogin(t *testing.T) {
 	var sqlExecuted string
 
@@ -116,12 +305,14 @@ func TestCreateAnonUser(t *testing.T) {
 			return true, nil
 		},
 	}
+	joinUserTransactions(userStoreMock)
 	handler := UserHandlerImpl{
 		Store: userStoreMock,
 	}
 
-	savedUser := handler.CreateAnonUser()
+	savedUser, err := handler.CreateAnonUser()
 
+	assert.AssertNil(t, err)
 	assert.AssertTrue(t, saved)
 	assert.AssertNotNil(t, savedUser)
 	assert.AssertMatchString(t, "Anon[0-9a-fA-F]{8}\\-[0-9a-fA-F]{4}\\-[0-9a-fA-F]{4}\\-[0-9a-fA-F]{4}\\-[0-9a-fA-F]{12}", savedUser.Login)
@@ -130,10 +321,11 @@ func TestCreateAnonUser(t *testing.T) {
 func TestFindUserByLoginAndPassword(t *testing.T) {
 	var sqlExecuted string
 
+	encoded, _ := EncodePassword(DefaultPasswordHasher, "dumb")
 	userStoreMock := &IUserStoreMock{
 		FindOneFunc: func(q *UserQuery) (*User, error) {
 			sqlExecuted = q.String()
-			return &User{}, nil
+			return &User{Password: encoded}, nil
 		},
 	}
 	handler := UserHandlerImpl{
@@ -144,9 +336,10 @@ func TestFindUserByLoginAndPassword(t *testing.T) {
 
 	assert.AssertNotNil(t, result)
 	assert.AssertNil(t, err)
+	assert.AssertEqual(t, 0, len(userStoreMock.SaveCalls()))
 	sql := "SELECT __user.id, __user.created_at, __user.updated_at, __user.login, __user.name, __user.password " +
 		"FROM poll_user __user " +
-		"WHERE __user.login = $1 AND __user.password = $2"
+		"WHERE __user.login = $1"
 	assert.AssertEqual(t, sql, sqlExecuted)
 }
 
@@ -156,7 +349,7 @@ func TestNotFindUserByLoginAndPassword(t *testing.T) {
 	userStoreMock := &IUserStoreMock{
 		FindOneFunc: func(q *UserQuery) (*User, error) {
 			sqlExecuted = q.String()
-			return nil, fmt.Errorf("Christmas Tree")
+			return nil, kallax.ErrNotFound
 		},
 	}
 	handler := UserHandlerImpl{
@@ -170,6 +363,161 @@ func TestNotFindUserByLoginAndPassword(t *testing.T) {
 	assert.AssertEqual(t, err.Error(), "User and password invalid")
 	sql := "SELECT __user.id, __user.created_at, __user.updated_at, __user.login, __user.name, __user.password " +
 		"FROM poll_user __user " +
-		"WHERE __user.login = $1 AND __user.password = $2"
+		"WHERE __user.login = $1"
 	assert.AssertEqual(t, sql, sqlExecuted)
 }
+
+func TestFindUserByLoginAndPasswordFailWhenStoreFail(t *testing.T) {
+	userStoreMock := &IUserStoreMock{
+		FindOneFunc: func(q *UserQuery) (*User, error) {
+			return nil, fmt.Errorf("Christmas Tree")
+		},
+	}
+	handler := UserHandlerImpl{
+		Store: userStoreMock,
+	}
+
+	result, err := handler.FindUserByLoginAndPassword("chuck.pierce@breakdown.com", "dumb")
+
+	assert.AssertEqual(t, result, nil)
+	assert.AssertEqual(t, "Christmas Tree", err.Error())
+}
+
+func TestNotFindUserByLoginAndWrongPassword(t *testing.T) {
+	encoded, _ := EncodePassword(DefaultPasswordHasher, "dumb")
+	userStoreMock := &IUserStoreMock{
+		FindOneFunc: func(q *UserQuery) (*User, error) {
+			return &User{Password: encoded}, nil
+		},
+	}
+	handler := UserHandlerImpl{
+		Store: userStoreMock,
+	}
+
+	result, err := handler.FindUserByLoginAndPassword("chuck.pierce@breakdown.com", "dumber")
+
+	assert.AssertEqual(t, result, nil)
+	assert.AssertEqual(t, err.Error(), "User and password invalid")
+	assert.AssertEqual(t, CodeUnauthenticated, err.(CodedError).Code())
+}
+
+func TestNotFindAnonUserByLoginAndPassword(t *testing.T) {
+	userStoreMock := &IUserStoreMock{
+		FindOneFunc: func(q *UserQuery) (*User, error) {
+			return &User{Login: "Anon", Password: ""}, nil
+		},
+	}
+	handler := UserHandlerImpl{
+		Store: userStoreMock,
+	}
+
+	result, err := handler.FindUserByLoginAndPassword("Anon", "")
+
+	assert.AssertEqual(t, result, nil)
+	assert.AssertEqual(t, err.Error(), "User and password invalid")
+}
+
+func TestFindUserByLoginAndPasswordUpgradesLegacyHash(t *testing.T) {
+	var savedUser *User
+
+	userStoreMock := &IUserStoreMock{
+		FindOneFunc: func(q *UserQuery) (*User, error) {
+			return &User{Password: "6b1628b016dff46e6fa35684be6acc96"}, nil
+		},
+		SaveFunc: func(record *User) (bool, error) {
+			savedUser = record
+			return true, nil
+		},
+	}
+	joinUserTransactions(userStoreMock)
+	handler := UserHandlerImpl{
+		Store: userStoreMock,
+	}
+
+	result, err := handler.FindUserByLoginAndPassword("phineas@disney.com", "summer")
+
+	assert.AssertNil(t, err)
+	assert.AssertNotNil(t, result)
+	assert.AssertEqual(t, 1, len(userStoreMock.SaveCalls()))
+	assert.AssertMatchString(t, "^bcrypt\\$", savedUser.Password)
+	assert.AssertEqual(t, savedUser.Password, result.Password)
+}
+
+func TestClaimAnonUser(t *testing.T) {
+	anonID := kallax.NewULID()
+	var savedUser *User
+
+	userStoreMock := &IUserStoreMock{
+		FindOneFunc: func(q *UserQuery) (*User, error) {
+			if strings.Contains(q.String(), "WHERE __user.login") {
+				return nil, kallax.ErrNotFound
+			}
+
+			return &User{ID: anonID, Login: "Anon" + anonID.String()}, nil
+		},
+		SaveFunc: func(record *User) (bool, error) {
+			savedUser = record
+			return true, nil
+		},
+	}
+	joinUserTransactions(userStoreMock)
+	handler := UserHandlerImpl{
+		Store: userStoreMock,
+	}
+
+	data := &UserCreationData{
+		Login:           "isabella@disney.com",
+		Name:            "Isabella",
+		Password:        "fireside7",
+		PasswordConfirm: "fireside7",
+	}
+
+	user, err := handler.ClaimAnonUser(anonID, data, UserRegisteredEvent{UserID: anonID, Login: data.Login})
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, EventUserRegistered, userStoreMock.SaveOutboxCalls()[0].Record.Event)
+	assert.AssertEqual(t, anonID, user.ID)
+	assert.AssertEqual(t, "isabella@disney.com", user.Login)
+	assert.AssertTrue(t, user.IsRegistered())
+	assert.AssertEqual(t, anonID, savedUser.ID)
+	assert.AssertEqual(t, 2, len(userStoreMock.FindOneCalls()))
+}
+
+func TestShouldNotClaimRegisteredUser(t *testing.T) {
+	userStoreMock := &IUserStoreMock{
+		FindOneFunc: func(q *UserQuery) (*User, error) {
+			return &User{Login: "isabella@disney.com", Password: "bcrypt$hash"}, nil
+		},
+	}
+	handler := UserHandlerImpl{
+		Store: userStoreMock,
+	}
+
+	user, err := handler.ClaimAnonUser(kallax.NewULID(), &UserCreationData{})
+
+	assert.AssertNil(t, user)
+	assert.AssertEqual(t, CodeConflict, err.(CodedError).Code())
+	assert.AssertEqual(t, 0, len(userStoreMock.SaveCalls()))
+}
+
+func TestShouldNotClaimAnonUserWithTakenLogin(t *testing.T) {
+	userStoreMock := &IUserStoreMock{
+		FindOneFunc: func(q *UserQuery) (*User, error) {
+			return &User{}, nil
+		},
+	}
+	handler := UserHandlerImpl{
+		Store: userStoreMock,
+	}
+
+	data := &UserCreationData{
+		Login:           "isabella@disney.com",
+		Password:        "fireside7",
+		PasswordConfirm: "fireside7",
+	}
+
+	_, err := handler.ClaimAnonUser(kallax.NewULID(), data)
+
+	assert.AssertEqual(t, CodeLoginTaken, err.(CodedError).Code())
+	assert.AssertEqual(t, 0, len(userStoreMock.SaveCalls()))
+}
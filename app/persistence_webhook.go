@@ -0,0 +1,177 @@
+package app
+
+import (
+	"database/sql"
+	"time"
+
+	"gopkg.in/src-d/go-kallax.v1"
+)
+
+//webhookDeliveryBatch is how many due deliveries the worker takes at a time.
+const webhookDeliveryBatch = 100
+
+//webhookDeliveryLease is how long a worker keeps the deliveries it took. It must outlast
+//posting a whole batch, one after the other, each up to the timeout of the client.
+const webhookDeliveryLease = 30 * time.Minute
+
+const webhookDeliveryEventIndex = "poll_webhook_delivery_event_idx"
+
+//WebhookHandler ...
+//go:generate moq -out webhookhandler_moq.go . WebhookHandler
+type WebhookHandler interface {
+	SaveWebhook(webhook PollWebhook) (PollWebhook, error)
+	FindWebhook(ID kallax.ULID) (*PollWebhook, error)
+	FindWebhooks(pollID kallax.ULID) ([]*PollWebhook, error)
+	ReachThreshold(webhookID kallax.ULID, delivery PollWebhookDelivery) (bool, error)
+	SaveDelivery(delivery PollWebhookDelivery) (PollWebhookDelivery, error)
+	ClaimDueDeliveries(now time.Time) ([]*PollWebhookDelivery, error)
+}
+
+//IPollWebhookStore ...
+//go:generate moq -out ipollwebhookstore_moq.go . IPollWebhookStore
+type IPollWebhookStore interface {
+	Save(record *PollWebhook) (updated bool, err error)
+	FindOne(q *PollWebhookQuery) (*PollWebhook, error)
+	FindAll(q *PollWebhookQuery) ([]*PollWebhook, error)
+	RawExec(sql string, params ...interface{}) (int64, error)
+	SaveDelivery(record *PollWebhookDelivery) error
+	FindDeliveries(q *PollWebhookDeliveryQuery) ([]*PollWebhookDelivery, error)
+	ClaimDeliveries(now time.Time, leaseUntil time.Time, limit int) ([]kallax.ULID, error)
+	Transaction(callback func(IPollWebhookStore) error) error
+}
+
+//deliveryPollWebhookStore adds the deliveries to PollWebhookStore.
+type deliveryPollWebhookStore struct {
+	*PollWebhookStore
+}
+
+//Transaction ...
+func (s deliveryPollWebhookStore) Transaction(callback func(IPollWebhookStore) error) error {
+	return s.PollWebhookStore.Transaction(func(tx *PollWebhookStore) error {
+		return callback(deliveryPollWebhookStore{tx})
+	})
+}
+
+//SaveDelivery ...
+func (s deliveryPollWebhookStore) SaveDelivery(record *PollWebhookDelivery) error {
+	_, err := (&PollWebhookDeliveryStore{s.GenericStore()}).Save(record)
+	return err
+}
+
+//FindDeliveries ...
+func (s deliveryPollWebhookStore) FindDeliveries(q *PollWebhookDeliveryQuery) ([]*PollWebhookDelivery, error) {
+	return (&PollWebhookDeliveryStore{s.GenericStore()}).FindAll(q)
+}
+
+const claimDeliveriesQuery = `UPDATE poll_webhook_delivery SET next_attempt_at = $2
+WHERE id IN (SELECT id FROM poll_webhook_delivery WHERE status = $3 AND next_attempt_at <= $1
+ORDER BY next_attempt_at LIMIT $4 FOR UPDATE SKIP LOCKED)
+RETURNING id`
+
+//ClaimDeliveries moves the next attempt of the due deliveries to the end of the lease in a
+//single statement. Rows another worker is claiming are skipped instead of waited for.
+func (s deliveryPollWebhookStore) ClaimDeliveries(now, leaseUntil time.Time, limit int) ([]kallax.ULID, error) {
+	rs, err := s.RawQuery(claimDeliveriesQuery, now, leaseUntil, DeliveryPending, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rs.Close()
+
+	IDs := make([]kallax.ULID, 0)
+	for rs.Next() {
+		var ID kallax.ULID
+		if err := rs.RawScan(&ID); err != nil {
+			return nil, err
+		}
+
+		IDs = append(IDs, ID)
+	}
+
+	return IDs, nil
+}
+
+//WebhookHandlerImpl ...
+type WebhookHandlerImpl struct {
+	Store IPollWebhookStore
+}
+
+//NewWebhookHandler ...
+func NewWebhookHandler(db *sql.DB) *WebhookHandlerImpl {
+	return &WebhookHandlerImpl{
+		Store: deliveryPollWebhookStore{NewPollWebhookStore(db)},
+	}
+}
+
+//SaveWebhook ...
+func (h WebhookHandlerImpl) SaveWebhook(webhook PollWebhook) (PollWebhook, error) {
+	_, err := h.Store.Save(&webhook)
+	return webhook, err
+}
+
+//FindWebhook ...
+func (h WebhookHandlerImpl) FindWebhook(ID kallax.ULID) (*PollWebhook, error) {
+	return h.Store.FindOne(NewPollWebhookQuery().FindByID(ID))
+}
+
+//FindWebhooks ...
+func (h WebhookHandlerImpl) FindWebhooks(pollID kallax.ULID) ([]*PollWebhook, error) {
+	query := NewPollWebhookQuery().
+		FindByPollID(pollID).
+		Order(kallax.Asc(Schema.PollWebhook.CreatedAt))
+
+	return h.Store.FindAll(query)
+}
+
+//ReachThreshold marks the vote threshold of the webhook as reached and queues the delivery
+//in the same transaction, so the threshold stays waiting when the delivery can't be saved.
+//Only the first call tells true, so concurrent votes notify it once.
+func (h WebhookHandlerImpl) ReachThreshold(webhookID kallax.ULID, delivery PollWebhookDelivery) (bool, error) {
+	reached := false
+	err := h.Store.Transaction(func(store IPollWebhookStore) error {
+		rows, err := store.RawExec(
+			"UPDATE poll_webhook SET threshold_reached = true WHERE id = $1 AND NOT threshold_reached", webhookID)
+		if err != nil || rows == 0 {
+			return err
+		}
+
+		if err := store.SaveDelivery(&delivery); err != nil {
+			return err
+		}
+
+		reached = true
+		return nil
+	})
+
+	return reached, err
+}
+
+//SaveDelivery saves the delivery. A new delivery of an event already queued to the webhook
+//is ignored, so an event relayed again doesn't notify twice.
+func (h WebhookHandlerImpl) SaveDelivery(delivery PollWebhookDelivery) (PollWebhookDelivery, error) {
+	err := h.Store.SaveDelivery(&delivery)
+	if violatesConstraint(err, webhookDeliveryEventIndex) {
+		return delivery, nil
+	}
+
+	return delivery, err
+}
+
+//ClaimDueDeliveries takes the pending deliveries whose next attempt is due, the oldest
+//first, leasing them to the caller: their next attempt moves to the end of the lease, so
+//other workers leave them alone, and they are due again if the caller never saves them.
+func (h WebhookHandlerImpl) ClaimDueDeliveries(now time.Time) ([]*PollWebhookDelivery, error) {
+	IDs, err := h.Store.ClaimDeliveries(now, now.Add(webhookDeliveryLease), webhookDeliveryBatch)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(IDs) == 0 {
+		return []*PollWebhookDelivery{}, nil
+	}
+
+	query := NewPollWebhookDeliveryQuery().
+		FindByID(IDs...).
+		Order(kallax.Asc(Schema.PollWebhookDelivery.CreatedAt))
+
+	return h.Store.FindDeliveries(query)
+}
@@ -0,0 +1,139 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chai2010/assert"
+	"github.com/lib/pq"
+
+	"gopkg.in/src-d/go-kallax.v1"
+)
+
+//createThresholdStoreMock keeps threshold_reached of a single webhook, dropping the change
+//when the transaction fails like the database would.
+func createThresholdStoreMock(reached *bool) *IPollWebhookStoreMock {
+	store := &IPollWebhookStoreMock{
+		SaveDeliveryFunc: func(record *PollWebhookDelivery) error {
+			return nil
+		},
+	}
+	var pending bool
+	store.RawExecFunc = func(sql string, params ...interface{}) (int64, error) {
+		if *reached || pending {
+			return 0, nil
+		}
+		pending = true
+		return 1, nil
+	}
+	store.TransactionFunc = func(callback func(IPollWebhookStore) error) error {
+		pending = false
+		err := callback(store)
+		if err == nil && pending {
+			*reached = true
+		}
+		return err
+	}
+
+	return store
+}
+
+func TestReachThresholdOnlyOnce(t *testing.T) {
+	reached := false
+	store := createThresholdStoreMock(&reached)
+	handler := WebhookHandlerImpl{
+		Store: store,
+	}
+
+	first, err := handler.ReachThreshold(kallax.NewULID(), PollWebhookDelivery{})
+	assert.AssertNil(t, err)
+	second, _ := handler.ReachThreshold(kallax.NewULID(), PollWebhookDelivery{})
+
+	assert.AssertTrue(t, first)
+	assert.AssertFalse(t, second)
+	assert.AssertEqual(t, 2, len(store.TransactionCalls()))
+	assert.AssertEqual(t, 1, len(store.SaveDeliveryCalls()))
+	assert.AssertEqual(t, "UPDATE poll_webhook SET threshold_reached = true WHERE id = $1 AND NOT threshold_reached",
+		store.RawExecCalls()[0].SQL)
+}
+
+func TestReachThresholdStaysWaitingWhenDeliveryCantBeSaved(t *testing.T) {
+	reached := false
+	store := createThresholdStoreMock(&reached)
+	store.SaveDeliveryFunc = func(record *PollWebhookDelivery) error {
+		return fmt.Errorf("Disk full")
+	}
+	handler := WebhookHandlerImpl{
+		Store: store,
+	}
+
+	notified, err := handler.ReachThreshold(kallax.NewULID(), PollWebhookDelivery{})
+
+	assert.AssertEqual(t, "Disk full", err.Error())
+	assert.AssertFalse(t, notified)
+	assert.AssertFalse(t, reached)
+}
+
+func TestClaimDueDeliveriesLeasesThemBeforeReading(t *testing.T) {
+	var sqlExecuted string
+	now := time.Date(2018, 12, 21, 12, 0, 0, 0, time.UTC)
+	claimed := []kallax.ULID{kallax.NewULID(), kallax.NewULID()}
+	store := &IPollWebhookStoreMock{
+		ClaimDeliveriesFunc: func(at time.Time, leaseUntil time.Time, limit int) ([]kallax.ULID, error) {
+			return claimed, nil
+		},
+		FindDeliveriesFunc: func(q *PollWebhookDeliveryQuery) ([]*PollWebhookDelivery, error) {
+			sqlExecuted = q.String()
+			return []*PollWebhookDelivery{}, nil
+		},
+	}
+
+	handler := WebhookHandlerImpl{
+		Store: store,
+	}
+
+	_, err := handler.ClaimDueDeliveries(now)
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, now, store.ClaimDeliveriesCalls()[0].Now)
+	assert.AssertEqual(t, now.Add(webhookDeliveryLease), store.ClaimDeliveriesCalls()[0].LeaseUntil)
+	assert.AssertEqual(t, webhookDeliveryBatch, store.ClaimDeliveriesCalls()[0].Limit)
+	assert.AssertTrue(t, strings.Contains(sqlExecuted, "WHERE __pollwebhookdelivery.id IN ($1, $2) "+
+		"ORDER BY __pollwebhookdelivery.created_at ASC"), sqlExecuted)
+}
+
+func TestClaimDueDeliveriesWhenNoneIsDue(t *testing.T) {
+	store := &IPollWebhookStoreMock{
+		ClaimDeliveriesFunc: func(at time.Time, leaseUntil time.Time, limit int) ([]kallax.ULID, error) {
+			return []kallax.ULID{}, nil
+		},
+	}
+
+	handler := WebhookHandlerImpl{
+		Store: store,
+	}
+
+	deliveries, err := handler.ClaimDueDeliveries(time.Now())
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, 0, len(deliveries))
+	assert.AssertEqual(t, 0, len(store.FindDeliveriesCalls()))
+}
+
+func TestSaveDeliveryIgnoresEventAlreadyQueued(t *testing.T) {
+	store := &IPollWebhookStoreMock{
+		SaveDeliveryFunc: func(record *PollWebhookDelivery) error {
+			return &pq.Error{Code: "23505", Message: `duplicate key value violates unique constraint "poll_webhook_delivery_event_idx"`, Constraint: "poll_webhook_delivery_event_idx"}
+		},
+	}
+
+	handler := WebhookHandlerImpl{
+		Store: store,
+	}
+
+	_, err := handler.SaveDelivery(PollWebhookDelivery{EventKey: "poll.closed:x"})
+
+	assert.AssertNil(t, err)
+}
@@ -6,11 +6,16 @@ package app
 import (
 	"gopkg.in/src-d/go-kallax.v1"
 	"sync"
+	"time"
 )
 
 var (
-	lockPollHandlerMockFindPollByID sync.RWMutex
-	lockPollHandlerMockSavePoll     sync.RWMutex
+	lockPollHandlerMockFindPollByID     sync.RWMutex
+	lockPollHandlerMockFindPolls        sync.RWMutex
+	lockPollHandlerMockFindPollsByOwner sync.RWMutex
+	lockPollHandlerMockFindPollsToClose sync.RWMutex
+	lockPollHandlerMockFindPollsToOpen  sync.RWMutex
+	lockPollHandlerMockSavePoll         sync.RWMutex
 )
 
 // PollHandlerMock is a mock implementation of PollHandler.
@@ -22,7 +27,19 @@ var (
 //             FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
 // 	               panic("mock out the FindPollByID method")
 //             },
-//             SavePollFunc: func(poll Poll) Poll {
+//             FindPollsFunc: func(query *PollQuery) ([]*Poll, error) {
+// 	               panic("mock out the FindPolls method")
+//             },
+//             FindPollsByOwnerFunc: func(userID kallax.ULID) ([]*Poll, error) {
+// 	               panic("mock out the FindPollsByOwner method")
+//             },
+//             FindPollsToCloseFunc: func(now time.Time) ([]*Poll, error) {
+// 	               panic("mock out the FindPollsToClose method")
+//             },
+//             FindPollsToOpenFunc: func(now time.Time) ([]*Poll, error) {
+// 	               panic("mock out the FindPollsToOpen method")
+//             },
+//             SavePollFunc: func(poll Poll, events ...Event) (Poll, error) {
 // 	               panic("mock out the SavePoll method")
 //             },
 //         }
@@ -35,8 +52,20 @@ type PollHandlerMock struct {
 	// FindPollByIDFunc mocks the FindPollByID method.
 	FindPollByIDFunc func(ID kallax.ULID) (*Poll, error)
 
+	// FindPollsFunc mocks the FindPolls method.
+	FindPollsFunc func(query *PollQuery) ([]*Poll, error)
+
+	// FindPollsByOwnerFunc mocks the FindPollsByOwner method.
+	FindPollsByOwnerFunc func(userID kallax.ULID) ([]*Poll, error)
+
+	// FindPollsToCloseFunc mocks the FindPollsToClose method.
+	FindPollsToCloseFunc func(now time.Time) ([]*Poll, error)
+
+	// FindPollsToOpenFunc mocks the FindPollsToOpen method.
+	FindPollsToOpenFunc func(now time.Time) ([]*Poll, error)
+
 	// SavePollFunc mocks the SavePoll method.
-	SavePollFunc func(poll Poll) Poll
+	SavePollFunc func(poll Poll, events ...Event) (Poll, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -45,10 +74,32 @@ type PollHandlerMock struct {
 			// ID is the ID argument value.
 			ID kallax.ULID
 		}
+		// FindPolls holds details about calls to the FindPolls method.
+		FindPolls []struct {
+			// Query is the query argument value.
+			Query *PollQuery
+		}
+		// FindPollsByOwner holds details about calls to the FindPollsByOwner method.
+		FindPollsByOwner []struct {
+			// UserID is the userID argument value.
+			UserID kallax.ULID
+		}
+		// FindPollsToClose holds details about calls to the FindPollsToClose method.
+		FindPollsToClose []struct {
+			// Now is the now argument value.
+			Now time.Time
+		}
+		// FindPollsToOpen holds details about calls to the FindPollsToOpen method.
+		FindPollsToOpen []struct {
+			// Now is the now argument value.
+			Now time.Time
+		}
 		// SavePoll holds details about calls to the SavePoll method.
 		SavePoll []struct {
 			// Poll is the poll argument value.
 			Poll Poll
+			// Events is the events argument value.
+			Events []Event
 		}
 	}
 }
@@ -84,30 +135,158 @@ func (mock *PollHandlerMock) FindPollByIDCalls() []struct {
 	return calls
 }
 
+// FindPolls calls FindPollsFunc.
+func (mock *PollHandlerMock) FindPolls(query *PollQuery) ([]*Poll, error) {
+	if mock.FindPollsFunc == nil {
+		panic("PollHandlerMock.FindPollsFunc: method is nil but PollHandler.FindPolls was just called")
+	}
+	callInfo := struct {
+		Query *PollQuery
+	}{
+		Query: query,
+	}
+	lockPollHandlerMockFindPolls.Lock()
+	mock.calls.FindPolls = append(mock.calls.FindPolls, callInfo)
+	lockPollHandlerMockFindPolls.Unlock()
+	return mock.FindPollsFunc(query)
+}
+
+// FindPollsCalls gets all the calls that were made to FindPolls.
+// Check the length with:
+//     len(mockedPollHandler.FindPollsCalls())
+func (mock *PollHandlerMock) FindPollsCalls() []struct {
+	Query *PollQuery
+} {
+	var calls []struct {
+		Query *PollQuery
+	}
+	lockPollHandlerMockFindPolls.RLock()
+	calls = mock.calls.FindPolls
+	lockPollHandlerMockFindPolls.RUnlock()
+	return calls
+}
+
+// FindPollsByOwner calls FindPollsByOwnerFunc.
+func (mock *PollHandlerMock) FindPollsByOwner(userID kallax.ULID) ([]*Poll, error) {
+	if mock.FindPollsByOwnerFunc == nil {
+		panic("PollHandlerMock.FindPollsByOwnerFunc: method is nil but PollHandler.FindPollsByOwner was just called")
+	}
+	callInfo := struct {
+		UserID kallax.ULID
+	}{
+		UserID: userID,
+	}
+	lockPollHandlerMockFindPollsByOwner.Lock()
+	mock.calls.FindPollsByOwner = append(mock.calls.FindPollsByOwner, callInfo)
+	lockPollHandlerMockFindPollsByOwner.Unlock()
+	return mock.FindPollsByOwnerFunc(userID)
+}
+
+// FindPollsByOwnerCalls gets all the calls that were made to FindPollsByOwner.
+// Check the length with:
+//     len(mockedPollHandler.FindPollsByOwnerCalls())
+func (mock *PollHandlerMock) FindPollsByOwnerCalls() []struct {
+	UserID kallax.ULID
+} {
+	var calls []struct {
+		UserID kallax.ULID
+	}
+	lockPollHandlerMockFindPollsByOwner.RLock()
+	calls = mock.calls.FindPollsByOwner
+	lockPollHandlerMockFindPollsByOwner.RUnlock()
+	return calls
+}
+
+// FindPollsToClose calls FindPollsToCloseFunc.
+func (mock *PollHandlerMock) FindPollsToClose(now time.Time) ([]*Poll, error) {
+	if mock.FindPollsToCloseFunc == nil {
+		panic("PollHandlerMock.FindPollsToCloseFunc: method is nil but PollHandler.FindPollsToClose was just called")
+	}
+	callInfo := struct {
+		Now time.Time
+	}{
+		Now: now,
+	}
+	lockPollHandlerMockFindPollsToClose.Lock()
+	mock.calls.FindPollsToClose = append(mock.calls.FindPollsToClose, callInfo)
+	lockPollHandlerMockFindPollsToClose.Unlock()
+	return mock.FindPollsToCloseFunc(now)
+}
+
+// FindPollsToCloseCalls gets all the calls that were made to FindPollsToClose.
+// Check the length with:
+//     len(mockedPollHandler.FindPollsToCloseCalls())
+func (mock *PollHandlerMock) FindPollsToCloseCalls() []struct {
+	Now time.Time
+} {
+	var calls []struct {
+		Now time.Time
+	}
+	lockPollHandlerMockFindPollsToClose.RLock()
+	calls = mock.calls.FindPollsToClose
+	lockPollHandlerMockFindPollsToClose.RUnlock()
+	return calls
+}
+
+// FindPollsToOpen calls FindPollsToOpenFunc.
+func (mock *PollHandlerMock) FindPollsToOpen(now time.Time) ([]*Poll, error) {
+	if mock.FindPollsToOpenFunc == nil {
+		panic("PollHandlerMock.FindPollsToOpenFunc: method is nil but PollHandler.FindPollsToOpen was just called")
+	}
+	callInfo := struct {
+		Now time.Time
+	}{
+		Now: now,
+	}
+	lockPollHandlerMockFindPollsToOpen.Lock()
+	mock.calls.FindPollsToOpen = append(mock.calls.FindPollsToOpen, callInfo)
+	lockPollHandlerMockFindPollsToOpen.Unlock()
+	return mock.FindPollsToOpenFunc(now)
+}
+
+// FindPollsToOpenCalls gets all the calls that were made to FindPollsToOpen.
+// Check the length with:
+//     len(mockedPollHandler.FindPollsToOpenCalls())
+func (mock *PollHandlerMock) FindPollsToOpenCalls() []struct {
+	Now time.Time
+} {
+	var calls []struct {
+		Now time.Time
+	}
+	lockPollHandlerMockFindPollsToOpen.RLock()
+	calls = mock.calls.FindPollsToOpen
+	lockPollHandlerMockFindPollsToOpen.RUnlock()
+	return calls
+}
+
 // SavePoll calls SavePollFunc.
-func (mock *PollHandlerMock) SavePoll(poll Poll) Poll {
+func (mock *PollHandlerMock) SavePoll(poll Poll, events ...Event) (Poll, error) {
 	if mock.SavePollFunc == nil {
 		panic("PollHandlerMock.SavePollFunc: method is nil but PollHandler.SavePoll was just called")
 	}
 	callInfo := struct {
-		Poll Poll
+		Poll   Poll
+		Events []Event
 	}{
-		Poll: poll,
+		Poll:   poll,
+		Events: events,
 	}
 	lockPollHandlerMockSavePoll.Lock()
 	mock.calls.SavePoll = append(mock.calls.SavePoll, callInfo)
 	lockPollHandlerMockSavePoll.Unlock()
-	return mock.SavePollFunc(poll)
+	return mock.SavePollFunc(poll, events...)
 }
 
 // SavePollCalls gets all the calls that were made to SavePoll.
 // Check the length with:
 //     len(mockedPollHandler.SavePollCalls())
 func (mock *PollHandlerMock) SavePollCalls() []struct {
-	Poll Poll
+	Poll   Poll
+	Events []Event
 } {
 	var calls []struct {
-		Poll Poll
+		Poll   Poll
+		Events []Event
 	}
 	lockPollHandlerMockSavePoll.RLock()
 	calls = mock.calls.SavePoll
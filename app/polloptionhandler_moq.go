@@ -10,7 +10,6 @@ import (
 
 var (
 	lockPollOptionHandlerMockDeletePollOption sync.RWMutex
-	lockPollOptionHandlerMockExistsOption     sync.RWMutex
 	lockPollOptionHandlerMockFindPollOptions  sync.RWMutex
 	lockPollOptionHandlerMockSavePollOption   sync.RWMutex
 )
@@ -21,16 +20,13 @@ var (
 //
 //         // make and configure a mocked PollOptionHandler
 //         mockedPollOptionHandler := &PollOptionHandlerMock{
-//             DeletePollOptionFunc: func(id kallax.ULID) error {
+//             DeletePollOptionFunc: func(pollID kallax.ULID, id kallax.ULID, events ...Event) error {
 // 	               panic("mock out the DeletePollOption method")
 //             },
-//             ExistsOptionFunc: func(pollID kallax.ULID, candidate string) (bool, error) {
-// 	               panic("mock out the ExistsOption method")
-//             },
 //             FindPollOptionsFunc: func(id kallax.ULID) ([]*PollOption, error) {
 // 	               panic("mock out the FindPollOptions method")
 //             },
-//             SavePollOptionFunc: func(poll PollOption) PollOption {
+//             SavePollOptionFunc: func(poll PollOption, events ...Event) (PollOption, error) {
 // 	               panic("mock out the SavePollOption method")
 //             },
 //         }
@@ -41,30 +37,24 @@ var (
 //     }
 type PollOptionHandlerMock struct {
 	// DeletePollOptionFunc mocks the DeletePollOption method.
-	DeletePollOptionFunc func(id kallax.ULID) error
-
-	// ExistsOptionFunc mocks the ExistsOption method.
-	ExistsOptionFunc func(pollID kallax.ULID, candidate string) (bool, error)
+	DeletePollOptionFunc func(pollID kallax.ULID, id kallax.ULID, events ...Event) error
 
 	// FindPollOptionsFunc mocks the FindPollOptions method.
 	FindPollOptionsFunc func(id kallax.ULID) ([]*PollOption, error)
 
 	// SavePollOptionFunc mocks the SavePollOption method.
-	SavePollOptionFunc func(poll PollOption) PollOption
+	SavePollOptionFunc func(poll PollOption, events ...Event) (PollOption, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
 		// DeletePollOption holds details about calls to the DeletePollOption method.
 		DeletePollOption []struct {
-			// ID is the id argument value.
-			ID kallax.ULID
-		}
-		// ExistsOption holds details about calls to the ExistsOption method.
-		ExistsOption []struct {
 			// PollID is the pollID argument value.
 			PollID kallax.ULID
-			// Candidate is the candidate argument value.
-			Candidate string
+			// ID is the id argument value.
+			ID kallax.ULID
+			// Events is the events argument value.
+			Events []Event
 		}
 		// FindPollOptions holds details about calls to the FindPollOptions method.
 		FindPollOptions []struct {
@@ -75,34 +65,44 @@ type PollOptionHandlerMock struct {
 		SavePollOption []struct {
 			// Poll is the poll argument value.
 			Poll PollOption
+			// Events is the events argument value.
+			Events []Event
 		}
 	}
 }
 
 // DeletePollOption calls DeletePollOptionFunc.
-func (mock *PollOptionHandlerMock) DeletePollOption(id kallax.ULID) error {
+func (mock *PollOptionHandlerMock) DeletePollOption(pollID kallax.ULID, id kallax.ULID, events ...Event) error {
 	if mock.DeletePollOptionFunc == nil {
 		panic("PollOptionHandlerMock.DeletePollOptionFunc: method is nil but PollOptionHandler.DeletePollOption was just called")
 	}
 	callInfo := struct {
-		ID kallax.ULID
+		PollID kallax.ULID
+		ID     kallax.ULID
+		Events []Event
 	}{
-		ID: id,
+		PollID: pollID,
+		ID:     id,
+		Events: events,
 	}
 	lockPollOptionHandlerMockDeletePollOption.Lock()
 	mock.calls.DeletePollOption = append(mock.calls.DeletePollOption, callInfo)
 	lockPollOptionHandlerMockDeletePollOption.Unlock()
-	return mock.DeletePollOptionFunc(id)
+	return mock.DeletePollOptionFunc(pollID, id, events...)
 }
 
 // DeletePollOptionCalls gets all the calls that were made to DeletePollOption.
 // Check the length with:
 //     len(mockedPollOptionHandler.DeletePollOptionCalls())
 func (mock *PollOptionHandlerMock) DeletePollOptionCalls() []struct {
-	ID kallax.ULID
+	PollID kallax.ULID
+	ID     kallax.ULID
+	Events []Event
 } {
 	var calls []struct {
-		ID kallax.ULID
+		PollID kallax.ULID
+		ID     kallax.ULID
+		Events []Event
 	}
 	lockPollOptionHandlerMockDeletePollOption.RLock()
 	calls = mock.calls.DeletePollOption
@@ -110,41 +110,6 @@ func (mock *PollOptionHandlerMock) DeletePollOptionCalls() []struct {
 	return calls
 }
 
-// ExistsOption calls ExistsOptionFunc.
-func (mock *PollOptionHandlerMock) ExistsOption(pollID kallax.ULID, candidate string) (bool, error) {
-	if mock.ExistsOptionFunc == nil {
-		panic("PollOptionHandlerMock.ExistsOptionFunc: method is nil but PollOptionHandler.ExistsOption was just called")
-	}
-	callInfo := struct {
-		PollID    kallax.ULID
-		Candidate string
-	}{
-		PollID:    pollID,
-		Candidate: candidate,
-	}
-	lockPollOptionHandlerMockExistsOption.Lock()
-	mock.calls.ExistsOption = append(mock.calls.ExistsOption, callInfo)
-	lockPollOptionHandlerMockExistsOption.Unlock()
-	return mock.ExistsOptionFunc(pollID, candidate)
-}
-
-// ExistsOptionCalls gets all the calls that were made to ExistsOption.
-// Check the length with:
-//     len(mockedPollOptionHandler.ExistsOptionCalls())
-func (mock *PollOptionHandlerMock) ExistsOptionCalls() []struct {
-	PollID    kallax.ULID
-	Candidate string
-} {
-	var calls []struct {
-		PollID    kallax.ULID
-		Candidate string
-	}
-	lockPollOptionHandlerMockExistsOption.RLock()
-	calls = mock.calls.ExistsOption
-	lockPollOptionHandlerMockExistsOption.RUnlock()
-	return calls
-}
-
 // FindPollOptions calls FindPollOptionsFunc.
 func (mock *PollOptionHandlerMock) FindPollOptions(id kallax.ULID) ([]*PollOption, error) {
 	if mock.FindPollOptionsFunc == nil {
@@ -177,29 +142,33 @@ func (mock *PollOptionHandlerMock) FindPollOptionsCalls() []struct {
 }
 
 // SavePollOption calls SavePollOptionFunc.
-func (mock *PollOptionHandlerMock) SavePollOption(poll PollOption) PollOption {
+func (mock *PollOptionHandlerMock) SavePollOption(poll PollOption, events ...Event) (PollOption, error) {
 	if mock.SavePollOptionFunc == nil {
 		panic("PollOptionHandlerMock.SavePollOptionFunc: method is nil but PollOptionHandler.SavePollOption was just called")
 	}
 	callInfo := struct {
-		Poll PollOption
+		Poll   PollOption
+		Events []Event
 	}{
-		Poll: poll,
+		Poll:   poll,
+		Events: events,
 	}
 	lockPollOptionHandlerMockSavePollOption.Lock()
 	mock.calls.SavePollOption = append(mock.calls.SavePollOption, callInfo)
 	lockPollOptionHandlerMockSavePollOption.Unlock()
-	return mock.SavePollOptionFunc(poll)
+	return mock.SavePollOptionFunc(poll, events...)
 }
 
 // SavePollOptionCalls gets all the calls that were made to SavePollOption.
 // Check the length with:
 //     len(mockedPollOptionHandler.SavePollOptionCalls())
 func (mock *PollOptionHandlerMock) SavePollOptionCalls() []struct {
-	Poll PollOption
+	Poll   PollOption
+	Events []Event
 } {
 	var calls []struct {
-		Poll PollOption
+		Poll   PollOption
+		Events []Event
 	}
 	lockPollOptionHandlerMockSavePollOption.RLock()
 	calls = mock.calls.SavePollOption
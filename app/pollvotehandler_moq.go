@@ -6,12 +6,21 @@ package app
 import (
 	"gopkg.in/src-d/go-kallax.v1"
 	"sync"
+	"time"
 )
 
 var (
+	lockPollVoteHandlerMockBallotsOf              sync.RWMutex
+	lockPollVoteHandlerMockChangeVote             sync.RWMutex
 	lockPollVoteHandlerMockPollAlreadyVotedByUser sync.RWMutex
+	lockPollVoteHandlerMockRebuildTally           sync.RWMutex
+	lockPollVoteHandlerMockRetractVote            sync.RWMutex
 	lockPollVoteHandlerMockSaveVote               sync.RWMutex
-	lockPollVoteHandlerMockVotesFor               sync.RWMutex
+	lockPollVoteHandlerMockScoresOf               sync.RWMutex
+	lockPollVoteHandlerMockSelectionsFor          sync.RWMutex
+	lockPollVoteHandlerMockTallyByPoll            sync.RWMutex
+	lockPollVoteHandlerMockVoteTimeline           sync.RWMutex
+	lockPollVoteHandlerMockVotersOf               sync.RWMutex
 )
 
 // PollVoteHandlerMock is a mock implementation of PollVoteHandler.
@@ -20,14 +29,38 @@ var (
 //
 //         // make and configure a mocked PollVoteHandler
 //         mockedPollVoteHandler := &PollVoteHandlerMock{
+//             BallotsOfFunc: func(pollID kallax.ULID) ([]Ballot, error) {
+// 	               panic("mock out the BallotsOf method")
+//             },
+//             ChangeVoteFunc: func(pollID kallax.ULID, userID kallax.ULID, chosen string, selections []PollVoteSelection, events ...Event) (PollVote, error) {
+// 	               panic("mock out the ChangeVote method")
+//             },
 //             PollAlreadyVotedByUserFunc: func(pollID kallax.ULID, userID kallax.ULID) (bool, error) {
 // 	               panic("mock out the PollAlreadyVotedByUser method")
 //             },
-//             SaveVoteFunc: func(vote PollVote) PollVote {
+//             RebuildTallyFunc: func(pollID kallax.ULID) error {
+// 	               panic("mock out the RebuildTally method")
+//             },
+//             RetractVoteFunc: func(pollID kallax.ULID, userID kallax.ULID, events ...Event) (PollVote, error) {
+// 	               panic("mock out the RetractVote method")
+//             },
+//             SaveVoteFunc: func(vote PollVote, selections []PollVoteSelection, events ...Event) (PollVote, error) {
 // 	               panic("mock out the SaveVote method")
 //             },
-//             VotesForFunc: func(pollID kallax.ULID, option string) int64 {
-// 	               panic("mock out the VotesFor method")
+//             ScoresOfFunc: func(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+// 	               panic("mock out the ScoresOf method")
+//             },
+//             SelectionsForFunc: func(pollID kallax.ULID, optionID kallax.ULID) (int64, error) {
+// 	               panic("mock out the SelectionsFor method")
+//             },
+//             TallyByPollFunc: func(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+// 	               panic("mock out the TallyByPoll method")
+//             },
+//             VoteTimelineFunc: func(pollID kallax.ULID, fn func(time.Time) error) error {
+// 	               panic("mock out the VoteTimeline method")
+//             },
+//             VotersOfFunc: func(pollID kallax.ULID) (int64, error) {
+// 	               panic("mock out the VotersOf method")
 //             },
 //         }
 //
@@ -36,17 +69,59 @@ var (
 //
 //     }
 type PollVoteHandlerMock struct {
+	// BallotsOfFunc mocks the BallotsOf method.
+	BallotsOfFunc func(pollID kallax.ULID) ([]Ballot, error)
+
+	// ChangeVoteFunc mocks the ChangeVote method.
+	ChangeVoteFunc func(pollID kallax.ULID, userID kallax.ULID, chosen string, selections []PollVoteSelection, events ...Event) (PollVote, error)
+
 	// PollAlreadyVotedByUserFunc mocks the PollAlreadyVotedByUser method.
 	PollAlreadyVotedByUserFunc func(pollID kallax.ULID, userID kallax.ULID) (bool, error)
 
+	// RebuildTallyFunc mocks the RebuildTally method.
+	RebuildTallyFunc func(pollID kallax.ULID) error
+
+	// RetractVoteFunc mocks the RetractVote method.
+	RetractVoteFunc func(pollID kallax.ULID, userID kallax.ULID, events ...Event) (PollVote, error)
+
 	// SaveVoteFunc mocks the SaveVote method.
-	SaveVoteFunc func(vote PollVote) PollVote
+	SaveVoteFunc func(vote PollVote, selections []PollVoteSelection, events ...Event) (PollVote, error)
+
+	// ScoresOfFunc mocks the ScoresOf method.
+	ScoresOfFunc func(pollID kallax.ULID) (map[kallax.ULID]int64, error)
+
+	// SelectionsForFunc mocks the SelectionsFor method.
+	SelectionsForFunc func(pollID kallax.ULID, optionID kallax.ULID) (int64, error)
 
-	// VotesForFunc mocks the VotesFor method.
-	VotesForFunc func(pollID kallax.ULID, option string) int64
+	// TallyByPollFunc mocks the TallyByPoll method.
+	TallyByPollFunc func(pollID kallax.ULID) (map[kallax.ULID]int64, error)
+
+	// VoteTimelineFunc mocks the VoteTimeline method.
+	VoteTimelineFunc func(pollID kallax.ULID, fn func(time.Time) error) error
+
+	// VotersOfFunc mocks the VotersOf method.
+	VotersOfFunc func(pollID kallax.ULID) (int64, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// BallotsOf holds details about calls to the BallotsOf method.
+		BallotsOf []struct {
+			// PollID is the pollID argument value.
+			PollID kallax.ULID
+		}
+		// ChangeVote holds details about calls to the ChangeVote method.
+		ChangeVote []struct {
+			// PollID is the pollID argument value.
+			PollID kallax.ULID
+			// UserID is the userID argument value.
+			UserID kallax.ULID
+			// Chosen is the chosen argument value.
+			Chosen string
+			// Selections is the selections argument value.
+			Selections []PollVoteSelection
+			// Events is the events argument value.
+			Events []Event
+		}
 		// PollAlreadyVotedByUser holds details about calls to the PollAlreadyVotedByUser method.
 		PollAlreadyVotedByUser []struct {
 			// PollID is the pollID argument value.
@@ -54,21 +129,139 @@ type PollVoteHandlerMock struct {
 			// UserID is the userID argument value.
 			UserID kallax.ULID
 		}
+		// RebuildTally holds details about calls to the RebuildTally method.
+		RebuildTally []struct {
+			// PollID is the pollID argument value.
+			PollID kallax.ULID
+		}
+		// RetractVote holds details about calls to the RetractVote method.
+		RetractVote []struct {
+			// PollID is the pollID argument value.
+			PollID kallax.ULID
+			// UserID is the userID argument value.
+			UserID kallax.ULID
+			// Events is the events argument value.
+			Events []Event
+		}
 		// SaveVote holds details about calls to the SaveVote method.
 		SaveVote []struct {
 			// Vote is the vote argument value.
 			Vote PollVote
+			// Selections is the selections argument value.
+			Selections []PollVoteSelection
+			// Events is the events argument value.
+			Events []Event
+		}
+		// ScoresOf holds details about calls to the ScoresOf method.
+		ScoresOf []struct {
+			// PollID is the pollID argument value.
+			PollID kallax.ULID
+		}
+		// SelectionsFor holds details about calls to the SelectionsFor method.
+		SelectionsFor []struct {
+			// PollID is the pollID argument value.
+			PollID kallax.ULID
+			// OptionID is the optionID argument value.
+			OptionID kallax.ULID
+		}
+		// TallyByPoll holds details about calls to the TallyByPoll method.
+		TallyByPoll []struct {
+			// PollID is the pollID argument value.
+			PollID kallax.ULID
+		}
+		// VoteTimeline holds details about calls to the VoteTimeline method.
+		VoteTimeline []struct {
+			// PollID is the pollID argument value.
+			PollID kallax.ULID
+			// Fn is the fn argument value.
+			Fn func(time.Time) error
 		}
-		// VotesFor holds details about calls to the VotesFor method.
-		VotesFor []struct {
+		// VotersOf holds details about calls to the VotersOf method.
+		VotersOf []struct {
 			// PollID is the pollID argument value.
 			PollID kallax.ULID
-			// Option is the option argument value.
-			Option string
 		}
 	}
 }
 
+// BallotsOf calls BallotsOfFunc.
+func (mock *PollVoteHandlerMock) BallotsOf(pollID kallax.ULID) ([]Ballot, error) {
+	if mock.BallotsOfFunc == nil {
+		panic("PollVoteHandlerMock.BallotsOfFunc: method is nil but PollVoteHandler.BallotsOf was just called")
+	}
+	callInfo := struct {
+		PollID kallax.ULID
+	}{
+		PollID: pollID,
+	}
+	lockPollVoteHandlerMockBallotsOf.Lock()
+	mock.calls.BallotsOf = append(mock.calls.BallotsOf, callInfo)
+	lockPollVoteHandlerMockBallotsOf.Unlock()
+	return mock.BallotsOfFunc(pollID)
+}
+
+// BallotsOfCalls gets all the calls that were made to BallotsOf.
+// Check the length with:
+//     len(mockedPollVoteHandler.BallotsOfCalls())
+func (mock *PollVoteHandlerMock) BallotsOfCalls() []struct {
+	PollID kallax.ULID
+} {
+	var calls []struct {
+		PollID kallax.ULID
+	}
+	lockPollVoteHandlerMockBallotsOf.RLock()
+	calls = mock.calls.BallotsOf
+	lockPollVoteHandlerMockBallotsOf.RUnlock()
+	return calls
+}
+
+// ChangeVote calls ChangeVoteFunc.
+func (mock *PollVoteHandlerMock) ChangeVote(pollID kallax.ULID, userID kallax.ULID, chosen string, selections []PollVoteSelection, events ...Event) (PollVote, error) {
+	if mock.ChangeVoteFunc == nil {
+		panic("PollVoteHandlerMock.ChangeVoteFunc: method is nil but PollVoteHandler.ChangeVote was just called")
+	}
+	callInfo := struct {
+		PollID     kallax.ULID
+		UserID     kallax.ULID
+		Chosen     string
+		Selections []PollVoteSelection
+		Events     []Event
+	}{
+		PollID:     pollID,
+		UserID:     userID,
+		Chosen:     chosen,
+		Selections: selections,
+		Events:     events,
+	}
+	lockPollVoteHandlerMockChangeVote.Lock()
+	mock.calls.ChangeVote = append(mock.calls.ChangeVote, callInfo)
+	lockPollVoteHandlerMockChangeVote.Unlock()
+	return mock.ChangeVoteFunc(pollID, userID, chosen, selections, events...)
+}
+
+// ChangeVoteCalls gets all the calls that were made to ChangeVote.
+// Check the length with:
+//     len(mockedPollVoteHandler.ChangeVoteCalls())
+func (mock *PollVoteHandlerMock) ChangeVoteCalls() []struct {
+	PollID     kallax.ULID
+	UserID     kallax.ULID
+	Chosen     string
+	Selections []PollVoteSelection
+	Events     []Event
+} {
+	var calls []struct {
+		PollID     kallax.ULID
+		UserID     kallax.ULID
+		Chosen     string
+		Selections []PollVoteSelection
+		Events     []Event
+	}
+	lockPollVoteHandlerMockChangeVote.RLock()
+	calls = mock.calls.ChangeVote
+	lockPollVoteHandlerMockChangeVote.RUnlock()
+	return calls
+}
+
 // PollAlreadyVotedByUser calls PollAlreadyVotedByUserFunc.
 func (mock *PollVoteHandlerMock) PollAlreadyVotedByUser(pollID kallax.ULID, userID kallax.ULID) (bool, error) {
 	if mock.PollAlreadyVotedByUserFunc == nil {
@@ -104,30 +297,108 @@ func (mock *PollVoteHandlerMock) PollAlreadyVotedByUserCalls() []struct {
 	return calls
 }
 
+// RebuildTally calls RebuildTallyFunc.
+func (mock *PollVoteHandlerMock) RebuildTally(pollID kallax.ULID) error {
+	if mock.RebuildTallyFunc == nil {
+		panic("PollVoteHandlerMock.RebuildTallyFunc: method is nil but PollVoteHandler.RebuildTally was just called")
+	}
+	callInfo := struct {
+		PollID kallax.ULID
+	}{
+		PollID: pollID,
+	}
+	lockPollVoteHandlerMockRebuildTally.Lock()
+	mock.calls.RebuildTally = append(mock.calls.RebuildTally, callInfo)
+	lockPollVoteHandlerMockRebuildTally.Unlock()
+	return mock.RebuildTallyFunc(pollID)
+}
+
+// RebuildTallyCalls gets all the calls that were made to RebuildTally.
+// Check the length with:
+//     len(mockedPollVoteHandler.RebuildTallyCalls())
+func (mock *PollVoteHandlerMock) RebuildTallyCalls() []struct {
+	PollID kallax.ULID
+} {
+	var calls []struct {
+		PollID kallax.ULID
+	}
+	lockPollVoteHandlerMockRebuildTally.RLock()
+	calls = mock.calls.RebuildTally
+	lockPollVoteHandlerMockRebuildTally.RUnlock()
+	return calls
+}
+
+// RetractVote calls RetractVoteFunc.
+func (mock *PollVoteHandlerMock) RetractVote(pollID kallax.ULID, userID kallax.ULID, events ...Event) (PollVote, error) {
+	if mock.RetractVoteFunc == nil {
+		panic("PollVoteHandlerMock.RetractVoteFunc: method is nil but PollVoteHandler.RetractVote was just called")
+	}
+	callInfo := struct {
+		PollID kallax.ULID
+		UserID kallax.ULID
+		Events []Event
+	}{
+		PollID: pollID,
+		UserID: userID,
+		Events: events,
+	}
+	lockPollVoteHandlerMockRetractVote.Lock()
+	mock.calls.RetractVote = append(mock.calls.RetractVote, callInfo)
+	lockPollVoteHandlerMockRetractVote.Unlock()
+	return mock.RetractVoteFunc(pollID, userID, events...)
+}
+
+// RetractVoteCalls gets all the calls that were made to RetractVote.
+// Check the length with:
+//     len(mockedPollVoteHandler.RetractVoteCalls())
+func (mock *PollVoteHandlerMock) RetractVoteCalls() []struct {
+	PollID kallax.ULID
+	UserID kallax.ULID
+	Events []Event
+} {
+	var calls []struct {
+		PollID kallax.ULID
+		UserID kallax.ULID
+		Events []Event
+	}
+	lockPollVoteHandlerMockRetractVote.RLock()
+	calls = mock.calls.RetractVote
+	lockPollVoteHandlerMockRetractVote.RUnlock()
+	return calls
+}
+
 // SaveVote calls SaveVoteFunc.
-func (mock *PollVoteHandlerMock) SaveVote(vote PollVote) PollVote {
+func (mock *PollVoteHandlerMock) SaveVote(vote PollVote, selections []PollVoteSelection, events ...Event) (PollVote, error) {
 	if mock.SaveVoteFunc == nil {
 		panic("PollVoteHandlerMock.SaveVoteFunc: method is nil but PollVoteHandler.SaveVote was just called")
 	}
 	callInfo := struct {
-		Vote PollVote
+		Vote       PollVote
+		Selections []PollVoteSelection
+		Events     []Event
 	}{
-		Vote: vote,
+		Vote:       vote,
+		Selections: selections,
+		Events:     events,
 	}
 	lockPollVoteHandlerMockSaveVote.Lock()
 	mock.calls.SaveVote = append(mock.calls.SaveVote, callInfo)
 	lockPollVoteHandlerMockSaveVote.Unlock()
-	return mock.SaveVoteFunc(vote)
+	return mock.SaveVoteFunc(vote, selections, events...)
 }
 
 // SaveVoteCalls gets all the calls that were made to SaveVote.
 // Check the length with:
 //     len(mockedPollVoteHandler.SaveVoteCalls())
 func (mock *PollVoteHandlerMock) SaveVoteCalls() []struct {
-	Vote PollVote
+	Vote       PollVote
+	Selections []PollVoteSelection
+	Events     []Event
 } {
 	var calls []struct {
-		Vote PollVote
+		Vote       PollVote
+		Selections []PollVoteSelection
+		Events     []Event
 	}
 	lockPollVoteHandlerMockSaveVote.RLock()
 	calls = mock.calls.SaveVote
@@ -135,37 +406,165 @@ func (mock *PollVoteHandlerMock) SaveVoteCalls() []struct {
 	return calls
 }
 
-// VotesFor calls VotesForFunc.
-func (mock *PollVoteHandlerMock) VotesFor(pollID kallax.ULID, option string) int64 {
-	if mock.VotesForFunc == nil {
-		panic("PollVoteHandlerMock.VotesForFunc: method is nil but PollVoteHandler.VotesFor was just called")
+// ScoresOf calls ScoresOfFunc.
+func (mock *PollVoteHandlerMock) ScoresOf(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+	if mock.ScoresOfFunc == nil {
+		panic("PollVoteHandlerMock.ScoresOfFunc: method is nil but PollVoteHandler.ScoresOf was just called")
+	}
+	callInfo := struct {
+		PollID kallax.ULID
+	}{
+		PollID: pollID,
+	}
+	lockPollVoteHandlerMockScoresOf.Lock()
+	mock.calls.ScoresOf = append(mock.calls.ScoresOf, callInfo)
+	lockPollVoteHandlerMockScoresOf.Unlock()
+	return mock.ScoresOfFunc(pollID)
+}
+
+// ScoresOfCalls gets all the calls that were made to ScoresOf.
+// Check the length with:
+//     len(mockedPollVoteHandler.ScoresOfCalls())
+func (mock *PollVoteHandlerMock) ScoresOfCalls() []struct {
+	PollID kallax.ULID
+} {
+	var calls []struct {
+		PollID kallax.ULID
+	}
+	lockPollVoteHandlerMockScoresOf.RLock()
+	calls = mock.calls.ScoresOf
+	lockPollVoteHandlerMockScoresOf.RUnlock()
+	return calls
+}
+
+// SelectionsFor calls SelectionsForFunc.
+func (mock *PollVoteHandlerMock) SelectionsFor(pollID kallax.ULID, optionID kallax.ULID) (int64, error) {
+	if mock.SelectionsForFunc == nil {
+		panic("PollVoteHandlerMock.SelectionsForFunc: method is nil but PollVoteHandler.SelectionsFor was just called")
+	}
+	callInfo := struct {
+		PollID   kallax.ULID
+		OptionID kallax.ULID
+	}{
+		PollID:   pollID,
+		OptionID: optionID,
+	}
+	lockPollVoteHandlerMockSelectionsFor.Lock()
+	mock.calls.SelectionsFor = append(mock.calls.SelectionsFor, callInfo)
+	lockPollVoteHandlerMockSelectionsFor.Unlock()
+	return mock.SelectionsForFunc(pollID, optionID)
+}
+
+// SelectionsForCalls gets all the calls that were made to SelectionsFor.
+// Check the length with:
+//     len(mockedPollVoteHandler.SelectionsForCalls())
+func (mock *PollVoteHandlerMock) SelectionsForCalls() []struct {
+	PollID   kallax.ULID
+	OptionID kallax.ULID
+} {
+	var calls []struct {
+		PollID   kallax.ULID
+		OptionID kallax.ULID
+	}
+	lockPollVoteHandlerMockSelectionsFor.RLock()
+	calls = mock.calls.SelectionsFor
+	lockPollVoteHandlerMockSelectionsFor.RUnlock()
+	return calls
+}
+
+// TallyByPoll calls TallyByPollFunc.
+func (mock *PollVoteHandlerMock) TallyByPoll(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+	if mock.TallyByPollFunc == nil {
+		panic("PollVoteHandlerMock.TallyByPollFunc: method is nil but PollVoteHandler.TallyByPoll was just called")
+	}
+	callInfo := struct {
+		PollID kallax.ULID
+	}{
+		PollID: pollID,
+	}
+	lockPollVoteHandlerMockTallyByPoll.Lock()
+	mock.calls.TallyByPoll = append(mock.calls.TallyByPoll, callInfo)
+	lockPollVoteHandlerMockTallyByPoll.Unlock()
+	return mock.TallyByPollFunc(pollID)
+}
+
+// TallyByPollCalls gets all the calls that were made to TallyByPoll.
+// Check the length with:
+//     len(mockedPollVoteHandler.TallyByPollCalls())
+func (mock *PollVoteHandlerMock) TallyByPollCalls() []struct {
+	PollID kallax.ULID
+} {
+	var calls []struct {
+		PollID kallax.ULID
+	}
+	lockPollVoteHandlerMockTallyByPoll.RLock()
+	calls = mock.calls.TallyByPoll
+	lockPollVoteHandlerMockTallyByPoll.RUnlock()
+	return calls
+}
+
+// VoteTimeline calls VoteTimelineFunc.
+func (mock *PollVoteHandlerMock) VoteTimeline(pollID kallax.ULID, fn func(time.Time) error) error {
+	if mock.VoteTimelineFunc == nil {
+		panic("PollVoteHandlerMock.VoteTimelineFunc: method is nil but PollVoteHandler.VoteTimeline was just called")
+	}
+	callInfo := struct {
+		PollID kallax.ULID
+		Fn     func(time.Time) error
+	}{
+		PollID: pollID,
+		Fn:     fn,
+	}
+	lockPollVoteHandlerMockVoteTimeline.Lock()
+	mock.calls.VoteTimeline = append(mock.calls.VoteTimeline, callInfo)
+	lockPollVoteHandlerMockVoteTimeline.Unlock()
+	return mock.VoteTimelineFunc(pollID, fn)
+}
+
+// VoteTimelineCalls gets all the calls that were made to VoteTimeline.
+// Check the length with:
+//     len(mockedPollVoteHandler.VoteTimelineCalls())
+func (mock *PollVoteHandlerMock) VoteTimelineCalls() []struct {
+	PollID kallax.ULID
+	Fn     func(time.Time) error
+} {
+	var calls []struct {
+		PollID kallax.ULID
+		Fn     func(time.Time) error
+	}
+	lockPollVoteHandlerMockVoteTimeline.RLock()
+	calls = mock.calls.VoteTimeline
+	lockPollVoteHandlerMockVoteTimeline.RUnlock()
+	return calls
+}
+
+// VotersOf calls VotersOfFunc.
+func (mock *PollVoteHandlerMock) VotersOf(pollID kallax.ULID) (int64, error) {
+	if mock.VotersOfFunc == nil {
+		panic("PollVoteHandlerMock.VotersOfFunc: method is nil but PollVoteHandler.VotersOf was just called")
 	}
 	callInfo := struct {
 		PollID kallax.ULID
-		Option string
 	}{
 		PollID: pollID,
-		Option: option,
 	}
-	lockPollVoteHandlerMockVotesFor.Lock()
-	mock.calls.VotesFor = append(mock.calls.VotesFor, callInfo)
-	lockPollVoteHandlerMockVotesFor.Unlock()
-	return mock.VotesForFunc(pollID, option)
+	lockPollVoteHandlerMockVotersOf.Lock()
+	mock.calls.VotersOf = append(mock.calls.VotersOf, callInfo)
+	lockPollVoteHandlerMockVotersOf.Unlock()
+	return mock.VotersOfFunc(pollID)
 }
 
-// VotesForCalls gets all the calls that were made to VotesFor.
+// VotersOfCalls gets all the calls that were made to VotersOf.
 // Check the length with:
-//     len(mockedPollVoteHandler.VotesForCalls())
-func (mock *PollVoteHandlerMock) VotesForCalls() []struct {
+//     len(mockedPollVoteHandler.VotersOfCalls())
+func (mock *PollVoteHandlerMock) VotersOfCalls() []struct {
 	PollID kallax.ULID
-	Option string
 } {
 	var calls []struct {
 		PollID kallax.ULID
-		Option string
 	}
-	lockPollVoteHandlerMockVotesFor.RLock()
-	calls = mock.calls.VotesFor
-	lockPollVoteHandlerMockVotesFor.RUnlock()
+	lockPollVoteHandlerMockVotersOf.RLock()
+	calls = mock.calls.VotersOf
+	lockPollVoteHandlerMockVotersOf.RUnlock()
 	return calls
 }
@@ -2,6 +2,7 @@ package app
 
 import (
 	"encoding/json"
+	"log"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -16,11 +17,15 @@ type ProcessingBlock func(v interface{}) (interface{}, error)
 type HTTPHelper interface {
 	Process(interface{}, ...ProcessingBlock)
 	ValidateSession() error
+	ValidateStreamSession() error
 	GetRequestSessionID() (string, error)
 	IsRegisteredUser() bool
 	Forbid(error)
+	Fail(error)
 	LoggedUserID() kallax.ULID
 	GetVar(name string) string
+	GetQuery(name string) string
+	Raw() (http.ResponseWriter, *http.Request)
 }
 
 //HTTPHelperImpl ...
@@ -44,7 +49,7 @@ func (h *HTTPHelperImpl) Process(v interface{}, blocks ...ProcessingBlock) {
 	err := json.NewDecoder(h.Request.Body).Decode(&v)
 
 	if err != nil && err.Error() != "EOF" {
-		http.Error(h.ResponseWriter, err.Error(), http.StatusBadRequest)
+		h.writeError(ErrValidation("Request body is not valid JSON."), err.Error())
 		return
 	}
 
@@ -55,7 +60,7 @@ func (h *HTTPHelperImpl) Process(v interface{}, blocks ...ProcessingBlock) {
 		result, aErr = f(result)
 
 		if aErr != nil {
-			http.Error(h.ResponseWriter, aErr.Error(), http.StatusConflict)
+			h.writeError(aErr, nil)
 			return
 		}
 	}
@@ -73,7 +78,20 @@ func (h *HTTPHelperImpl) ValidateSession() error {
 	return h.CheckSession(ID)
 }
 
-//GetRequestSessionID ...
+//ValidateStreamSession is ValidateSession that also takes the sessionId query parameter,
+//for streams opened by clients that can't set headers, like the EventSource and WebSocket
+//of browsers. Only streams accept it, so sessions don't end up in the URLs of other routes.
+func (h *HTTPHelperImpl) ValidateStreamSession() error {
+	if h.Request.Header.Get("sessionId") == "" && h.Request.URL != nil {
+		if ID := h.Request.URL.Query().Get("sessionId"); ID != "" {
+			return h.CheckSession(ID)
+		}
+	}
+
+	return h.ValidateSession()
+}
+
+//GetRequestSessionID reads the sessionId header.
 func (h *HTTPHelperImpl) GetRequestSessionID() (string, error) {
 	sessionID := h.Request.Header.Get("sessionId")
 
@@ -89,9 +107,30 @@ func (h *HTTPHelperImpl) IsRegisteredUser() bool {
 	return h.Session != nil && h.Session.RegisteredUser
 }
 
-//Forbid ...
+//Forbid answers 401 for errors that ask the user to log in and 403 for everything else.
+//Only the messages of those two are shown; any other error is logged and answered with a
+//plain "Forbidden", as it may come from the store or the driver.
 func (h *HTTPHelperImpl) Forbid(err error) {
-	http.Error(h.ResponseWriter, err.Error(), http.StatusForbidden)
+	coded, ok := err.(CodedError)
+	if !ok || (coded.Status() != http.StatusUnauthorized && coded.Status() != http.StatusForbidden) {
+		log.Println("Forbidden:", err)
+		err = ErrForbidden("Forbidden")
+	}
+
+	h.writeError(err, nil)
+}
+
+//Fail answers the error, for handlers that write the response themselves.
+func (h *HTTPHelperImpl) Fail(err error) {
+	h.writeError(err, nil)
+}
+
+func (h *HTTPHelperImpl) writeError(err error, details interface{}) {
+	status, response := NewErrorResponse(err, details)
+
+	h.ResponseWriter.Header().Set("Content-Type", "application/json")
+	h.ResponseWriter.WriteHeader(status)
+	json.NewEncoder(h.ResponseWriter).Encode(response)
 }
 
 //LoggedUserID ...
@@ -107,3 +146,13 @@ func (h *HTTPHelperImpl) LoggedUserID() kallax.ULID {
 func (h *HTTPHelperImpl) GetVar(name string) string {
 	return mux.Vars(h.Request)[name]
 }
+
+//GetQuery reads a query parameter.
+func (h *HTTPHelperImpl) GetQuery(name string) string {
+	return h.Request.URL.Query().Get(name)
+}
+
+//Raw gives the response and the request, for handlers that stream the response.
+func (h *HTTPHelperImpl) Raw() (http.ResponseWriter, *http.Request) {
+	return h.ResponseWriter, h.Request
+}
@@ -2,9 +2,11 @@ package app
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"net/textproto"
 	"strings"
 	"testing"
@@ -13,6 +15,7 @@ import (
 	"gopkg.in/src-d/go-kallax.v1"
 
 	"github.com/chai2010/assert"
+	"github.com/lib/pq"
 )
 
 type FakeResponseWriter struct {
@@ -117,7 +120,7 @@ func TestProcessErrorOnProcessing(t *testing.T) {
 	}
 
 	convert := func(v interface{}) (interface{}, error) {
-		return nil, fmt.Errorf("Nothing good happens after 2:00 PM")
+		return nil, ErrConflict("Nothing good happens after 2:00 PM")
 	}
 
 	stringfy := func(v interface{}) (interface{}, error) {
@@ -134,7 +137,7 @@ func TestProcessErrorOnProcessing(t *testing.T) {
 
 	helper.Process(&FakeData{}, convert, stringfy)
 
-	expected := "Nothing good happens after 2:00 PM"
+	expected := `{"code":"conflict","message":"Nothing good happens after 2:00 PM"}`
 	assert.AssertEqual(t, expected, strings.TrimSpace(result.String()))
 }
 
@@ -161,7 +164,8 @@ func TestProcessWithInvalidJSON(t *testing.T) {
 
 	helper.Process(&FakeData{}, convert)
 
-	expected := "invalid character 'K' looking for beginning of value"
+	expected := `{"code":"validation_failed","message":"Request body is not valid JSON.",` +
+		`"details":"invalid character 'K' looking for beginning of value"}`
 	assert.AssertEqual(t, expected, strings.TrimSpace(result.String()))
 }
 
@@ -234,6 +238,33 @@ func TestValidateSessionFailWhenNotHaveSessionId(t *testing.T) {
 	assert.AssertEqual(t, "Must be logged to perform this action. Missing value.", err.Error())
 }
 
+func TestValidateStreamSessionFromQuery(t *testing.T) {
+	var checked string
+	helper := &HTTPHelperImpl{
+		Request: httptest.NewRequest("GET", "/polls/x/stream?sessionId=7d97abb1-2f1b-4542-8173-67e78a590ab9", nil),
+		CheckSession: func(ID string) error {
+			checked = ID
+			return nil
+		},
+	}
+
+	err := helper.ValidateStreamSession()
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, "7d97abb1-2f1b-4542-8173-67e78a590ab9", checked)
+}
+
+func TestValidateSessionIgnoresQuery(t *testing.T) {
+	helper := &HTTPHelperImpl{
+		Request: httptest.NewRequest("GET", "/polls/x?sessionId=7d97abb1-2f1b-4542-8173-67e78a590ab9", nil),
+		CheckSession: func(ID string) error {
+			return nil
+		},
+	}
+
+	err := helper.ValidateSession()
+	assert.AssertEqual(t, "Must be logged to perform this action. Missing value.", err.Error())
+}
+
 func TestIsRegisteredUser(t *testing.T) {
 	helper := &HTTPHelperImpl{
 		Session: &Session{
@@ -273,12 +304,99 @@ func TestForbid(t *testing.T) {
 		ResponseWriter: writer,
 	}
 
-	helper.Forbid(fmt.Errorf("Baba Yaga"))
+	helper.Forbid(fmt.Errorf("pq: relation \"session\" does not exist"))
 
-	expected := "Baba Yaga"
+	expected := `{"code":"forbidden","message":"Forbidden"}`
 	assert.AssertEqual(t, expected, strings.TrimSpace(result.String()))
 }
 
+func TestForbidKeepsForbiddenMessage(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	helper := &HTTPHelperImpl{
+		ResponseWriter: recorder,
+	}
+
+	helper.Forbid(ErrForbidden("Not yours"))
+
+	assert.AssertEqual(t, http.StatusForbidden, recorder.Code)
+	expected := `{"code":"forbidden","message":"Not yours"}`
+	assert.AssertEqual(t, expected, strings.TrimSpace(recorder.Body.String()))
+}
+
+func TestForbidKeepsUnauthenticatedStatus(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	helper := &HTTPHelperImpl{
+		ResponseWriter: recorder,
+	}
+
+	helper.Forbid(ErrSessionExpired("Session expired. Must log in again."))
+
+	assert.AssertEqual(t, http.StatusUnauthorized, recorder.Code)
+	expected := `{"code":"session_expired","message":"Session expired. Must log in again."}`
+	assert.AssertEqual(t, expected, strings.TrimSpace(recorder.Body.String()))
+}
+
+func TestProcessMapsErrorsToStatus(t *testing.T) {
+	cases := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{ErrNotFound("Nope"), http.StatusNotFound, CodeNotFound},
+		{kallax.ErrNotFound, http.StatusNotFound, CodeNotFound},
+		{ErrValidation("Bad"), http.StatusBadRequest, CodeValidation},
+		{ErrConflict("Again"), http.StatusConflict, CodeConflict},
+		{ErrForbidden("Not yours"), http.StatusForbidden, CodeForbidden},
+		{ErrNotChangePoll("Published"), http.StatusConflict, CodePollNotChangeable},
+		{ErrPollNotOpen("Draft"), http.StatusConflict, CodePollNotOpen},
+		{ErrUserNotLogged("Who?"), http.StatusUnauthorized, CodeUnauthenticated},
+		{ErrInternal("Boom"), http.StatusInternalServerError, CodeInternal},
+		{fmt.Errorf("pq: connection refused"), http.StatusInternalServerError, CodeInternal},
+	}
+
+	for _, c := range cases {
+		recorder := httptest.NewRecorder()
+		helper := &HTTPHelperImpl{
+			ResponseWriter: recorder,
+			Request: &http.Request{
+				Body: JSONReader{InnerReader: strings.NewReader("")},
+			},
+		}
+
+		failing := func(v interface{}) (interface{}, error) {
+			return nil, c.err
+		}
+
+		helper.Process(&FakeData{}, failing)
+
+		var response ErrorResponse
+		json.NewDecoder(recorder.Body).Decode(&response)
+
+		assert.AssertEqual(t, c.status, recorder.Code, c.err.Error())
+		assert.AssertEqual(t, c.code, response.Code, c.err.Error())
+		assert.AssertEqual(t, "application/json", recorder.Header().Get("Content-Type"))
+	}
+}
+
+func TestProcessHidesInternalErrorMessage(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	helper := &HTTPHelperImpl{
+		ResponseWriter: recorder,
+		Request: &http.Request{
+			Body: JSONReader{InnerReader: strings.NewReader("")},
+		},
+	}
+
+	failing := func(v interface{}) (interface{}, error) {
+		return nil, fmt.Errorf("pq: password authentication failed for user \"poll\"")
+	}
+
+	helper.Process(&FakeData{}, failing)
+
+	expected := `{"code":"internal_error","message":"Internal error."}`
+	assert.AssertEqual(t, expected, strings.TrimSpace(recorder.Body.String()))
+}
+
 func TestLoggedUserID(t *testing.T) {
 	userID := kallax.NewULID()
 	helper := &HTTPHelperImpl{
@@ -340,3 +458,12 @@ func TestGetVar(t *testing.T) {
 	result := helper.GetVar("something")
 	assert.AssertEqual(t, "I would like a dinner reservation for midnight", result)
 }
+
+func TestViolatesConstraint(t *testing.T) {
+	err := &pq.Error{Code: "23505", Message: "duplicate key value", Constraint: userLoginIndex}
+
+	assert.AssertTrue(t, violatesConstraint(err, userLoginIndex))
+	assert.AssertFalse(t, violatesConstraint(err, pollVoteUserIndex))
+	assert.AssertFalse(t, violatesConstraint(fmt.Errorf(`duplicate key value violates "poll_user_login_idx"`), userLoginIndex))
+	assert.AssertFalse(t, violatesConstraint(nil, userLoginIndex))
+}
@@ -0,0 +1,150 @@
+package app
+
+import (
+	"gopkg.in/src-d/go-kallax.v1"
+)
+
+//Ballot lists the options chosen in a vote, the most preferred first.
+type Ballot []kallax.ULID
+
+//RunoffRound is one counting round of an instant runoff, counting the ballots of each
+//option ID. Exhausted counts the ballots whose options were all eliminated.
+type RunoffRound struct {
+	Counting   map[kallax.ULID]int64
+	Eliminated []*PollOption
+	Exhausted  int64
+}
+
+//RunoffResult holds every round and the winner. When nobody voted there is no winner and
+//Tied lists every option.
+type RunoffResult struct {
+	Rounds []RunoffRound
+	Winner *PollOption
+	Tied   []*PollOption
+	Tally  VoteTally
+}
+
+//InstantRunoff gives each ballot to its most preferred option still in the race and
+//eliminates the option with the fewest ballots, one per round, until one of them holds the
+//majority of the ballots not exhausted or is the last one left.
+//Ties for the fewest ballots go to the option with fewer ballots in the rounds before,
+//the latest first, and then to the option placed last.
+func InstantRunoff(options []*PollOption, ballots []Ballot) RunoffResult {
+	remaining := make([]*PollOption, len(options))
+	copy(remaining, options)
+
+	result := RunoffResult{Rounds: make([]RunoffRound, 0), Tally: BallotTally(options, ballots)}
+	history := make([]map[kallax.ULID]int64, 0)
+
+	for len(remaining) > 0 {
+		counts, exhausted := countFirstPreferences(remaining, ballots)
+
+		round := RunoffRound{
+			Counting:   make(map[kallax.ULID]int64),
+			Eliminated: make([]*PollOption, 0),
+			Exhausted:  exhausted,
+		}
+		for _, opt := range remaining {
+			round.Counting[opt.ID] = counts[opt.ID]
+		}
+
+		if len(ballots) == 0 {
+			result.Rounds = append(result.Rounds, round)
+			result.Tied = remaining
+			return result
+		}
+
+		continuing := int64(len(ballots)) - exhausted
+		leader := remaining[0]
+		for _, opt := range remaining {
+			if counts[opt.ID] > counts[leader.ID] {
+				leader = opt
+			}
+		}
+
+		if len(remaining) == 1 || counts[leader.ID]*2 > continuing {
+			result.Rounds = append(result.Rounds, round)
+			result.Winner = leader
+			return result
+		}
+
+		loser := runoffLoser(remaining, counts, history)
+		round.Eliminated = append(round.Eliminated, loser)
+		result.Rounds = append(result.Rounds, round)
+		history = append(history, counts)
+
+		kept := make([]*PollOption, 0, len(remaining)-1)
+		for _, opt := range remaining {
+			if opt != loser {
+				kept = append(kept, opt)
+			}
+		}
+		remaining = kept
+	}
+
+	return result
+}
+
+//runoffLoser picks the option to eliminate: the fewest ballots now, then in the rounds
+//before, the latest first, and then the last placed one.
+func runoffLoser(remaining []*PollOption, counts map[kallax.ULID]int64,
+	history []map[kallax.ULID]int64) *PollOption {
+	loses := func(opt, than *PollOption) bool {
+		if counts[opt.ID] != counts[than.ID] {
+			return counts[opt.ID] < counts[than.ID]
+		}
+
+		for i := len(history) - 1; i >= 0; i-- {
+			if history[i][opt.ID] != history[i][than.ID] {
+				return history[i][opt.ID] < history[i][than.ID]
+			}
+		}
+
+		return opt.Position >= than.Position
+	}
+
+	loser := remaining[0]
+	for _, opt := range remaining[1:] {
+		if loses(opt, loser) {
+			loser = opt
+		}
+	}
+
+	return loser
+}
+
+//countFirstPreferences gives each ballot to the first of its options still in the race.
+func countFirstPreferences(remaining []*PollOption, ballots []Ballot) (map[kallax.ULID]int64, int64) {
+	inRace := make(map[kallax.ULID]bool)
+	for _, opt := range remaining {
+		inRace[opt.ID] = true
+	}
+
+	counts := make(map[kallax.ULID]int64)
+	exhausted := int64(0)
+	for _, ballot := range ballots {
+		counted := false
+		for _, ID := range ballot {
+			if inRace[ID] {
+				counts[ID]++
+				counted = true
+				break
+			}
+		}
+
+		if !counted {
+			exhausted++
+		}
+	}
+
+	return counts, exhausted
+}
+
+func contentsOf(options []*PollOption) []string {
+	contents := make([]string, 0, len(options))
+	for _, opt := range options {
+		contents = append(contents, opt.Content)
+	}
+
+	return contents
+}
@@ -0,0 +1,169 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chai2010/assert"
+)
+
+func TestInstantRunoff(t *testing.T) {
+	options := newOptions("A", "B", "C", "D")
+	a, b, c, d := options[0].ID, options[1].ID, options[2].ID, options[3].ID
+
+	cases := []struct {
+		name       string
+		ballots    []Ballot
+		winner     string
+		tied       []string
+		rounds     int
+		eliminated [][]string
+		exhausted  []int64
+	}{
+		{
+			name:       "majority on first round",
+			ballots:    []Ballot{{a}, {a, b}, {b}},
+			winner:     "A",
+			rounds:     1,
+			eliminated: [][]string{{}},
+			exhausted:  []int64{0},
+		},
+		{
+			name:       "transfers decide the winner",
+			ballots:    []Ballot{{a}, {a}, {a}, {b, c}, {c, b}, {c, b}, {d, b}, {b}},
+			winner:     "B",
+			rounds:     3,
+			eliminated: [][]string{{"D"}, {"C"}, {}},
+			exhausted:  []int64{0, 0, 0},
+		},
+		{
+			name:       "tied last places go by position",
+			ballots:    []Ballot{{a}, {a}, {a, c}, {b, a}, {c, a}, {d, c}, {d, c}},
+			winner:     "A",
+			rounds:     2,
+			eliminated: [][]string{{"C"}, {}},
+			exhausted:  []int64{0, 0},
+		},
+		{
+			name:       "tied last places go by the round before",
+			ballots:    []Ballot{{a}, {a}, {a}, {a}, {b}, {c}, {c}, {d, b}},
+			winner:     "A",
+			rounds:     3,
+			eliminated: [][]string{{"D"}, {"B"}, {}},
+			exhausted:  []int64{0, 0, 2},
+		},
+		{
+			name:       "exhausted ballots leave the majority",
+			ballots:    []Ballot{{a}, {a}, {a}, {b}, {b}, {c}, {d}},
+			winner:     "A",
+			rounds:     3,
+			eliminated: [][]string{{"D"}, {"C"}, {}},
+			exhausted:  []int64{0, 1, 2},
+		},
+		{
+			name:       "every option tied",
+			ballots:    []Ballot{{a}, {b}, {c}, {d}},
+			winner:     "A",
+			rounds:     4,
+			eliminated: [][]string{{"D"}, {"C"}, {"B"}, {}},
+			exhausted:  []int64{0, 1, 2, 3},
+		},
+		{
+			name:       "tie after eliminations",
+			ballots:    []Ballot{{a}, {a}, {b}, {b}, {c}, {d, c}},
+			winner:     "A",
+			rounds:     4,
+			eliminated: [][]string{{"D"}, {"C"}, {"B"}, {}},
+			exhausted:  []int64{0, 0, 2, 4},
+		},
+		{
+			name:       "no ballots",
+			ballots:    []Ballot{},
+			tied:       []string{"A", "B", "C", "D"},
+			rounds:     1,
+			eliminated: [][]string{{}},
+			exhausted:  []int64{0},
+		},
+	}
+
+	for _, tc := range cases {
+		result := InstantRunoff(options, tc.ballots)
+
+		assert.AssertEqual(t, tc.winner, contentOf(result.Winner), tc.name)
+		assert.AssertEqual(t, tc.tied == nil, result.Tied == nil, tc.name)
+		if tc.tied != nil {
+			assert.AssertTrue(t, reflect.DeepEqual(tc.tied, contentsOf(result.Tied)), tc.name, result.Tied)
+		}
+		assert.AssertEqual(t, tc.rounds, len(result.Rounds), tc.name)
+		for i, round := range result.Rounds {
+			eliminated := contentsOf(round.Eliminated)
+			assert.AssertTrue(t, reflect.DeepEqual(tc.eliminated[i], eliminated), tc.name, i, eliminated)
+			assert.AssertEqual(t, tc.exhausted[i], round.Exhausted, tc.name, i)
+		}
+	}
+}
+
+func TestInstantRunoffCountsEveryRoundOnRemainingOptions(t *testing.T) {
+	options := newOptions("A", "B", "C")
+	a, b, c := options[0].ID, options[1].ID, options[2].ID
+
+	result := InstantRunoff(options, []Ballot{{a}, {a}, {b, a}, {c, b}, {c, b}})
+
+	assert.AssertEqual(t, 2, len(result.Rounds))
+	assert.AssertEqual(t, int64(2), result.Rounds[0].Counting[a])
+	assert.AssertEqual(t, int64(1), result.Rounds[0].Counting[b])
+	assert.AssertEqual(t, int64(2), result.Rounds[0].Counting[c])
+	assert.AssertEqual(t, int64(3), result.Rounds[1].Counting[a])
+	assert.AssertEqual(t, int64(2), result.Rounds[1].Counting[c])
+	_, counted := result.Rounds[1].Counting[b]
+	assert.AssertFalse(t, counted)
+	assert.AssertEqual(t, "A", contentOf(result.Winner))
+}
+
+func contentOf(option *PollOption) string {
+	if option == nil {
+		return ""
+	}
+
+	return option.Content
+}
+
+func TestInstantRunoffBreaksTiesByPosition(t *testing.T) {
+	options := newOptions("A", "B")
+	options[0].Position = 1
+	options[1].Position = 0
+	a, b := options[0].ID, options[1].ID
+
+	result := InstantRunoff(options, []Ballot{{a}, {b}})
+
+	assert.AssertEqual(t, []string{"A"}, contentsOf(result.Rounds[0].Eliminated))
+	assert.AssertEqual(t, "B", contentOf(result.Winner))
+}
+
+func TestInstantRunoffLastOptionWins(t *testing.T) {
+	options := newOptions("A", "B")
+	a := options[0].ID
+
+	result := InstantRunoff(options[1:], []Ballot{{a}})
+
+	assert.AssertEqual(t, 1, len(result.Rounds))
+	assert.AssertEqual(t, int64(1), result.Rounds[0].Exhausted)
+	assert.AssertEqual(t, "B", contentOf(result.Winner))
+	assert.AssertTrue(t, result.Tied == nil)
+}
+
+func TestInstantRunoffKeepsOptionsWithTheSameContentApart(t *testing.T) {
+	options := newOptions("Pizza", "Pizza", "Sushi")
+	first, second, sushi := options[0].ID, options[1].ID, options[2].ID
+
+	result := InstantRunoff(options, []Ballot{{first}, {first}, {second}, {second}, {second}, {sushi, first}})
+
+	assert.AssertEqual(t, 3, len(result.Rounds))
+	assert.AssertEqual(t, int64(2), result.Rounds[0].Counting[first])
+	assert.AssertEqual(t, int64(3), result.Rounds[0].Counting[second])
+	assert.AssertEqual(t, sushi, result.Rounds[0].Eliminated[0].ID)
+	assert.AssertEqual(t, int64(3), result.Rounds[1].Counting[first])
+	assert.AssertEqual(t, int64(3), result.Rounds[1].Counting[second])
+	assert.AssertEqual(t, first, result.Rounds[1].Eliminated[0].ID)
+	assert.AssertEqual(t, second, result.Winner.ID)
+}
@@ -9,8 +9,11 @@ import (
 )
 
 var (
-	lockSessionHandlerMockCreateSession sync.RWMutex
-	lockSessionHandlerMockSaveSession   sync.RWMutex
+	lockSessionHandlerMockCreateSession         sync.RWMutex
+	lockSessionHandlerMockDeleteExpiredSessions sync.RWMutex
+	lockSessionHandlerMockDeleteSession         sync.RWMutex
+	lockSessionHandlerMockFindValidSession      sync.RWMutex
+	lockSessionHandlerMockSaveSession           sync.RWMutex
 )
 
 // SessionHandlerMock is a mock implementation of SessionHandler.
@@ -19,10 +22,19 @@ var (
 //
 //         // make and configure a mocked SessionHandler
 //         mockedSessionHandler := &SessionHandlerMock{
-//             CreateSessionFunc: func(userID kallax.ULID, registeredUser bool) *Session {
+//             CreateSessionFunc: func(userID kallax.ULID, registeredUser bool) (*Session, error) {
 // 	               panic("mock out the CreateSession method")
 //             },
-//             SaveSessionFunc: func(session Session) Session {
+//             DeleteExpiredSessionsFunc: func() (int64, error) {
+// 	               panic("mock out the DeleteExpiredSessions method")
+//             },
+//             DeleteSessionFunc: func(id kallax.ULID) error {
+// 	               panic("mock out the DeleteSession method")
+//             },
+//             FindValidSessionFunc: func(id kallax.ULID) (*Session, error) {
+// 	               panic("mock out the FindValidSession method")
+//             },
+//             SaveSessionFunc: func(session Session) (Session, error) {
 // 	               panic("mock out the SaveSession method")
 //             },
 //         }
@@ -33,10 +45,19 @@ var (
 //     }
 type SessionHandlerMock struct {
 	// CreateSessionFunc mocks the CreateSession method.
-	CreateSessionFunc func(userID kallax.ULID, registeredUser bool) *Session
+	CreateSessionFunc func(userID kallax.ULID, registeredUser bool) (*Session, error)
+
+	// DeleteExpiredSessionsFunc mocks the DeleteExpiredSessions method.
+	DeleteExpiredSessionsFunc func() (int64, error)
+
+	// DeleteSessionFunc mocks the DeleteSession method.
+	DeleteSessionFunc func(id kallax.ULID) error
+
+	// FindValidSessionFunc mocks the FindValidSession method.
+	FindValidSessionFunc func(id kallax.ULID) (*Session, error)
 
 	// SaveSessionFunc mocks the SaveSession method.
-	SaveSessionFunc func(session Session) Session
+	SaveSessionFunc func(session Session) (Session, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -47,6 +68,19 @@ type SessionHandlerMock struct {
 			// RegisteredUser is the registeredUser argument value.
 			RegisteredUser bool
 		}
+		// DeleteExpiredSessions holds details about calls to the DeleteExpiredSessions method.
+		DeleteExpiredSessions []struct {
+		}
+		// DeleteSession holds details about calls to the DeleteSession method.
+		DeleteSession []struct {
+			// ID is the id argument value.
+			ID kallax.ULID
+		}
+		// FindValidSession holds details about calls to the FindValidSession method.
+		FindValidSession []struct {
+			// ID is the id argument value.
+			ID kallax.ULID
+		}
 		// SaveSession holds details about calls to the SaveSession method.
 		SaveSession []struct {
 			// Session is the session argument value.
@@ -56,7 +90,7 @@ type SessionHandlerMock struct {
 }
 
 // CreateSession calls CreateSessionFunc.
-func (mock *SessionHandlerMock) CreateSession(userID kallax.ULID, registeredUser bool) *Session {
+func (mock *SessionHandlerMock) CreateSession(userID kallax.ULID, registeredUser bool) (*Session, error) {
 	if mock.CreateSessionFunc == nil {
 		panic("SessionHandlerMock.CreateSessionFunc: method is nil but SessionHandler.CreateSession was just called")
 	}
@@ -90,8 +124,96 @@ func (mock *SessionHandlerMock) CreateSessionCalls() []struct {
 	return calls
 }
 
+// DeleteExpiredSessions calls DeleteExpiredSessionsFunc.
+func (mock *SessionHandlerMock) DeleteExpiredSessions() (int64, error) {
+	if mock.DeleteExpiredSessionsFunc == nil {
+		panic("SessionHandlerMock.DeleteExpiredSessionsFunc: method is nil but SessionHandler.DeleteExpiredSessions was just called")
+	}
+	callInfo := struct {
+	}{}
+	lockSessionHandlerMockDeleteExpiredSessions.Lock()
+	mock.calls.DeleteExpiredSessions = append(mock.calls.DeleteExpiredSessions, callInfo)
+	lockSessionHandlerMockDeleteExpiredSessions.Unlock()
+	return mock.DeleteExpiredSessionsFunc()
+}
+
+// DeleteExpiredSessionsCalls gets all the calls that were made to DeleteExpiredSessions.
+// Check the length with:
+//     len(mockedSessionHandler.DeleteExpiredSessionsCalls())
+func (mock *SessionHandlerMock) DeleteExpiredSessionsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	lockSessionHandlerMockDeleteExpiredSessions.RLock()
+	calls = mock.calls.DeleteExpiredSessions
+	lockSessionHandlerMockDeleteExpiredSessions.RUnlock()
+	return calls
+}
+
+// DeleteSession calls DeleteSessionFunc.
+func (mock *SessionHandlerMock) DeleteSession(id kallax.ULID) error {
+	if mock.DeleteSessionFunc == nil {
+		panic("SessionHandlerMock.DeleteSessionFunc: method is nil but SessionHandler.DeleteSession was just called")
+	}
+	callInfo := struct {
+		ID kallax.ULID
+	}{
+		ID: id,
+	}
+	lockSessionHandlerMockDeleteSession.Lock()
+	mock.calls.DeleteSession = append(mock.calls.DeleteSession, callInfo)
+	lockSessionHandlerMockDeleteSession.Unlock()
+	return mock.DeleteSessionFunc(id)
+}
+
+// DeleteSessionCalls gets all the calls that were made to DeleteSession.
+// Check the length with:
+//     len(mockedSessionHandler.DeleteSessionCalls())
+func (mock *SessionHandlerMock) DeleteSessionCalls() []struct {
+	ID kallax.ULID
+} {
+	var calls []struct {
+		ID kallax.ULID
+	}
+	lockSessionHandlerMockDeleteSession.RLock()
+	calls = mock.calls.DeleteSession
+	lockSessionHandlerMockDeleteSession.RUnlock()
+	return calls
+}
+
+// FindValidSession calls FindValidSessionFunc.
+func (mock *SessionHandlerMock) FindValidSession(id kallax.ULID) (*Session, error) {
+	if mock.FindValidSessionFunc == nil {
+		panic("SessionHandlerMock.FindValidSessionFunc: method is nil but SessionHandler.FindValidSession was just called")
+	}
+	callInfo := struct {
+		ID kallax.ULID
+	}{
+		ID: id,
+	}
+	lockSessionHandlerMockFindValidSession.Lock()
+	mock.calls.FindValidSession = append(mock.calls.FindValidSession, callInfo)
+	lockSessionHandlerMockFindValidSession.Unlock()
+	return mock.FindValidSessionFunc(id)
+}
+
+// FindValidSessionCalls gets all the calls that were made to FindValidSession.
+// Check the length with:
+//     len(mockedSessionHandler.FindValidSessionCalls())
+func (mock *SessionHandlerMock) FindValidSessionCalls() []struct {
+	ID kallax.ULID
+} {
+	var calls []struct {
+		ID kallax.ULID
+	}
+	lockSessionHandlerMockFindValidSession.RLock()
+	calls = mock.calls.FindValidSession
+	lockSessionHandlerMockFindValidSession.RUnlock()
+	return calls
+}
+
 // SaveSession calls SaveSessionFunc.
-func (mock *SessionHandlerMock) SaveSession(session Session) Session {
+func (mock *SessionHandlerMock) SaveSession(session Session) (Session, error) {
 	if mock.SaveSessionFunc == nil {
 		panic("SessionHandlerMock.SaveSessionFunc: method is nil but SessionHandler.SaveSession was just called")
 	}
@@ -0,0 +1,156 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"gopkg.in/src-d/go-kallax.v1"
+)
+
+const streamKeepAlive = 30 * time.Second
+
+const streamWriteWait = 10 * time.Second
+
+var upgrader = websocket.Upgrader{}
+
+//StreamPollTally sends the current tally of the poll and then every tally published for it,
+//until the client goes away. Clients asking for an upgrade get a WebSocket, the others get
+//Server-Sent Events.
+func StreamPollTally(helper HTTPHelper, pollHandler PollHandler, pollOptionHandler PollOptionHandler,
+	pollVoteHandler PollVoteHandler, hub *TallyHub) {
+	if err := helper.ValidateStreamSession(); err != nil {
+		helper.Forbid(err)
+		return
+	}
+
+	pollID, err := getPollIDFromRequest(helper)(nil)
+	if err != nil {
+		helper.Fail(err)
+		return
+	}
+
+	//Subscribing first keeps votes counted meanwhile from being missed.
+	sub := hub.Subscribe(pollID.(kallax.ULID))
+	defer hub.Unsubscribe(sub)
+
+	current, err := currentTallyView(sub.PollID, pollHandler, pollOptionHandler, pollVoteHandler)
+	if err != nil {
+		helper.Fail(err)
+		return
+	}
+
+	w, r := helper.Raw()
+	if websocket.IsWebSocketUpgrade(r) {
+		streamWebSocket(w, r, current, sub)
+		return
+	}
+
+	streamEvents(helper, current, sub)
+}
+
+func streamEvents(helper HTTPHelper, current interface{}, sub *TallySubscription) {
+	w, r := helper.Raw()
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		helper.Fail(fmt.Errorf("Streaming unsupported"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	send := func(format string, args ...interface{}) bool {
+		if _, err := fmt.Fprintf(w, format, args...); err != nil {
+			return false
+		}
+		flusher.Flush()
+		return true
+	}
+
+	sendTally := func(tally interface{}) bool {
+		data, err := json.Marshal(tally)
+		return err == nil && send("event: tally\ndata: %s\n\n", data)
+	}
+
+	if !sendTally(current) {
+		return
+	}
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			if !send(": keep-alive\n\n") {
+				return
+			}
+		case tally, open := <-sub.Updates:
+			if !open || !sendTally(tally) {
+				return
+			}
+		}
+	}
+}
+
+func streamWebSocket(w http.ResponseWriter, r *http.Request, current interface{}, sub *TallySubscription) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	//The client sends nothing but control frames, read only to notice when it goes away.
+	gone := make(chan struct{})
+	conn.SetReadDeadline(time.Now().Add(2 * streamKeepAlive))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * streamKeepAlive))
+	})
+	go func() {
+		defer close(gone)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	sendTally := func(tally interface{}) bool {
+		conn.SetWriteDeadline(time.Now().Add(streamWriteWait))
+		return conn.WriteJSON(tally) == nil
+	}
+
+	if !sendTally(current) {
+		return
+	}
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-gone:
+			return
+		case <-keepAlive.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteWait)); err != nil {
+				return
+			}
+		case tally, open := <-sub.Updates:
+			if !open {
+				conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseGoingAway, ""), time.Now().Add(streamWriteWait))
+				return
+			}
+
+			if !sendTally(tally) {
+				return
+			}
+		}
+	}
+}
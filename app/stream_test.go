@@ -0,0 +1,143 @@
+package app
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chai2010/assert"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+
+	"gopkg.in/src-d/go-kallax.v1"
+)
+
+func newStreamServer(hub *TallyHub) *httptest.Server {
+	options := newOptions("A", "B")
+	pollVoteHandlerMock := &PollVoteHandlerMock{
+		TallyByPollFunc: tallyEach(options, 1),
+		VotersOfFunc: func(pollID kallax.ULID) (int64, error) {
+			return 2, nil
+		},
+	}
+
+	router := mux.NewRouter()
+	router.HandleFunc("/polls/{id}/stream", func(w http.ResponseWriter, r *http.Request) {
+		helper := NewHTTPHelper(w, r)
+		helper.CheckSession = func(ID string) error {
+			return nil
+		}
+
+		StreamPollTally(helper, createOpenPollHandlerMock(), createOptionsMock(options), pollVoteHandlerMock, hub)
+	})
+
+	return httptest.NewServer(router)
+}
+
+func waitSubscribers(hub *TallyHub, pollID kallax.ULID, count int) bool {
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if hub.Subscribers(pollID) == count {
+			return true
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	return false
+}
+
+func readEvent(t *testing.T, reader *bufio.Reader) map[string]interface{} {
+	event, err := reader.ReadString('\n')
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, "event: tally\n", event)
+
+	data, err := reader.ReadString('\n')
+	assert.AssertNil(t, err)
+	reader.ReadString('\n')
+
+	var tally map[string]interface{}
+	json.Unmarshal([]byte(strings.TrimPrefix(data, "data: ")), &tally)
+	return tally
+}
+
+func TestStreamPollTallyOverServerSentEvents(t *testing.T) {
+	hub := NewTallyHub(1)
+	server := newStreamServer(hub)
+	defer server.Close()
+	pollID := kallax.NewULID()
+
+	request, _ := http.NewRequest("GET", server.URL+"/polls/"+pollID.String()+"/stream", nil)
+	request.Header.Set("sessionId", kallax.NewULID().String())
+	response, err := http.DefaultClient.Do(request)
+	assert.AssertNil(t, err)
+
+	assert.AssertEqual(t, "text/event-stream", response.Header.Get("Content-Type"))
+	reader := bufio.NewReader(response.Body)
+	current := readEvent(t, reader)
+	assert.AssertEqual(t, VotingPlurality, current["method"])
+
+	hub.Publish(pollID, hub.NextVersion(), PluralityView{Method: "updated"})
+	assert.AssertEqual(t, "updated", readEvent(t, reader)["method"])
+
+	response.Body.Close()
+	assert.AssertTrue(t, waitSubscribers(hub, pollID, 0))
+}
+
+func TestStreamPollTallyOverWebSocket(t *testing.T) {
+	hub := NewTallyHub(1)
+	server := newStreamServer(hub)
+	defer server.Close()
+	pollID := kallax.NewULID()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/polls/" + pollID.String() + "/stream?sessionId=x"
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	assert.AssertNil(t, err)
+
+	var tally map[string]interface{}
+	assert.AssertNil(t, conn.ReadJSON(&tally))
+	assert.AssertEqual(t, VotingPlurality, tally["method"])
+
+	hub.Publish(pollID, hub.NextVersion(), PluralityView{Method: "updated"})
+	assert.AssertNil(t, conn.ReadJSON(&tally))
+	assert.AssertEqual(t, "updated", tally["method"])
+
+	conn.Close()
+	assert.AssertTrue(t, waitSubscribers(hub, pollID, 0))
+}
+
+func TestShouldNotStreamPollTallyWithoutSession(t *testing.T) {
+	hub := NewTallyHub(1)
+	server := newStreamServer(hub)
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "/polls/" + kallax.NewULID().String() + "/stream")
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, http.StatusUnauthorized, response.StatusCode)
+}
+
+func TestStreamPollTallyFailsWhenCountingFails(t *testing.T) {
+	box := &ProcessErrorBox{}
+	hub := NewTallyHub(1)
+	helperMock := createPollChangeHelperMock()
+	helperMock.ValidateStreamSessionFunc = func() error { return nil }
+	helperMock.FailFunc = func(err error) {
+		box.ErrorOcurred = err
+	}
+	pollHandlerMock := &PollHandlerMock{
+		FindPollByIDFunc: func(ID kallax.ULID) (*Poll, error) {
+			return nil, fmt.Errorf("Connection reset")
+		},
+	}
+
+	StreamPollTally(helperMock, pollHandlerMock, &PollOptionHandlerMock{}, &PollVoteHandlerMock{}, hub)
+
+	assert.AssertEqual(t, "Connection reset", box.ErrorOcurred.Error())
+	assert.AssertEqual(t, 0, len(helperMock.RawCalls()))
+	assert.AssertEqual(t, 0, hub.Subscribers(pollHandlerMock.FindPollByIDCalls()[0].ID))
+}
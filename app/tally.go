@@ -0,0 +1,256 @@
+package app
+
+import (
+	"math"
+
+	"gopkg.in/src-d/go-kallax.v1"
+)
+
+//Tallier counts the votes of a poll following one voting method.
+type Tallier interface {
+	Tally(poll *Poll, pollOptionHandler PollOptionHandler, pollVoteHandler PollVoteHandler) (TallyResult, error)
+}
+
+//TallyResult is what a Tallier gives. Each voting method has its own.
+type TallyResult interface {
+	Method() string
+}
+
+//Talliers holds the Tallier of each voting method.
+var Talliers = map[string]Tallier{
+	VotingPlurality: PluralityTallier{},
+	VotingRanked:    RunoffTallier{},
+	VotingApproval:  ApprovalTallier{},
+	VotingScore:     ScoreTallier{},
+	VotingBorda:     BordaTallier{},
+}
+
+//TallierFor gives the Tallier of the voting method of the poll.
+func TallierFor(poll *Poll) Tallier {
+	if tallier, exists := Talliers[poll.CurrentVotingMethod()]; exists {
+		return tallier
+	}
+
+	return PluralityTallier{}
+}
+
+//SelectionTallyOf gives how many times each option was selected, which every voting
+//method counts along its own result.
+func SelectionTallyOf(result TallyResult) VoteTally {
+	switch r := result.(type) {
+	case VoteTally:
+		return r
+	case ApprovalResult:
+		return r.VoteTally
+	case RunoffResult:
+		return r.Tally
+	case ScoreResult:
+		return r.Tally
+	case BordaResult:
+		return r.Tally
+	}
+
+	return VoteTally{}
+}
+
+//PluralityTallier shares the selections among the options.
+type PluralityTallier struct{}
+
+//Tally ...
+func (PluralityTallier) Tally(poll *Poll, pollOptionHandler PollOptionHandler,
+	pollVoteHandler PollVoteHandler) (TallyResult, error) {
+	return TallyVotes(poll.ID, pollOptionHandler, pollVoteHandler)
+}
+
+//Method ...
+func (t VoteTally) Method() string {
+	return VotingPlurality
+}
+
+//RunoffTallier decides the poll by instant runoff.
+type RunoffTallier struct{}
+
+//Tally ...
+func (RunoffTallier) Tally(poll *Poll, pollOptionHandler PollOptionHandler,
+	pollVoteHandler PollVoteHandler) (TallyResult, error) {
+	return RunoffVotes(poll.ID, pollOptionHandler, pollVoteHandler)
+}
+
+//Method ...
+func (r RunoffResult) Method() string {
+	return VotingRanked
+}
+
+//ApprovalResult holds how many voters approved each option. The most approved options win.
+type ApprovalResult struct {
+	VoteTally
+	Winners []*PollOption
+}
+
+//Method ...
+func (r ApprovalResult) Method() string {
+	return VotingApproval
+}
+
+//ApprovalTallier counts the voters that approved each option.
+type ApprovalTallier struct{}
+
+//Tally ...
+func (ApprovalTallier) Tally(poll *Poll, pollOptionHandler PollOptionHandler,
+	pollVoteHandler PollVoteHandler) (TallyResult, error) {
+	tally, err := TallyVotes(poll.ID, pollOptionHandler, pollVoteHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	return ApprovalResult{
+		VoteTally: tally,
+		Winners:   winnersOf(tally.Options, tally.Selections),
+	}, nil
+}
+
+//ScoreResult holds the sum of the scores each option got. The highest sums win.
+type ScoreResult struct {
+	Options  []*PollOption
+	Totals   map[kallax.ULID]int64
+	Voters   int64
+	MaxScore int
+	Winners  []*PollOption
+	Tally    VoteTally
+}
+
+//Method ...
+func (r ScoreResult) Method() string {
+	return VotingScore
+}
+
+//Average gives the mean score of the option, taking options left unscored as 0.
+func (r ScoreResult) Average(option *PollOption) float64 {
+	if r.Voters == 0 {
+		return 0.0
+	}
+
+	return math.Round(float64(r.Totals[option.ID])/float64(r.Voters)*100) / 100
+}
+
+//ScoreTallier sums the scores given to each option.
+type ScoreTallier struct{}
+
+//Tally ...
+func (ScoreTallier) Tally(poll *Poll, pollOptionHandler PollOptionHandler,
+	pollVoteHandler PollVoteHandler) (TallyResult, error) {
+	options, err := pollOptionHandler.FindPollOptions(poll.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := pollVoteHandler.ScoresOf(poll.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	selections, err := pollVoteHandler.TallyByPoll(poll.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	voters, err := pollVoteHandler.VotersOf(poll.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ScoreResult{
+		Options:  options,
+		Totals:   totals,
+		Voters:   voters,
+		MaxScore: poll.ScoreScale(),
+		Winners:  winnersOf(options, totals),
+		Tally:    newVoteTally(options, selections, voters),
+	}, nil
+}
+
+//BordaResult holds the points each option got. The most pointed options win.
+type BordaResult struct {
+	Options []*PollOption
+	Points  map[kallax.ULID]int64
+	Winners []*PollOption
+	Tally   VoteTally
+}
+
+//Method ...
+func (r BordaResult) Method() string {
+	return VotingBorda
+}
+
+//BordaTallier gives points to the options by their place in each ranking.
+type BordaTallier struct{}
+
+//Tally ...
+func (BordaTallier) Tally(poll *Poll, pollOptionHandler PollOptionHandler,
+	pollVoteHandler PollVoteHandler) (TallyResult, error) {
+	options, err := pollOptionHandler.FindPollOptions(poll.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ballots, err := pollVoteHandler.BallotsOf(poll.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return BordaCount(options, ballots), nil
+}
+
+//BordaCount gives, on each ballot, as many points to an option as there are options
+//ranked below it: with n options the first place gets n-1 points and the last gets 0.
+//Options left out of a ballot get no points from it.
+func BordaCount(options []*PollOption, ballots []Ballot) BordaResult {
+	known := make(map[kallax.ULID]bool)
+	for _, opt := range options {
+		known[opt.ID] = true
+	}
+
+	points := make(map[kallax.ULID]int64)
+	for _, ballot := range ballots {
+		place := 0
+		for _, ID := range ballot {
+			if !known[ID] {
+				continue
+			}
+
+			points[ID] += int64(len(options) - 1 - place)
+			place++
+		}
+	}
+
+	return BordaResult{
+		Options: options,
+		Points:  points,
+		Winners: winnersOf(options, points),
+		Tally:   BallotTally(options, ballots),
+	}
+}
+
+//winnersOf gives the options with the highest count. Ties give more than one winner and
+//nothing counted gives none.
+func winnersOf(options []*PollOption, counts map[kallax.ULID]int64) []*PollOption {
+	highest := int64(0)
+	for _, opt := range options {
+		if counts[opt.ID] > highest {
+			highest = counts[opt.ID]
+		}
+	}
+
+	winners := make([]*PollOption, 0)
+	if highest == 0 {
+		return winners
+	}
+
+	for _, opt := range options {
+		if counts[opt.ID] == highest {
+			winners = append(winners, opt)
+		}
+	}
+
+	return winners
+}
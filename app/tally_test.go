@@ -0,0 +1,157 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/chai2010/assert"
+
+	"gopkg.in/src-d/go-kallax.v1"
+)
+
+func TestTallierFor(t *testing.T) {
+	assert.AssertEqual(t, PluralityTallier{}, TallierFor(&Poll{}))
+	assert.AssertEqual(t, RunoffTallier{}, TallierFor(&Poll{VotingMethod: VotingRanked}))
+	assert.AssertEqual(t, ApprovalTallier{}, TallierFor(&Poll{VotingMethod: VotingApproval}))
+	assert.AssertEqual(t, ScoreTallier{}, TallierFor(&Poll{VotingMethod: VotingScore}))
+	assert.AssertEqual(t, BordaTallier{}, TallierFor(&Poll{VotingMethod: VotingBorda}))
+	assert.AssertEqual(t, PluralityTallier{}, TallierFor(&Poll{VotingMethod: "condorcet"}))
+
+	for _, method := range VotingMethods {
+		result, _ := Talliers[method].Tally(&Poll{}, createOptionsMock(newOptions("A")), &PollVoteHandlerMock{
+			TallyByPollFunc: func(pollID kallax.ULID) (map[kallax.ULID]int64, error) { return nil, nil },
+			VotersOfFunc:    func(pollID kallax.ULID) (int64, error) { return 0, nil },
+			BallotsOfFunc:   func(pollID kallax.ULID) ([]Ballot, error) { return nil, nil },
+			ScoresOfFunc:    func(pollID kallax.ULID) (map[kallax.ULID]int64, error) { return nil, nil },
+		})
+		assert.AssertEqual(t, method, result.Method())
+	}
+}
+
+func TestBordaCount(t *testing.T) {
+	options := newOptions("A", "B", "C")
+	a, b, c := options[0].ID, options[1].ID, options[2].ID
+
+	cases := []struct {
+		name    string
+		ballots []Ballot
+		points  []int64
+		winners []string
+	}{
+		{
+			name:    "full rankings",
+			ballots: []Ballot{{a, b, c}, {b, a, c}, {a, c, b}},
+			points:  []int64{5, 3, 1},
+			winners: []string{"A"},
+		},
+		{
+			name:    "partial rankings leave the rest without points",
+			ballots: []Ballot{{c}, {b, a}},
+			points:  []int64{1, 2, 2},
+			winners: []string{"B", "C"},
+		},
+		{
+			name:    "unknown options are skipped",
+			ballots: []Ballot{{kallax.NewULID(), b}},
+			points:  []int64{0, 2, 0},
+			winners: []string{"B"},
+		},
+		{
+			name:    "no ballots",
+			ballots: []Ballot{},
+			points:  []int64{0, 0, 0},
+			winners: []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		result := BordaCount(options, tc.ballots)
+
+		for i, opt := range options {
+			assert.AssertEqual(t, tc.points[i], result.Points[opt.ID], tc.name, opt.Content)
+		}
+		assert.AssertEqual(t, tc.winners, contentsOf(result.Winners), tc.name)
+	}
+}
+
+func TestApprovalTallier(t *testing.T) {
+	options := newOptions("A", "B", "C")
+	approvals := map[kallax.ULID]int64{options[0].ID: 3, options[1].ID: 1, options[2].ID: 3}
+	pollVoteHandlerMock := &PollVoteHandlerMock{
+		TallyByPollFunc: func(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+			return approvals, nil
+		},
+		VotersOfFunc: func(pollID kallax.ULID) (int64, error) {
+			return 4, nil
+		},
+	}
+
+	result, err := ApprovalTallier{}.Tally(&Poll{ID: kallax.NewULID()}, createOptionsMock(options), pollVoteHandlerMock)
+
+	assert.AssertNil(t, err)
+	view := NewTallyView(result).(ApprovalView)
+	assert.AssertEqual(t, VotingApproval, view.Method)
+	assert.AssertEqual(t, 3, len(view.Options))
+	assert.AssertEqual(t, int64(3), view.Approvals[options[0].ID.String()])
+	assert.AssertEqual(t, 75.0, view.Counting[options[0].ID.String()])
+	assert.AssertEqual(t, 25.0, view.Counting[options[1].ID.String()])
+	assert.AssertEqual(t, int64(4), view.Voters)
+	assert.AssertEqual(t, []string{options[0].ID.String(), options[2].ID.String()}, view.Winners)
+}
+
+func TestScoreTallier(t *testing.T) {
+	options := newOptions("A", "B")
+	pollVoteHandlerMock := &PollVoteHandlerMock{
+		ScoresOfFunc: func(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+			return map[kallax.ULID]int64{options[0].ID: 7, options[1].ID: 9}, nil
+		},
+		TallyByPollFunc: tallyEach(options, 3),
+		VotersOfFunc: func(pollID kallax.ULID) (int64, error) {
+			return 3, nil
+		},
+	}
+
+	result, err := ScoreTallier{}.Tally(&Poll{ID: kallax.NewULID(), MaxScore: 10}, createOptionsMock(options), pollVoteHandlerMock)
+
+	assert.AssertNil(t, err)
+	view := NewTallyView(result).(ScoreView)
+	assert.AssertEqual(t, VotingScore, view.Method)
+	assert.AssertEqual(t, int64(9), view.Totals[options[1].ID.String()])
+	assert.AssertEqual(t, 2.33, view.Averages[options[0].ID.String()])
+	assert.AssertEqual(t, 3.0, view.Averages[options[1].ID.String()])
+	assert.AssertEqual(t, 10, view.MaxScore)
+	assert.AssertEqual(t, []string{options[1].ID.String()}, view.Winners)
+	assert.AssertEqual(t, int64(3), SelectionTallyOf(result).Selections[options[1].ID])
+}
+
+func TestScoreTallierFailsWhenScoresFail(t *testing.T) {
+	pollVoteHandlerMock := &PollVoteHandlerMock{
+		ScoresOfFunc: func(pollID kallax.ULID) (map[kallax.ULID]int64, error) {
+			return nil, fmt.Errorf("Connection reset")
+		},
+	}
+
+	_, err := ScoreTallier{}.Tally(&Poll{}, createOptionsMock(newOptions("A")), pollVoteHandlerMock)
+
+	assert.AssertEqual(t, "Connection reset", err.Error())
+}
+
+func TestScoreAverageWithoutVoters(t *testing.T) {
+	options := newOptions("A")
+	result := ScoreResult{Options: options, Totals: map[kallax.ULID]int64{}}
+
+	assert.AssertEqual(t, 0.0, result.Average(options[0]))
+}
+
+func TestTallyViewKeepsOptionsWithTheSameContentApart(t *testing.T) {
+	options := newOptions("Pizza", "Pizza")
+	tally := newVoteTally(options, map[kallax.ULID]int64{options[0].ID: 3, options[1].ID: 1}, 4)
+
+	view := NewTallyView(tally).(PluralityView)
+
+	assert.AssertEqual(t, 75.0, view.Counting[options[0].ID.String()])
+	assert.AssertEqual(t, 25.0, view.Counting[options[1].ID.String()])
+	assert.AssertEqual(t, 4.0, view.Counting["total"])
+	assert.AssertEqual(t, 3, len(view.Counting))
+	assert.AssertEqual(t, NewPollOptionViews(options), view.Options)
+}
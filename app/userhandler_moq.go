@@ -9,6 +9,7 @@ import (
 )
 
 var (
+	lockUserHandlerMockClaimAnonUser              sync.RWMutex
 	lockUserHandlerMockCreateAnonUser             sync.RWMutex
 	lockUserHandlerMockCreateUserFromData         sync.RWMutex
 	lockUserHandlerMockFindUserByID               sync.RWMutex
@@ -23,7 +24,10 @@ var (
 //
 //         // make and configure a mocked UserHandler
 //         mockedUserHandler := &UserHandlerMock{
-//             CreateAnonUserFunc: func() User {
+//             ClaimAnonUserFunc: func(ID kallax.ULID, d *UserCreationData, events ...Event) (*User, error) {
+// 	               panic("mock out the ClaimAnonUser method")
+//             },
+//             CreateAnonUserFunc: func() (User, error) {
 // 	               panic("mock out the CreateAnonUser method")
 //             },
 //             CreateUserFromDataFunc: func(d *UserCreationData) (User, error) {
@@ -38,7 +42,7 @@ var (
 //             FindUserByLoginAndPasswordFunc: func(login string, password string) (*User, error) {
 // 	               panic("mock out the FindUserByLoginAndPassword method")
 //             },
-//             SaveUserFunc: func(user User) User {
+//             SaveUserFunc: func(user User, events ...Event) (User, error) {
 // 	               panic("mock out the SaveUser method")
 //             },
 //         }
@@ -48,8 +52,11 @@ var (
 //
 //     }
 type UserHandlerMock struct {
+	// ClaimAnonUserFunc mocks the ClaimAnonUser method.
+	ClaimAnonUserFunc func(ID kallax.ULID, d *UserCreationData, events ...Event) (*User, error)
+
 	// CreateAnonUserFunc mocks the CreateAnonUser method.
-	CreateAnonUserFunc func() User
+	CreateAnonUserFunc func() (User, error)
 
 	// CreateUserFromDataFunc mocks the CreateUserFromData method.
 	CreateUserFromDataFunc func(d *UserCreationData) (User, error)
@@ -64,10 +71,19 @@ type UserHandlerMock struct {
 	FindUserByLoginAndPasswordFunc func(login string, password string) (*User, error)
 
 	// SaveUserFunc mocks the SaveUser method.
-	SaveUserFunc func(user User) User
+	SaveUserFunc func(user User, events ...Event) (User, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// ClaimAnonUser holds details about calls to the ClaimAnonUser method.
+		ClaimAnonUser []struct {
+			// ID is the ID argument value.
+			ID kallax.ULID
+			// D is the d argument value.
+			D *UserCreationData
+			// Events is the events argument value.
+			Events []Event
+		}
 		// CreateAnonUser holds details about calls to the CreateAnonUser method.
 		CreateAnonUser []struct {
 		}
@@ -97,12 +113,53 @@ type UserHandlerMock struct {
 		SaveUser []struct {
 			// User is the user argument value.
 			User User
+			// Events is the events argument value.
+			Events []Event
 		}
 	}
 }
 
+// ClaimAnonUser calls ClaimAnonUserFunc.
+func (mock *UserHandlerMock) ClaimAnonUser(ID kallax.ULID, d *UserCreationData, events ...Event) (*User, error) {
+	if mock.ClaimAnonUserFunc == nil {
+		panic("UserHandlerMock.ClaimAnonUserFunc: method is nil but UserHandler.ClaimAnonUser was just called")
+	}
+	callInfo := struct {
+		ID     kallax.ULID
+		D      *UserCreationData
+		Events []Event
+	}{
+		ID:     ID,
+		D:      d,
+		Events: events,
+	}
+	lockUserHandlerMockClaimAnonUser.Lock()
+	mock.calls.ClaimAnonUser = append(mock.calls.ClaimAnonUser, callInfo)
+	lockUserHandlerMockClaimAnonUser.Unlock()
+	return mock.ClaimAnonUserFunc(ID, d, events...)
+}
+
+// ClaimAnonUserCalls gets all the calls that were made to ClaimAnonUser.
+// Check the length with:
+//     len(mockedUserHandler.ClaimAnonUserCalls())
+func (mock *UserHandlerMock) ClaimAnonUserCalls() []struct {
+	ID     kallax.ULID
+	D      *UserCreationData
+	Events []Event
+} {
+	var calls []struct {
+		ID     kallax.ULID
+		D      *UserCreationData
+		Events []Event
+	}
+	lockUserHandlerMockClaimAnonUser.RLock()
+	calls = mock.calls.ClaimAnonUser
+	lockUserHandlerMockClaimAnonUser.RUnlock()
+	return calls
+}
+
 // CreateAnonUser calls CreateAnonUserFunc.
-func (mock *UserHandlerMock) CreateAnonUser() User {
+func (mock *UserHandlerMock) CreateAnonUser() (User, error) {
 	if mock.CreateAnonUserFunc == nil {
 		panic("UserHandlerMock.CreateAnonUserFunc: method is nil but UserHandler.CreateAnonUser was just called")
 	}
@@ -256,29 +313,33 @@ func (mock *UserHandlerMock) FindUserByLoginAndPasswordCalls() []struct {
 }
 
 // SaveUser calls SaveUserFunc.
-func (mock *UserHandlerMock) SaveUser(user User) User {
+func (mock *UserHandlerMock) SaveUser(user User, events ...Event) (User, error) {
 	if mock.SaveUserFunc == nil {
 		panic("UserHandlerMock.SaveUserFunc: method is nil but UserHandler.SaveUser was just called")
 	}
 	callInfo := struct {
-		User User
+		User   User
+		Events []Event
 	}{
-		User: user,
+		User:   user,
+		Events: events,
 	}
 	lockUserHandlerMockSaveUser.Lock()
 	mock.calls.SaveUser = append(mock.calls.SaveUser, callInfo)
 	lockUserHandlerMockSaveUser.Unlock()
-	return mock.SaveUserFunc(user)
+	return mock.SaveUserFunc(user, events...)
 }
 
 // SaveUserCalls gets all the calls that were made to SaveUser.
 // Check the length with:
 //     len(mockedUserHandler.SaveUserCalls())
 func (mock *UserHandlerMock) SaveUserCalls() []struct {
-	User User
+	User   User
+	Events []Event
 } {
 	var calls []struct {
-		User User
+		User   User
+		Events []Event
 	}
 	lockUserHandlerMockSaveUser.RLock()
 	calls = mock.calls.SaveUser
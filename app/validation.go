@@ -0,0 +1,205 @@
+package app
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+//Limits applied to user data.
+const (
+	MaxLoginLength    = 254
+	MaxNameLength     = 100
+	MinPasswordLength = 8
+	MaxPasswordLength = 72
+)
+
+var loginPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+//ErrInvalidFields tells which fields were rejected and why.
+type ErrInvalidFields map[string]string
+
+func (e ErrInvalidFields) Error() string {
+	fields := make([]string, 0, len(e))
+	for field := range e {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	messages := make([]string, 0, len(fields))
+	for _, field := range fields {
+		messages = append(messages, field+": "+e[field])
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+//Code ...
+func (e ErrInvalidFields) Code() string {
+	return CodeValidation
+}
+
+//Status ...
+func (e ErrInvalidFields) Status() int {
+	return ErrValidation("").Status()
+}
+
+//Details ...
+func (e ErrInvalidFields) Details() interface{} {
+	return map[string]string(e)
+}
+
+//Validator collects field errors so every problem is reported at once.
+type Validator struct {
+	errs ErrInvalidFields
+}
+
+//Check records the message for the field when the condition doesn't hold.
+//Only the first failure of each field is kept.
+func (v *Validator) Check(ok bool, field, message string) {
+	if ok {
+		return
+	}
+
+	if v.errs == nil {
+		v.errs = make(ErrInvalidFields)
+	}
+
+	if _, exists := v.errs[field]; !exists {
+		v.errs[field] = message
+	}
+}
+
+//Err returns nil when every check passed.
+func (v *Validator) Err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+
+	return v.errs
+}
+
+//ValidateLogin ...
+func ValidateLogin(v *Validator, login string) {
+	v.Check(login != "", "login", "must be informed")
+	v.Check(len(login) <= MaxLoginLength, "login", "is too long")
+	v.Check(loginPattern.MatchString(login), "login", "must be an e-mail address")
+}
+
+//ValidateName ...
+func ValidateName(v *Validator, name string) {
+	v.Check(utf8.RuneCountInString(name) <= MaxNameLength, "name", "is too long")
+}
+
+//ValidatePassword requires a minimum length and both letters and digits.
+func ValidatePassword(v *Validator, password string) {
+	v.Check(len(password) >= MinPasswordLength, "password",
+		fmt.Sprintf("must have at least %d characters", MinPasswordLength))
+	v.Check(len(password) <= MaxPasswordLength, "password",
+		fmt.Sprintf("must have at most %d bytes", MaxPasswordLength))
+
+	var letter, digit bool
+	for _, r := range password {
+		letter = letter || unicode.IsLetter(r)
+		digit = digit || unicode.IsDigit(r)
+	}
+	v.Check(letter && digit, "password", "must mix letters and digits")
+}
+
+//ValidatePollWindow requires the closing time to come after the opening time.
+func ValidatePollWindow(v *Validator, opensAt, closesAt *time.Time) {
+	v.Check(opensAt == nil || closesAt == nil || closesAt.After(*opensAt), "closesAt", "must be after opensAt")
+}
+
+//ValidateChoiceRange accepts unset limits, which fall back to a single choice.
+func ValidateChoiceRange(v *Validator, min, max int) {
+	v.Check(min >= 0, "minChoices", "must not be negative")
+	v.Check(max >= 0, "maxChoices", "must not be negative")
+	v.Check(max == 0 || max >= min, "maxChoices", "must not be less than minChoices")
+}
+
+//ValidateVotingMethod accepts an unset method, which falls back to plurality.
+func ValidateVotingMethod(v *Validator, method string) {
+	_, known := Talliers[method]
+	v.Check(method == "" || known, "votingMethod", "must be one of "+strings.Join(VotingMethods, ", "))
+}
+
+//ValidateMaxScore accepts an unset maximum, which falls back to DefaultMaxScore.
+func ValidateMaxScore(v *Validator, maxScore int) {
+	v.Check(maxScore >= 0, "maxScore", "must not be negative")
+}
+
+//ValidateWebhookURL requires an absolute http or https URL that doesn't name localhost or
+//a non-public address. Names are only resolved when posting, by NewWebhookClient.
+func ValidateWebhookURL(v *Validator, raw string) {
+	parsed, err := url.Parse(raw)
+	v.Check(raw != "", "url", "must be informed")
+	absolute := err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+	v.Check(absolute, "url", "must be an absolute http or https URL")
+	if !absolute {
+		return
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(parsed.Hostname(), "."))
+	ip := net.ParseIP(host)
+	v.Check(host != "localhost" && !strings.HasSuffix(host, ".localhost") && (ip == nil || IsPublicIP(ip)),
+		"url", "must be a public address")
+}
+
+//ValidateWebhookTriggers requires at least one known trigger, and a positive number of
+//votes when the votes trigger is chosen.
+func ValidateWebhookTriggers(v *Validator, triggers []string, votes int) {
+	v.Check(len(triggers) > 0, "events", "must be informed")
+
+	asksVotes := false
+	for _, trigger := range triggers {
+		known := false
+		for _, t := range WebhookTriggers {
+			known = known || trigger == t
+		}
+		v.Check(known, "events", "must be some of "+strings.Join(WebhookTriggers, ", "))
+		asksVotes = asksVotes || trigger == TriggerVotes
+	}
+
+	v.Check(!asksVotes || votes > 0, "votes", "must be positive")
+	v.Check(asksVotes || votes == 0, "votes", "is only taken with the votes event")
+}
+
+//ValidateCreateWebhookData ...
+func ValidateCreateWebhookData(d *CreateWebhookData) error {
+	v := &Validator{}
+
+	ValidateWebhookURL(v, d.URL)
+	ValidateWebhookTriggers(v, d.Events, d.Votes)
+
+	return v.Err()
+}
+
+//ValidateCreatePollData ...
+func ValidateCreatePollData(d *CreatePollData) error {
+	v := &Validator{}
+
+	ValidatePollWindow(v, d.OpensAt, d.ClosesAt)
+	ValidateChoiceRange(v, d.MinChoices, d.MaxChoices)
+	ValidateVotingMethod(v, d.VotingMethod)
+	ValidateMaxScore(v, d.MaxScore)
+
+	return v.Err()
+}
+
+//ValidateUserCreationData ...
+func ValidateUserCreationData(d *UserCreationData) error {
+	v := &Validator{}
+
+	ValidateLogin(v, d.Login)
+	ValidateName(v, d.Name)
+	ValidatePassword(v, d.Password)
+
+	return v.Err()
+}
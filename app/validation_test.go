@@ -0,0 +1,133 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chai2010/assert"
+)
+
+func TestValidateUserCreationData(t *testing.T) {
+	err := ValidateUserCreationData(&UserCreationData{
+		Login:    "candace@disney.com",
+		Name:     "Candace Flynn",
+		Password: "busted2019",
+	})
+
+	assert.AssertNil(t, err)
+}
+
+func TestValidateUserCreationDataReportsEveryField(t *testing.T) {
+	err := ValidateUserCreationData(&UserCreationData{
+		Login:    "",
+		Name:     strings.Repeat("a", MaxNameLength+1),
+		Password: "onlyletters",
+	})
+
+	invalid := err.(ErrInvalidFields)
+	assert.AssertEqual(t, 3, len(invalid))
+	assert.AssertEqual(t, "must be informed", invalid["login"])
+	assert.AssertEqual(t, "is too long", invalid["name"])
+	assert.AssertEqual(t, "must mix letters and digits", invalid["password"])
+	assert.AssertEqual(t, "login: must be informed; name: is too long; password: must mix letters and digits", err.Error())
+}
+
+func TestValidatePasswordLength(t *testing.T) {
+	short := &Validator{}
+	ValidatePassword(short, "a1")
+	assert.AssertEqual(t, "must have at least 8 characters", short.Err().(ErrInvalidFields)["password"])
+
+	long := &Validator{}
+	ValidatePassword(long, strings.Repeat("a1", 40))
+	assert.AssertEqual(t, "must have at most 72 bytes", long.Err().(ErrInvalidFields)["password"])
+}
+
+func TestValidateLoginFormat(t *testing.T) {
+	for _, login := range []string{"doofenshmirtz", "perry@", "@agency.com", "perry the@agency.com"} {
+		v := &Validator{}
+		ValidateLogin(v, login)
+		assert.AssertNotNil(t, v.Err(), login)
+	}
+}
+
+func TestInvalidFieldsGoToErrorDetails(t *testing.T) {
+	status, response := NewErrorResponse(ErrInvalidFields{"login": "must be informed"}, nil)
+
+	assert.AssertEqual(t, 400, status)
+	assert.AssertEqual(t, CodeValidation, response.Code)
+	assert.AssertEqual(t, "must be informed", response.Details.(map[string]string)["login"])
+}
+
+func TestValidateChoiceRange(t *testing.T) {
+	valid := &Validator{}
+	ValidateChoiceRange(valid, 0, 0)
+	ValidateChoiceRange(valid, 1, 3)
+	assert.AssertNil(t, valid.Err())
+
+	inverted := &Validator{}
+	ValidateChoiceRange(inverted, 3, 2)
+	assert.AssertEqual(t, "must not be less than minChoices", inverted.Err().(ErrInvalidFields)["maxChoices"])
+
+	negative := &Validator{}
+	ValidateChoiceRange(negative, -1, 2)
+	assert.AssertEqual(t, "must not be negative", negative.Err().(ErrInvalidFields)["minChoices"])
+}
+
+func TestValidateVotingMethod(t *testing.T) {
+	for _, method := range append([]string{""}, VotingMethods...) {
+		v := &Validator{}
+		ValidateVotingMethod(v, method)
+		assert.AssertNil(t, v.Err(), method)
+	}
+
+	v := &Validator{}
+	ValidateVotingMethod(v, "condorcet")
+	assert.AssertEqual(t, "must be one of plurality, ranked, approval, score, borda", v.Err().(ErrInvalidFields)["votingMethod"])
+}
+
+func TestValidateMaxScore(t *testing.T) {
+	v := &Validator{}
+	ValidateMaxScore(v, -1)
+	assert.AssertEqual(t, "must not be negative", v.Err().(ErrInvalidFields)["maxScore"])
+}
+
+func TestValidateWebhookURL(t *testing.T) {
+	for _, url := range []string{"https://example.com/polls?token=1", "http://93.184.216.34:8080/hook"} {
+		v := &Validator{}
+		ValidateWebhookURL(v, url)
+		assert.AssertNil(t, v.Err(), url)
+	}
+
+	for _, url := range []string{"", "example.com/hook", "/hook", "mailto:owner@example.com", "https://"} {
+		v := &Validator{}
+		ValidateWebhookURL(v, url)
+		assert.AssertNotNil(t, v.Err(), url)
+	}
+
+	for _, url := range []string{"http://localhost:8080/hook", "http://LOCALHOST./hook", "http://api.localhost/hook",
+		"http://127.0.0.1/hook", "http://10.0.0.5/hook", "http://169.254.169.254/latest", "http://[::1]/hook",
+		"http://[::ffff:192.168.0.1]/hook", "http://0.0.0.0/hook"} {
+		v := &Validator{}
+		ValidateWebhookURL(v, url)
+		assert.AssertEqual(t, "must be a public address", v.Err().(ErrInvalidFields)["url"], url)
+	}
+}
+
+func TestValidateWebhookTriggers(t *testing.T) {
+	valid := &Validator{}
+	ValidateWebhookTriggers(valid, []string{TriggerPublished, TriggerClosed}, 0)
+	ValidateWebhookTriggers(valid, []string{TriggerVotes}, 5)
+	assert.AssertNil(t, valid.Err())
+
+	none := &Validator{}
+	ValidateWebhookTriggers(none, nil, 0)
+	assert.AssertEqual(t, "must be informed", none.Err().(ErrInvalidFields)["events"])
+
+	unknown := &Validator{}
+	ValidateWebhookTriggers(unknown, []string{"deleted"}, 0)
+	assert.AssertEqual(t, "must be some of published, closed, votes", unknown.Err().(ErrInvalidFields)["events"])
+
+	stray := &Validator{}
+	ValidateWebhookTriggers(stray, []string{TriggerClosed}, 5)
+	assert.AssertEqual(t, "is only taken with the votes event", stray.Err().(ErrInvalidFields)["votes"])
+}
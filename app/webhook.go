@@ -0,0 +1,361 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	kallax "gopkg.in/src-d/go-kallax.v1"
+)
+
+//Webhook delivery limits. The wait before a retry starts at WebhookBackoff and doubles
+//after each failed attempt.
+const (
+	MaxWebhookAttempts = 6
+	WebhookBackoff     = 30 * time.Second
+)
+
+//Events posted to the webhooks.
+const (
+	WebhookPollPublished = "poll.published"
+	WebhookPollClosed    = "poll.closed"
+	WebhookVotesReached  = "poll.votes_reached"
+)
+
+//nonPublicNetworks holds the loopback, private, link-local, shared and reserved ranges.
+//Webhooks never reach them, so owners can't make the server post to its own network.
+var nonPublicNetworks = parseNetworks(
+	"0.0.0.0/8", "10.0.0.0/8", "100.64.0.0/10", "127.0.0.0/8", "169.254.0.0/16", "172.16.0.0/12",
+	"192.0.0.0/24", "192.0.2.0/24", "192.88.99.0/24", "192.168.0.0/16", "198.18.0.0/15",
+	"198.51.100.0/24", "203.0.113.0/24", "224.0.0.0/4", "240.0.0.0/4",
+	"::/128", "::1/128", "64:ff9b::/96", "100::/64", "2001::/23", "2001:db8::/32", "fc00::/7",
+	"fe80::/10", "ff00::/8",
+)
+
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+
+	return networks
+}
+
+//IsPublicIP tells if the address is outside of every loopback, private, link-local and
+//reserved range. IPv4 addresses mapped to IPv6 are taken as IPv4.
+func IsPublicIP(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+var errWebhookRedirect = errors.New("Webhooks can't redirect")
+
+//NewWebhookClient gives the client that posts the webhooks. It checks each address after
+//it is resolved, right before connecting, so names resolving to a non-public address are
+//refused too, and it doesn't follow redirects nor go through proxies.
+func NewWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			if ip := net.ParseIP(host); ip == nil || !IsPublicIP(ip) {
+				return fmt.Errorf("Webhooks can't reach %s", host)
+			}
+
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+				return dialer.DialContext(ctx, network, address)
+			},
+			TLSHandshakeTimeout: timeout,
+		},
+		CheckRedirect: func(request *http.Request, via []*http.Request) error {
+			return errWebhookRedirect
+		},
+	}
+}
+
+//NewWebhookSecret gives a random key to sign the payloads of a webhook.
+func NewWebhookSecret() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(key), nil
+}
+
+//SignWebhookPayload gives the hex HMAC-SHA256 of the body keyed by the secret. Receivers
+//compare it with the X-Poll-Signature header, after the "sha256=" prefix.
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+//WebhookBackoffAfter tells how long to wait for the next attempt after the given number
+//of failed attempts.
+func WebhookBackoffAfter(attempts int) time.Duration {
+	if attempts < 1 {
+		return WebhookBackoff
+	}
+
+	return WebhookBackoff << uint(attempts-1)
+}
+
+//NotifyWebhooks queues a delivery to every webhook of the poll that asked for the event.
+//The vote threshold of a webhook is notified once, by the vote that reaches it. An event
+//handled again with the same key doesn't queue a second delivery, so the event can be
+//handed again when queueing fails.
+func NotifyWebhooks(webhookHandler WebhookHandler, pollVoteHandler PollVoteHandler, clock Clock) EventHandler {
+	return func(event Event) error {
+		var err error
+
+		switch e := event.(type) {
+		case PollPublishedEvent:
+			err = notifyWebhooksOn(webhookHandler, e.PollID, e.Key, clock(), func(w *PollWebhook) bool {
+				return w.OnPublish
+			}, WebhookPayload{Event: WebhookPollPublished, Status: e.Status, OccurredAt: e.At})
+		case PollClosedEvent:
+			err = notifyWebhooksOn(webhookHandler, e.PollID, e.Key, clock(), func(w *PollWebhook) bool {
+				return w.OnClose
+			}, WebhookPayload{Event: WebhookPollClosed, Status: PollClosed, OccurredAt: e.At})
+		case VoteCastEvent:
+			err = notifyVoteThresholds(webhookHandler, pollVoteHandler, e, clock())
+		}
+
+		return err
+	}
+}
+
+func notifyWebhooksOn(webhookHandler WebhookHandler, pollID kallax.ULID, key string, now time.Time,
+	wants func(*PollWebhook) bool, payload WebhookPayload) error {
+	webhooks, err := webhookHandler.FindWebhooks(pollID)
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		if !wants(webhook) {
+			continue
+		}
+
+		if err := queueDelivery(webhookHandler, webhook, key, payload, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func notifyVoteThresholds(webhookHandler WebhookHandler, pollVoteHandler PollVoteHandler, vote VoteCastEvent,
+	now time.Time) error {
+	webhooks, err := webhookHandler.FindWebhooks(vote.PollID)
+	if err != nil {
+		return err
+	}
+
+	waiting := make([]*PollWebhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		if webhook.VoteThreshold > 0 && !webhook.ThresholdReached {
+			waiting = append(waiting, webhook)
+		}
+	}
+
+	if len(waiting) == 0 {
+		return nil
+	}
+
+	voters, err := pollVoteHandler.VotersOf(vote.PollID)
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range waiting {
+		if voters < int64(webhook.VoteThreshold) {
+			continue
+		}
+
+		payload := WebhookPayload{Event: WebhookVotesReached, Votes: voters, OccurredAt: vote.At}
+		delivery, err := newDelivery(webhook, vote.Key, payload, now)
+		if err != nil {
+			return err
+		}
+
+		if _, err := webhookHandler.ReachThreshold(webhook.ID, delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func queueDelivery(webhookHandler WebhookHandler, webhook *PollWebhook, key string, payload WebhookPayload,
+	now time.Time) error {
+	delivery, err := newDelivery(webhook, key, payload, now)
+	if err != nil {
+		return err
+	}
+
+	_, err = webhookHandler.SaveDelivery(delivery)
+	return err
+}
+
+func newDelivery(webhook *PollWebhook, key string, payload WebhookPayload, now time.Time) (PollWebhookDelivery, error) {
+	ID := kallax.NewULID()
+	payload.PollID = webhook.PollID.String()
+	payload.DeliveryID = ID.String()
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return PollWebhookDelivery{}, err
+	}
+
+	return PollWebhookDelivery{
+		ID:            ID,
+		WebhookID:     webhook.ID,
+		Event:         payload.Event,
+		Payload:       string(body),
+		Status:        DeliveryPending,
+		NextAttemptAt: now,
+		EventKey:      key,
+	}, nil
+}
+
+//RunWebhookDeliveries claims and posts the deliveries that are due, so each one is posted
+//by a single worker. A delivery answered with a 2xx
+//status is done; any other outcome is retried later, until MaxWebhookAttempts, when the
+//delivery is left dead. It tells how many deliveries were done.
+//A delivery may be posted again when its outcome can't be saved, so receivers should
+//ignore the X-Poll-Delivery IDs they already handled.
+func RunWebhookDeliveries(webhookHandler WebhookHandler, client *http.Client, now time.Time) (int, error) {
+	deliveries, err := webhookHandler.ClaimDueDeliveries(now)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, delivery := range deliveries {
+		webhook, err := webhookHandler.FindWebhook(delivery.WebhookID)
+		if err != nil {
+			return delivered, err
+		}
+
+		recordWebhookAttempt(delivery, postWebhook(client, webhook, delivery), now)
+
+		if _, err := webhookHandler.SaveDelivery(*delivery); err != nil {
+			return delivered, err
+		}
+
+		if delivery.Status == DeliveryDelivered {
+			delivered++
+		}
+	}
+
+	return delivered, nil
+}
+
+func postWebhook(client *http.Client, webhook *PollWebhook, delivery *PollWebhookDelivery) error {
+	body := []byte(delivery.Payload)
+
+	request, err := http.NewRequest("POST", webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Poll-Event", delivery.Event)
+	request.Header.Set("X-Poll-Delivery", delivery.ID.String())
+	request.Header.Set("X-Poll-Signature", "sha256="+SignWebhookPayload(webhook.Secret, body))
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(response.Body, 4096))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("Webhook answered %s", response.Status)
+	}
+
+	return nil
+}
+
+func recordWebhookAttempt(delivery *PollWebhookDelivery, err error, now time.Time) {
+	delivery.Attempts++
+
+	if err == nil {
+		delivery.Status = DeliveryDelivered
+		delivery.LastError = ""
+		return
+	}
+
+	delivery.LastError = err.Error()
+
+	if delivery.Attempts >= MaxWebhookAttempts {
+		delivery.Status = DeliveryDead
+		log.Println("Webhook delivery dead after", delivery.Attempts, "attempts:", delivery.ID, err)
+		return
+	}
+
+	delivery.NextAttemptAt = now.Add(WebhookBackoffAfter(delivery.Attempts))
+}
+
+//DeliverWebhooks runs the webhook deliveries every interval until done is closed.
+func DeliverWebhooks(webhookHandler WebhookHandler, client *http.Client, clock Clock, interval time.Duration,
+	done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			delivered, err := RunWebhookDeliveries(webhookHandler, client, clock())
+			if err != nil {
+				log.Println("Unable to deliver the webhooks", err)
+				continue
+			}
+
+			if delivered > 0 {
+				log.Println("Webhook deliveries done:", delivered)
+			}
+		}
+	}
+}
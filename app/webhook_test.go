@@ -0,0 +1,301 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chai2010/assert"
+
+	"gopkg.in/src-d/go-kallax.v1"
+)
+
+type receivedWebhook struct {
+	Header http.Header
+	Body   []byte
+}
+
+//newWebhookReceiver answers every post with the given status and keeps what it received.
+func newWebhookReceiver(status int) (*httptest.Server, chan receivedWebhook) {
+	received := make(chan receivedWebhook, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- receivedWebhook{Header: r.Header, Body: body}
+		w.WriteHeader(status)
+	}))
+
+	return server, received
+}
+
+func createDeliveryHandlerMock(webhook *PollWebhook, delivery *PollWebhookDelivery) *WebhookHandlerMock {
+	return &WebhookHandlerMock{
+		ClaimDueDeliveriesFunc: func(now time.Time) ([]*PollWebhookDelivery, error) {
+			return []*PollWebhookDelivery{delivery}, nil
+		},
+		FindWebhookFunc: func(ID kallax.ULID) (*PollWebhook, error) {
+			return webhook, nil
+		},
+		SaveDeliveryFunc: func(delivery PollWebhookDelivery) (PollWebhookDelivery, error) {
+			return delivery, nil
+		},
+	}
+}
+
+func newPendingDelivery(webhook *PollWebhook, attempts int) *PollWebhookDelivery {
+	return &PollWebhookDelivery{
+		ID:        kallax.NewULID(),
+		WebhookID: webhook.ID,
+		Event:     WebhookPollClosed,
+		Payload:   `{"event":"poll.closed"}`,
+		Status:    DeliveryPending,
+		Attempts:  attempts,
+	}
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	signature := SignWebhookPayload("key", []byte(`{"event":"poll.closed"}`))
+
+	assert.AssertEqual(t, "5fea1478aa1641f4cff3cf2db208a6189cf6057f68e2ee1c62e637223ec687bc", signature)
+}
+
+func TestWebhookBackoffDoubles(t *testing.T) {
+	assert.AssertEqual(t, 30*time.Second, WebhookBackoffAfter(1))
+	assert.AssertEqual(t, time.Minute, WebhookBackoffAfter(2))
+	assert.AssertEqual(t, 8*time.Minute, WebhookBackoffAfter(5))
+}
+
+func TestIsPublicIP(t *testing.T) {
+	for _, ip := range []string{"93.184.216.34", "8.8.8.8", "2606:2800:220:1:248:1893:25c8:1946"} {
+		assert.AssertTrue(t, IsPublicIP(net.ParseIP(ip)), ip)
+	}
+
+	for _, ip := range []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254",
+		"100.64.0.1", "0.0.0.0", "224.0.0.1", "255.255.255.255", "::1", "::", "fd00::1", "fe80::1",
+		"::ffff:127.0.0.1", "2001:db8::1"} {
+		assert.AssertFalse(t, IsPublicIP(net.ParseIP(ip)), ip)
+	}
+}
+
+func TestWebhookClientRefusesNonPublicAddresses(t *testing.T) {
+	server, received := newWebhookReceiver(http.StatusNoContent)
+	defer server.Close()
+
+	_, err := NewWebhookClient(time.Second).Post(server.URL+"/hook", "application/json", nil)
+
+	assert.AssertNotNil(t, err)
+	assert.AssertTrue(t, strings.Contains(err.Error(), "Webhooks can't reach 127.0.0.1"), err)
+	assert.AssertEqual(t, 0, len(received))
+}
+
+func TestWebhookClientRefusesRedirects(t *testing.T) {
+	request, _ := http.NewRequest("POST", "https://example.com/hook", nil)
+
+	err := NewWebhookClient(time.Second).CheckRedirect(request, []*http.Request{request})
+
+	assert.AssertEqual(t, errWebhookRedirect, err)
+}
+
+func TestRunWebhookDeliveriesPostsSignedPayload(t *testing.T) {
+	server, received := newWebhookReceiver(http.StatusNoContent)
+	defer server.Close()
+	webhook := &PollWebhook{ID: kallax.NewULID(), URL: server.URL + "/hook", Secret: "key"}
+	delivery := newPendingDelivery(webhook, 0)
+	handlerMock := createDeliveryHandlerMock(webhook, delivery)
+
+	delivered, err := RunWebhookDeliveries(handlerMock, server.Client(), time.Now())
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, 1, delivered)
+	posted := <-received
+	assert.AssertEqual(t, delivery.Payload, string(posted.Body))
+	assert.AssertEqual(t, "application/json", posted.Header.Get("Content-Type"))
+	assert.AssertEqual(t, WebhookPollClosed, posted.Header.Get("X-Poll-Event"))
+	assert.AssertEqual(t, delivery.ID.String(), posted.Header.Get("X-Poll-Delivery"))
+	assert.AssertEqual(t, "sha256="+SignWebhookPayload("key", posted.Body), posted.Header.Get("X-Poll-Signature"))
+
+	saved := handlerMock.SaveDeliveryCalls()[0].Delivery
+	assert.AssertEqual(t, DeliveryDelivered, saved.Status)
+	assert.AssertEqual(t, 1, saved.Attempts)
+}
+
+func TestRunWebhookDeliveriesRetriesWithBackoff(t *testing.T) {
+	server, received := newWebhookReceiver(http.StatusInternalServerError)
+	defer server.Close()
+	now := time.Date(2018, 12, 19, 12, 0, 0, 0, time.UTC)
+	webhook := &PollWebhook{ID: kallax.NewULID(), URL: server.URL, Secret: "key"}
+	handlerMock := createDeliveryHandlerMock(webhook, newPendingDelivery(webhook, 2))
+
+	delivered, err := RunWebhookDeliveries(handlerMock, server.Client(), now)
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, 0, delivered)
+	assert.AssertEqual(t, 1, len(received))
+	saved := handlerMock.SaveDeliveryCalls()[0].Delivery
+	assert.AssertEqual(t, DeliveryPending, saved.Status)
+	assert.AssertEqual(t, 3, saved.Attempts)
+	assert.AssertEqual(t, now.Add(2*time.Minute), saved.NextAttemptAt)
+	assert.AssertEqual(t, "Webhook answered 500 Internal Server Error", saved.LastError)
+}
+
+func TestRunWebhookDeliveriesLeavesDeliveryDeadAfterLastAttempt(t *testing.T) {
+	server, _ := newWebhookReceiver(http.StatusBadGateway)
+	defer server.Close()
+	webhook := &PollWebhook{ID: kallax.NewULID(), URL: server.URL, Secret: "key"}
+	handlerMock := createDeliveryHandlerMock(webhook, newPendingDelivery(webhook, MaxWebhookAttempts-1))
+
+	RunWebhookDeliveries(handlerMock, server.Client(), time.Now())
+
+	saved := handlerMock.SaveDeliveryCalls()[0].Delivery
+	assert.AssertEqual(t, DeliveryDead, saved.Status)
+	assert.AssertEqual(t, MaxWebhookAttempts, saved.Attempts)
+}
+
+func TestRunWebhookDeliveriesRetriesUnreachableWebhook(t *testing.T) {
+	server, _ := newWebhookReceiver(http.StatusOK)
+	server.Close()
+	webhook := &PollWebhook{ID: kallax.NewULID(), URL: server.URL, Secret: "key"}
+	handlerMock := createDeliveryHandlerMock(webhook, newPendingDelivery(webhook, 0))
+
+	delivered, err := RunWebhookDeliveries(handlerMock, server.Client(), time.Now())
+
+	assert.AssertNil(t, err)
+	assert.AssertEqual(t, 0, delivered)
+	saved := handlerMock.SaveDeliveryCalls()[0].Delivery
+	assert.AssertEqual(t, DeliveryPending, saved.Status)
+	assert.AssertTrue(t, saved.LastError != "")
+}
+
+func createNotifyHandlerMock(webhooks ...*PollWebhook) *WebhookHandlerMock {
+	return &WebhookHandlerMock{
+		FindWebhooksFunc: func(pollID kallax.ULID) ([]*PollWebhook, error) {
+			return webhooks, nil
+		},
+		SaveDeliveryFunc: func(delivery PollWebhookDelivery) (PollWebhookDelivery, error) {
+			return delivery, nil
+		},
+		ReachThresholdFunc: func(webhookID kallax.ULID, delivery PollWebhookDelivery) (bool, error) {
+			return true, nil
+		},
+	}
+}
+
+func TestNotifyWebhooksQueuesPublishedPoll(t *testing.T) {
+	now := time.Date(2018, 12, 19, 12, 0, 0, 0, time.UTC)
+	pollID := kallax.NewULID()
+	wanted := &PollWebhook{ID: kallax.NewULID(), PollID: pollID, OnPublish: true}
+	other := &PollWebhook{ID: kallax.NewULID(), PollID: pollID, OnClose: true}
+	handlerMock := createNotifyHandlerMock(wanted, other)
+
+	NotifyWebhooks(handlerMock, &PollVoteHandlerMock{}, fixedClock(now))(PollPublishedEvent{PollID: pollID, Status: PollOpen, At: now})
+
+	assert.AssertEqual(t, 1, len(handlerMock.SaveDeliveryCalls()))
+	delivery := handlerMock.SaveDeliveryCalls()[0].Delivery
+	assert.AssertEqual(t, wanted.ID, delivery.WebhookID)
+	assert.AssertEqual(t, DeliveryPending, delivery.Status)
+	assert.AssertEqual(t, now, delivery.NextAttemptAt)
+
+	var payload WebhookPayload
+	json.Unmarshal([]byte(delivery.Payload), &payload)
+	assert.AssertEqual(t, WebhookPollPublished, payload.Event)
+	assert.AssertEqual(t, pollID.String(), payload.PollID)
+	assert.AssertEqual(t, PollOpen, payload.Status)
+	assert.AssertEqual(t, delivery.ID.String(), payload.DeliveryID)
+}
+
+func TestNotifyWebhooksKeysDeliveryByEvent(t *testing.T) {
+	pollID := kallax.NewULID()
+	handlerMock := createNotifyHandlerMock(&PollWebhook{ID: kallax.NewULID(), PollID: pollID, OnClose: true})
+	closed := PollClosedEvent{PollID: pollID}
+	closed.Key = "poll.closed:01F8MECHZX3TBDSZ7XRADM79XE"
+
+	NotifyWebhooks(handlerMock, &PollVoteHandlerMock{}, time.Now)(closed)
+
+	assert.AssertEqual(t, closed.Key, handlerMock.SaveDeliveryCalls()[0].Delivery.EventKey)
+}
+
+func TestNotifyWebhooksQueuesReachedVoteThresholdOnce(t *testing.T) {
+	pollID := kallax.NewULID()
+	reached := &PollWebhook{ID: kallax.NewULID(), PollID: pollID, VoteThreshold: 3}
+	notReached := &PollWebhook{ID: kallax.NewULID(), PollID: pollID, VoteThreshold: 4}
+	alreadyNotified := &PollWebhook{ID: kallax.NewULID(), PollID: pollID, VoteThreshold: 2, ThresholdReached: true}
+	handlerMock := createNotifyHandlerMock(reached, notReached, alreadyNotified)
+	pollVoteHandlerMock := &PollVoteHandlerMock{
+		VotersOfFunc: func(pollID kallax.ULID) (int64, error) {
+			return 3, nil
+		},
+	}
+
+	NotifyWebhooks(handlerMock, pollVoteHandlerMock, time.Now)(VoteCastEvent{PollID: pollID})
+
+	assert.AssertEqual(t, 1, len(handlerMock.ReachThresholdCalls()))
+	assert.AssertEqual(t, reached.ID, handlerMock.ReachThresholdCalls()[0].WebhookID)
+	var payload WebhookPayload
+	json.Unmarshal([]byte(handlerMock.ReachThresholdCalls()[0].Delivery.Payload), &payload)
+	assert.AssertEqual(t, WebhookVotesReached, payload.Event)
+	assert.AssertEqual(t, int64(3), payload.Votes)
+	assert.AssertEqual(t, 0, len(handlerMock.SaveDeliveryCalls()))
+}
+
+func TestNotifyWebhooksQueuesVoteThresholdWhenHandedAgainAfterFailing(t *testing.T) {
+	pollID := kallax.NewULID()
+	webhook := &PollWebhook{ID: kallax.NewULID(), PollID: pollID, VoteThreshold: 3}
+	reached := false
+	store := createThresholdStoreMock(&reached)
+	store.FindAllFunc = func(q *PollWebhookQuery) ([]*PollWebhook, error) {
+		return []*PollWebhook{&PollWebhook{ID: webhook.ID, PollID: pollID, VoteThreshold: 3, ThresholdReached: reached}}, nil
+	}
+	failures := 1
+	store.SaveDeliveryFunc = func(record *PollWebhookDelivery) error {
+		if failures > 0 {
+			failures--
+			return fmt.Errorf("Connection lost")
+		}
+		return nil
+	}
+	pollVoteHandlerMock := &PollVoteHandlerMock{
+		VotersOfFunc: func(pollID kallax.ULID) (int64, error) {
+			return 3, nil
+		},
+	}
+	notify := NotifyWebhooks(WebhookHandlerImpl{Store: store}, pollVoteHandlerMock, time.Now)
+	vote := VoteCastEvent{PollID: pollID}
+	vote.Key = "vote.cast:01F8MECHZX3TBDSZ7XRADM79XE"
+
+	assert.AssertEqual(t, "Connection lost", notify(vote).Error())
+	assert.AssertFalse(t, reached)
+	assert.AssertNil(t, notify(vote))
+
+	assert.AssertTrue(t, reached)
+	assert.AssertEqual(t, 2, len(store.SaveDeliveryCalls()))
+	queued := store.SaveDeliveryCalls()[1].Record
+	assert.AssertEqual(t, webhook.ID, queued.WebhookID)
+	assert.AssertEqual(t, WebhookVotesReached, queued.Event)
+	assert.AssertEqual(t, vote.Key, queued.EventKey)
+}
+
+func TestNotifyWebhooksSkipsCountingVotersWithoutThresholds(t *testing.T) {
+	handlerMock := createNotifyHandlerMock(&PollWebhook{OnClose: true})
+	pollVoteHandlerMock := &PollVoteHandlerMock{}
+
+	NotifyWebhooks(handlerMock, pollVoteHandlerMock, time.Now)(VoteCastEvent{PollID: kallax.NewULID()})
+
+	assert.AssertEqual(t, 0, len(pollVoteHandlerMock.VotersOfCalls()))
+	assert.AssertEqual(t, 0, len(handlerMock.SaveDeliveryCalls()))
+}
+
+func TestNotifyWebhooksFailsWhenDeliveryCantBeQueued(t *testing.T) {
+	handlerMock := createNotifyHandlerMock(&PollWebhook{ID: kallax.NewULID(), OnPublish: true})
+	handlerMock.SaveDeliveryFunc = func(delivery PollWebhookDelivery) (PollWebhookDelivery, error) {
+		return delivery, fmt.Errorf("Connection lost")
+	}
+
+	err := NotifyWebhooks(handlerMock, &PollVoteHandlerMock{}, time.Now)(PollPublishedEvent{PollID: kallax.NewULID()})
+
+	assert.AssertEqual(t, "Connection lost", err.Error())
+}
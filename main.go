@@ -2,8 +2,11 @@ package main
 
 import (
 	"database/sql"
+	"expvar"
 	"log"
 	"net/http"
+	"os"
+	"time"
 
 	"gopkg.in/src-d/go-kallax.v1"
 
@@ -19,12 +22,24 @@ var sessionHandler *SessionHandlerImpl
 var pollHandler *PollHandlerImpl
 var pollOptionHandler *PollOptionHandlerImpl
 var pollVoteHandler *PollVoteHandlerImpl
+var webhookHandler *WebhookHandlerImpl
+var outboxHandler *OutboxHandlerImpl
+
+/////// Real time
+var tallyHub = NewTallyHub(8)
+var eventBus = NewAsyncEventBus(256)
+var outboxMetrics = &OutboxMetrics{}
 
 //CreateUserEndpointEntry ...
 func CreateUserEndpointEntry(w http.ResponseWriter, r *http.Request) {
 	CreateUser(createHTTPHelper(w, r), userHandler)
 }
 
+//ClaimUserEndpointEntry ...
+func ClaimUserEndpointEntry(w http.ResponseWriter, r *http.Request) {
+	ClaimUser(createHTTPHelper(w, r), userHandler, sessionHandler)
+}
+
 //VisitEndpointEntry ...
 func VisitEndpointEntry(w http.ResponseWriter, r *http.Request) {
 	Visit(createHTTPHelper(w, r), userHandler, sessionHandler)
@@ -35,6 +50,11 @@ func LoginEndpointEntry(w http.ResponseWriter, r *http.Request) {
 	Login(createHTTPHelper(w, r), userHandler, sessionHandler)
 }
 
+//LogoutEndpointEntry ...
+func LogoutEndpointEntry(w http.ResponseWriter, r *http.Request) {
+	Logout(createHTTPHelper(w, r), sessionHandler)
+}
+
 //StartCreatePollEndpointEntry ...
 func StartCreatePollEndpointEntry(w http.ResponseWriter, r *http.Request) {
 	StartCreatePoll(createHTTPHelper(w, r), pollHandler)
@@ -50,81 +70,84 @@ func RemoveOptionEndpointEntry(w http.ResponseWriter, r *http.Request) {
 	RemoveOption(createHTTPHelper(w, r), pollHandler, pollOptionHandler)
 }
 
+//ReorderOptionsEndpointEntry ...
+func ReorderOptionsEndpointEntry(w http.ResponseWriter, r *http.Request) {
+	ReorderOptions(createHTTPHelper(w, r), pollHandler, pollOptionHandler)
+}
+
 //PublishEndpointEntry ...
 func PublishEndpointEntry(w http.ResponseWriter, r *http.Request) {
 	Publish(createHTTPHelper(w, r), pollHandler, pollOptionHandler)
 }
 
+//ClosePollEndpointEntry ...
+func ClosePollEndpointEntry(w http.ResponseWriter, r *http.Request) {
+	ClosePoll(createHTTPHelper(w, r), pollHandler)
+}
+
+//ReopenPollEndpointEntry ...
+func ReopenPollEndpointEntry(w http.ResponseWriter, r *http.Request) {
+	ReopenPoll(createHTTPHelper(w, r), pollHandler)
+}
+
+//ArchivePollEndpointEntry ...
+func ArchivePollEndpointEntry(w http.ResponseWriter, r *http.Request) {
+	ArchivePoll(createHTTPHelper(w, r), pollHandler)
+}
+
 //CreateVoteEndpointEntry ...
 func CreateVoteEndpointEntry(w http.ResponseWriter, r *http.Request) {
-	CreateVote(createHTTPHelper(w, r), pollOptionHandler, pollVoteHandler)
+	CreateVote(createHTTPHelper(w, r), pollHandler, pollOptionHandler, pollVoteHandler, tallyHub)
 }
 
-//GetPoll ...
-func GetPoll(w http.ResponseWriter, r *http.Request) {
-	// ExecuteSessioned(w, r, func(session *Session) interface{} {
-	// 	vars := mux.Vars(r)
-	// 	id, err := kallax.NewULIDFromText(vars["id"])
-	// 	if err != nil {
-	// 		http.Error(w, err.Error(), http.StatusBadRequest)
-	// 		return nil
-	// 	}
+//ChangeVoteEndpointEntry ...
+func ChangeVoteEndpointEntry(w http.ResponseWriter, r *http.Request) {
+	ChangeVote(createHTTPHelper(w, r), pollHandler, pollOptionHandler, pollVoteHandler, tallyHub)
+}
 
-	// 	poll, errFind := FindPollByID(id)
-	// 	if errFind != nil {
-	// 		http.Error(w, errFind.Error(), http.StatusBadRequest)
-	// 		return nil
-	// 	}
+//RetractVoteEndpointEntry ...
+func RetractVoteEndpointEntry(w http.ResponseWriter, r *http.Request) {
+	RetractVote(createHTTPHelper(w, r), pollHandler, pollOptionHandler, pollVoteHandler, tallyHub)
+}
 
-	// 	return poll
-	// })
+//CreateWebhookEndpointEntry ...
+func CreateWebhookEndpointEntry(w http.ResponseWriter, r *http.Request) {
+	CreateWebhook(createHTTPHelper(w, r), pollHandler, webhookHandler)
 }
 
-//CountingPollVotes ...
-func CountingPollVotes(w http.ResponseWriter, r *http.Request) {
-	// ExecuteSessioned(w, r, func(session *Session) interface{} {
-	// 	vars := mux.Vars(r)
-	// 	id, err := kallax.NewULIDFromText(vars["id"])
-	// 	if err != nil {
-	// 		http.Error(w, err.Error(), http.StatusBadRequest)
-	// 		return make(map[string]float64)
-	// 	}
-	// 	return CountVotes(id)
-	// })
+//GetPollEndpointEntry ...
+func GetPollEndpointEntry(w http.ResponseWriter, r *http.Request) {
+	GetPoll(createHTTPHelper(w, r), pollHandler)
 }
 
-//GetPolls ...
-func GetPolls(w http.ResponseWriter, r *http.Request) {
-	// ExecuteSessioned(w, r, func(session *Session) interface{} {
-	// 	log.Println("Getting all")
-	// 	query := NewPollQuery().
-	// 		Order(kallax.Asc(Schema.Poll.CreatedAt))
-	// 	return FindPolls(w, query)
-	// })
+//CountingPollVotesEndpointEntry ...
+func CountingPollVotesEndpointEntry(w http.ResponseWriter, r *http.Request) {
+	CountingPollVotes(createHTTPHelper(w, r), pollHandler, pollOptionHandler, pollVoteHandler)
 }
 
-//GetPollsMine ...
-func GetPollsMine(w http.ResponseWriter, r *http.Request) {
-	// ExecuteSessioned(w, r, func(session *Session) interface{} {
-	// 	query := NewPollQuery().
-	// 		FindByOwner(session.UserID).
-	// 		Order(kallax.Asc(Schema.Poll.CreatedAt))
+//CountingPollVotersEndpointEntry ...
+func CountingPollVotersEndpointEntry(w http.ResponseWriter, r *http.Request) {
+	CountingPollVoters(createHTTPHelper(w, r), pollOptionHandler, pollVoteHandler)
+}
 
-	// 	return FindPolls(w, query)
-	// })
+//ExportPollEndpointEntry ...
+func ExportPollEndpointEntry(w http.ResponseWriter, r *http.Request) {
+	ExportPoll(createHTTPHelper(w, r), pollHandler, pollOptionHandler, pollVoteHandler)
 }
 
-// FindPolls ...
-func FindPolls(w http.ResponseWriter, query *PollQuery) []*Poll {
-	// polls, err := pollStore.FindAll(query)
+//StreamPollTallyEndpointEntry ...
+func StreamPollTallyEndpointEntry(w http.ResponseWriter, r *http.Request) {
+	StreamPollTally(createHTTPHelper(w, r), pollHandler, pollOptionHandler, pollVoteHandler, tallyHub)
+}
 
-	// if err != nil {
-	// 	http.Error(w, err.Error(), http.StatusBadRequest)
-	// 	return nil
-	// }
+//GetPollsEndpointEntry ...
+func GetPollsEndpointEntry(w http.ResponseWriter, r *http.Request) {
+	GetPolls(createHTTPHelper(w, r), pollHandler)
+}
 
-	// return polls
-	return make([]*Poll, 0)
+//GetPollsMineEndpointEntry ...
+func GetPollsMineEndpointEntry(w http.ResponseWriter, r *http.Request) {
+	GetPollsMine(createHTTPHelper(w, r), pollHandler)
 }
 
 //ConnectToDatabase ...
@@ -141,6 +164,8 @@ func ConnectToDatabase() {
 	pollOptionHandler = NewPollOptionHandler(db)
 	pollHandler = NewPollHandler(db, pollOptionHandler)
 	pollVoteHandler = NewPollVoteHandler(db)
+	webhookHandler = NewWebhookHandler(db)
+	outboxHandler = NewOutboxHandler(db)
 
 	log.Println("Successfuly connected!")
 }
@@ -154,7 +179,7 @@ func createHTTPHelper(w http.ResponseWriter, r *http.Request) *HTTPHelperImpl {
 			return err
 		}
 
-		session, errFind := sessionHandler.FindSessionByID(realID)
+		session, errFind := sessionHandler.FindValidSession(realID)
 		helper.Session = session
 		return errFind
 	}
@@ -166,34 +191,93 @@ func createHTTPHelper(w http.ResponseWriter, r *http.Request) *HTTPHelperImpl {
 func ConfigStartServer() {
 	router := mux.NewRouter()
 	router.HandleFunc("/users", CreateUserEndpointEntry).Methods("POST")
+	router.HandleFunc("/users/claim", ClaimUserEndpointEntry).Methods("POST")
 
 	router.HandleFunc("/visit", VisitEndpointEntry).Methods("POST")
 	router.HandleFunc("/login", LoginEndpointEntry).Methods("POST")
+	router.HandleFunc("/session", LogoutEndpointEntry).Methods("DELETE")
 
 	router.HandleFunc("/polls", StartCreatePollEndpointEntry).Methods("POST")
 	router.HandleFunc("/polls/{id}", AddOptionEndpointEntry).Methods("PUT")
 	router.HandleFunc("/polls/{id}", RemoveOptionEndpointEntry).Methods("DELETE")
+	router.HandleFunc("/polls/{id}/options/order", ReorderOptionsEndpointEntry).Methods("PUT")
 	router.HandleFunc("/polls/{id}/publish", PublishEndpointEntry).Methods("PUT")
+	router.HandleFunc("/polls/{id}/close", ClosePollEndpointEntry).Methods("PUT")
+	router.HandleFunc("/polls/{id}/reopen", ReopenPollEndpointEntry).Methods("PUT")
+	router.HandleFunc("/polls/{id}/archive", ArchivePollEndpointEntry).Methods("PUT")
 	router.HandleFunc("/polls/{id}/vote", CreateVoteEndpointEntry).Methods("POST")
-	router.HandleFunc("/polls/{id}", GetPoll).Methods("GET")
-	router.HandleFunc("/polls/{id}/counting", CountingPollVotes).Methods("GET")
-	router.HandleFunc("/polls", GetPolls).Methods("GET")
-	router.HandleFunc("/mine/polls", GetPollsMine).Methods("GET")
+	router.HandleFunc("/polls/{id}/vote", ChangeVoteEndpointEntry).Methods("PUT")
+	router.HandleFunc("/polls/{id}/vote", RetractVoteEndpointEntry).Methods("DELETE")
+	router.HandleFunc("/polls/{id}", GetPollEndpointEntry).Methods("GET")
+	router.HandleFunc("/polls/{id}/counting", CountingPollVotesEndpointEntry).Methods("GET")
+	router.HandleFunc("/polls/{id}/counting/voters", CountingPollVotersEndpointEntry).Methods("GET")
+	router.HandleFunc("/polls/{id}/export", ExportPollEndpointEntry).Methods("GET")
+	router.HandleFunc("/polls/{id}/stream", StreamPollTallyEndpointEntry).Methods("GET")
+	router.HandleFunc("/polls/{id}/webhooks", CreateWebhookEndpointEntry).Methods("POST")
+	router.HandleFunc("/polls", GetPollsEndpointEntry).Methods("GET")
+	router.HandleFunc("/mine/polls", GetPollsMineEndpointEntry).Methods("GET")
 
 	log.Println("Server running")
 	log.Fatal(http.ListenAndServe(":8000", router))
 }
 
+//StartDebugServer serves the expvar metrics on a listener of its own, reachable from the
+//host only, so they stay out of the public API.
+func StartDebugServer() {
+	router := http.NewServeMux()
+	router.Handle("/debug/vars", expvar.Handler())
+
+	go func() {
+		log.Fatal(http.ListenAndServe("localhost:6060", router))
+	}()
+}
+
+//RebuildTalliesCommand rebuilds the tallies of the polls whose IDs are given, or of every poll.
+func RebuildTalliesCommand(args []string) {
+	pollIDs := make([]kallax.ULID, 0, len(args))
+	for _, arg := range args {
+		ID, err := kallax.NewULIDFromText(arg)
+		if err != nil {
+			log.Fatalln("Invalid poll ID", arg, err)
+		}
+		pollIDs = append(pollIDs, ID)
+	}
+
+	rebuilt, err := RebuildTallies(pollHandler, pollVoteHandler, pollIDs)
+	if err != nil {
+		log.Fatalln("Unable to rebuild tallies after", rebuilt, "polls:", err)
+	}
+
+	log.Println("Tallies rebuilt:", rebuilt)
+}
+
 //main ...
 func main() {
 	ConnectToDatabase()
+
+	if len(os.Args) > 1 && os.Args[1] == "rebuild-tallies" {
+		RebuildTalliesCommand(os.Args[2:])
+		return
+	}
+
+	go SweepExpiredSessions(sessionHandler, 10*time.Minute, make(chan struct{}))
+	go SchedulePolls(pollHandler, time.Now, time.Minute, make(chan struct{}))
+	eventBus.Subscribe(func(event Event) error {
+		log.Println("Event", event.EventName(), KeyOf(event))
+		return nil
+	})
+	eventBus.Subscribe(NotifyWebhooks(webhookHandler, pollVoteHandler, time.Now),
+		EventPollPublished, EventPollClosed, EventVoteCast)
+	go DeliverWebhooks(webhookHandler, NewWebhookClient(10*time.Second), time.Now, 10*time.Second,
+		make(chan struct{}))
+	go RelayEvents(outboxHandler, eventBus.Sync(), outboxMetrics, time.Now, time.Second, make(chan struct{}))
+	expvar.Publish("outbox", expvar.Func(func() interface{} {
+		return outboxMetrics.Snapshot()
+	}))
+	StartDebugServer()
 	ConfigStartServer()
 }
 
 // TODOs (Improvements)
-// Sessions to expires
-// Sessions efemerals
 // Endpoint for published polls
-// Poll DTO for GETs
 // Split files by packages
-// Poll Options with defined order
@@ -0,0 +1,8 @@
+--session_expiration down
+BEGIN;
+
+drop index poll_session_expires_at_idx;
+
+alter table poll_session drop column expires_at;
+
+COMMIT;
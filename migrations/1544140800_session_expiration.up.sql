@@ -0,0 +1,12 @@
+--session_expiration up
+BEGIN;
+
+alter table poll_session add column expires_at timestamptz;
+
+update poll_session set expires_at = created_at + interval '1 hour';
+
+alter table poll_session alter column expires_at set not null;
+
+create index poll_session_expires_at_idx on poll_session (expires_at);
+
+COMMIT;
@@ -0,0 +1,8 @@
+--poll_option_position down
+BEGIN;
+
+drop index poll_option_position_idx;
+
+alter table poll_option drop column position;
+
+COMMIT;
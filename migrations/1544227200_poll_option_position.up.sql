@@ -0,0 +1,15 @@
+--poll_option_position up
+BEGIN;
+
+alter table poll_option add column position integer not null default 0;
+
+update poll_option o set position = numbered.position
+from (
+    select id, row_number() over (partition by poll_id order by id) - 1 as position
+    from poll_option
+) numbered
+where o.id = numbered.id;
+
+create index poll_option_position_idx on poll_option (poll_id, position);
+
+COMMIT;
@@ -0,0 +1,7 @@
+--unique_user_login down
+BEGIN;
+
+-- Duplicate logins renamed by the up migration are left as they are.
+drop index poll_user_login_idx;
+
+COMMIT;
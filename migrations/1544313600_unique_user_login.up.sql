@@ -0,0 +1,26 @@
+--unique_user_login up
+BEGIN;
+
+-- Logins were taken more than once before this index. The registered, then the oldest,
+-- user keeps each login; the others are renamed to login#id. They may belong to other
+-- people, so nothing moves to the user that keeps the login: their polls and votes stay
+-- with them, where they can be recovered, and their sessions are deleted.
+create temporary table poll_user_duplicate on commit drop as
+select id from (
+	select id,
+		first_value(id) over (partition by login order by (password <> '') desc, created_at, id) as keeper_id
+	from poll_user
+) ranked
+where id <> keeper_id;
+
+delete from poll_session s
+using poll_user_duplicate d
+where s.user_id = d.id;
+
+update poll_user u set login = u.login || '#' || u.id
+from poll_user_duplicate d
+where u.id = d.id;
+
+create unique index poll_user_login_idx on poll_user (login);
+
+COMMIT;
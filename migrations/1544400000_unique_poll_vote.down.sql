@@ -0,0 +1,6 @@
+--unique_poll_vote down
+BEGIN;
+
+drop index poll_vote_poll_user_idx;
+
+COMMIT;
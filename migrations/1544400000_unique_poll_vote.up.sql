@@ -0,0 +1,12 @@
+--unique_poll_vote up
+BEGIN;
+
+delete from poll_vote v
+using poll_vote kept
+where v.poll_id = kept.poll_id
+  and v.user_id = kept.user_id
+  and (v.created_at, v.id) > (kept.created_at, kept.id);
+
+create unique index poll_vote_poll_user_idx on poll_vote (poll_id, user_id);
+
+COMMIT;
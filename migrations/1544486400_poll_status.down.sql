@@ -0,0 +1,12 @@
+--poll_status down
+BEGIN;
+
+alter table poll add column published boolean not null default false;
+
+update poll set published = status <> 'draft';
+
+alter table poll drop constraint poll_status_check;
+
+alter table poll drop column status;
+
+COMMIT;
@@ -0,0 +1,13 @@
+--poll_status up
+BEGIN;
+
+alter table poll add column status text not null default 'draft';
+
+update poll set status = 'open' where published;
+
+alter table poll add constraint poll_status_check
+  check (status in ('draft', 'scheduled', 'open', 'closed', 'archived'));
+
+alter table poll drop column published;
+
+COMMIT;
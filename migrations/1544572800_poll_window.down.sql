@@ -0,0 +1,12 @@
+--poll_window down
+BEGIN;
+
+drop index poll_status_closes_at_idx;
+drop index poll_status_opens_at_idx;
+
+alter table poll drop constraint poll_window_check;
+
+alter table poll drop column closes_at;
+alter table poll drop column opens_at;
+
+COMMIT;
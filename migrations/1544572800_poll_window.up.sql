@@ -0,0 +1,13 @@
+--poll_window up
+BEGIN;
+
+alter table poll add column opens_at timestamptz;
+alter table poll add column closes_at timestamptz;
+
+alter table poll add constraint poll_window_check
+  check (opens_at is null or closes_at is null or closes_at > opens_at);
+
+create index poll_status_opens_at_idx on poll (status, opens_at);
+create index poll_status_closes_at_idx on poll (status, closes_at);
+
+COMMIT;
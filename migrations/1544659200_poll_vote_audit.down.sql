@@ -0,0 +1,6 @@
+--poll_vote_audit down
+BEGIN;
+
+DROP TABLE poll_vote_audit;
+
+COMMIT;
@@ -0,0 +1,17 @@
+--poll_vote_audit up
+BEGIN;
+
+CREATE TABLE poll_vote_audit (
+	id uuid NOT NULL PRIMARY KEY,
+	created_at timestamptz NOT NULL,
+	updated_at timestamptz NOT NULL,
+	vote_id uuid NOT NULL,
+	poll_id uuid NOT NULL REFERENCES poll(id),
+	user_id uuid NOT NULL REFERENCES poll_user(id),
+	previous_option text NOT NULL,
+	action text NOT NULL CHECK (action in ('changed', 'retracted'))
+);
+
+create index poll_vote_audit_vote_idx on poll_vote_audit (vote_id);
+
+COMMIT;
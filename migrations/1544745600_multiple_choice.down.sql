@@ -0,0 +1,10 @@
+--multiple_choice down
+BEGIN;
+
+DROP TABLE poll_vote_selection;
+
+alter table poll drop constraint poll_choice_range_check;
+alter table poll drop column max_choices;
+alter table poll drop column min_choices;
+
+COMMIT;
@@ -0,0 +1,30 @@
+--multiple_choice up
+BEGIN;
+
+alter table poll add column min_choices int not null default 1;
+alter table poll add column max_choices int not null default 1;
+
+alter table poll add constraint poll_choice_range_check
+  check (min_choices >= 0 and max_choices >= 0 and (max_choices = 0 or max_choices >= min_choices));
+
+CREATE TABLE poll_vote_selection (
+	id uuid NOT NULL PRIMARY KEY,
+	created_at timestamptz NOT NULL,
+	updated_at timestamptz NOT NULL,
+	vote_id uuid NOT NULL REFERENCES poll_vote(id) ON DELETE CASCADE,
+	poll_id uuid NOT NULL REFERENCES poll(id),
+	poll_option_id uuid NOT NULL REFERENCES poll_option(id)
+);
+
+create unique index poll_vote_selection_vote_option_idx on poll_vote_selection (vote_id, poll_option_id);
+create index poll_vote_selection_poll_option_idx on poll_vote_selection (poll_id, poll_option_id);
+
+-- Votes cast so far are single-choice and only know the content of the chosen option.
+-- When options of a poll share that content, the vote goes to the first of them only.
+INSERT INTO poll_vote_selection (id, created_at, updated_at, vote_id, poll_id, poll_option_id)
+SELECT DISTINCT ON (v.id) md5(v.id::text)::uuid, v.created_at, v.updated_at, v.id, v.poll_id, o.id
+FROM poll_vote v
+JOIN poll_option o ON o.poll_id = v.poll_id AND o.content = v.chosen_option
+ORDER BY v.id, o.position, o.id;
+
+COMMIT;
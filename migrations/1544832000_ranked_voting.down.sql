@@ -0,0 +1,12 @@
+--ranked_voting down
+BEGIN;
+
+drop index poll_vote_selection_vote_rank_idx;
+
+alter table poll_vote_selection drop column rank;
+
+alter table poll drop constraint poll_voting_method_check;
+
+alter table poll drop column voting_method;
+
+COMMIT;
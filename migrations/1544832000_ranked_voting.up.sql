@@ -0,0 +1,21 @@
+--ranked_voting up
+BEGIN;
+
+alter table poll add column voting_method text not null default 'plurality';
+
+alter table poll add constraint poll_voting_method_check
+  check (voting_method in ('plurality', 'ranked'));
+
+alter table poll_vote_selection add column rank int not null default 1;
+
+-- Selections saved so far have no order; rank them as they were inserted.
+update poll_vote_selection s set rank = ranked.rank
+from (
+  select id, row_number() over (partition by vote_id order by created_at, id) as rank
+  from poll_vote_selection
+) ranked
+where s.id = ranked.id;
+
+create unique index poll_vote_selection_vote_rank_idx on poll_vote_selection (vote_id, rank);
+
+COMMIT;
@@ -0,0 +1,15 @@
+--tally_methods down
+BEGIN;
+
+alter table poll_vote_selection drop column score;
+
+alter table poll drop constraint poll_max_score_check;
+
+alter table poll drop column max_score;
+
+alter table poll drop constraint poll_voting_method_check;
+
+alter table poll add constraint poll_voting_method_check
+  check (voting_method in ('plurality', 'ranked'));
+
+COMMIT;
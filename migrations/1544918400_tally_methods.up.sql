@@ -0,0 +1,16 @@
+--tally_methods up
+BEGIN;
+
+alter table poll drop constraint poll_voting_method_check;
+
+alter table poll add constraint poll_voting_method_check
+  check (voting_method in ('plurality', 'ranked', 'approval', 'score', 'borda'));
+
+alter table poll add column max_score int not null default 0;
+
+alter table poll add constraint poll_max_score_check
+  check (max_score >= 0);
+
+alter table poll_vote_selection add column score int not null default 0;
+
+COMMIT;
@@ -0,0 +1,8 @@
+--vote_option_fk down
+BEGIN;
+
+drop index poll_vote_poll_option_idx;
+
+alter table poll_vote drop column poll_option_id;
+
+COMMIT;
@@ -0,0 +1,21 @@
+--vote_option_fk up
+BEGIN;
+
+alter table poll_vote add column poll_option_id uuid references poll_option(id);
+
+-- The first selection of each vote is its option.
+update poll_vote v set poll_option_id = s.poll_option_id
+from poll_vote_selection s
+where s.vote_id = v.id and s.rank = 1;
+
+-- Votes without selections fall back to the content, when only one option of the poll has it.
+update poll_vote v set poll_option_id = o.id
+from poll_option o
+where v.poll_option_id is null
+  and o.poll_id = v.poll_id
+  and o.content = v.chosen_option
+  and (select count(*) from poll_option same where same.poll_id = o.poll_id and same.content = o.content) = 1;
+
+create index poll_vote_poll_option_idx on poll_vote (poll_option_id);
+
+COMMIT;
@@ -0,0 +1,6 @@
+--poll_tally down
+BEGIN;
+
+DROP TABLE poll_tally;
+
+COMMIT;
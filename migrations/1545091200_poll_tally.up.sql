@@ -0,0 +1,17 @@
+--poll_tally up
+BEGIN;
+
+CREATE TABLE poll_tally (
+	poll_id uuid NOT NULL REFERENCES poll(id),
+	poll_option_id uuid NOT NULL REFERENCES poll_option(id) ON DELETE CASCADE,
+	selections bigint NOT NULL DEFAULT 0,
+	PRIMARY KEY (poll_id, poll_option_id)
+);
+
+-- The tally starts from the selections registered so far.
+INSERT INTO poll_tally (poll_id, poll_option_id, selections)
+SELECT poll_id, poll_option_id, count(*)
+FROM poll_vote_selection
+GROUP BY poll_id, poll_option_id;
+
+COMMIT;
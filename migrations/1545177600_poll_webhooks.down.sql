@@ -0,0 +1,7 @@
+--poll_webhooks down
+BEGIN;
+
+DROP TABLE poll_webhook_delivery;
+DROP TABLE poll_webhook;
+
+COMMIT;
@@ -0,0 +1,35 @@
+--poll_webhooks up
+BEGIN;
+
+CREATE TABLE poll_webhook (
+	id uuid NOT NULL PRIMARY KEY,
+	created_at timestamptz NOT NULL,
+	updated_at timestamptz NOT NULL,
+	poll_id uuid NOT NULL REFERENCES poll(id),
+	url text NOT NULL,
+	secret text NOT NULL,
+	on_publish boolean NOT NULL DEFAULT false,
+	on_close boolean NOT NULL DEFAULT false,
+	vote_threshold int NOT NULL DEFAULT 0,
+	threshold_reached boolean NOT NULL DEFAULT false
+);
+
+create index poll_webhook_poll_idx on poll_webhook (poll_id);
+
+CREATE TABLE poll_webhook_delivery (
+	id uuid NOT NULL PRIMARY KEY,
+	created_at timestamptz NOT NULL,
+	updated_at timestamptz NOT NULL,
+	webhook_id uuid NOT NULL REFERENCES poll_webhook(id) ON DELETE CASCADE,
+	event text NOT NULL,
+	payload text NOT NULL,
+	status text NOT NULL CHECK (status in ('pending', 'delivered', 'dead')),
+	attempts int NOT NULL DEFAULT 0,
+	next_attempt_at timestamptz NOT NULL,
+	last_error text NOT NULL DEFAULT ''
+);
+
+-- The worker only looks for pending deliveries that are due.
+create index poll_webhook_delivery_due_idx on poll_webhook_delivery (next_attempt_at) where status = 'pending';
+
+COMMIT;
@@ -0,0 +1,8 @@
+--poll_outbox down
+BEGIN;
+
+drop index poll_webhook_delivery_event_idx;
+alter table poll_webhook_delivery drop column event_key;
+DROP TABLE poll_outbox;
+
+COMMIT;
@@ -0,0 +1,21 @@
+--poll_outbox up
+BEGIN;
+
+CREATE TABLE poll_outbox (
+	id uuid NOT NULL PRIMARY KEY,
+	created_at timestamptz NOT NULL,
+	updated_at timestamptz NOT NULL,
+	event text NOT NULL,
+	payload text NOT NULL,
+	published boolean NOT NULL DEFAULT false,
+	published_at timestamptz
+);
+
+-- The relay only looks for the events still to be published, oldest first.
+create index poll_outbox_pending_idx on poll_outbox (created_at) where not published;
+
+-- An event relayed again must not queue a second delivery to the same webhook.
+alter table poll_webhook_delivery add column event_key text not null default '';
+create unique index poll_webhook_delivery_event_idx on poll_webhook_delivery (webhook_id, event_key) where event_key <> '';
+
+COMMIT;
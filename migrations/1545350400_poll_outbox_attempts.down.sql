@@ -0,0 +1,12 @@
+--poll_outbox_attempts down
+BEGIN;
+
+drop index poll_outbox_pending_idx;
+create index poll_outbox_pending_idx on poll_outbox (created_at) where not published;
+
+alter table poll_outbox drop column dead;
+alter table poll_outbox drop column last_error;
+alter table poll_outbox drop column next_attempt_at;
+alter table poll_outbox drop column attempts;
+
+COMMIT;
@@ -0,0 +1,14 @@
+--poll_outbox_attempts up
+BEGIN;
+
+-- Events whose handlers failed stay pending and are relayed again after a wait; the ones
+-- that can't be decoded or keep failing are left dead.
+alter table poll_outbox add column attempts int not null default 0;
+alter table poll_outbox add column next_attempt_at timestamptz not null default now();
+alter table poll_outbox add column last_error text not null default '';
+alter table poll_outbox add column dead boolean not null default false;
+
+drop index poll_outbox_pending_idx;
+create index poll_outbox_pending_idx on poll_outbox (created_at) where not published and not dead;
+
+COMMIT;